@@ -0,0 +1,356 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/alert_rules [post]
+// @Summary Create an alert rule
+// @Description API for creating an alert rule. Kinds are STATUS_DURATION, ETA_NEAR_PICKUP, ETA_PASSED (threshold in minutes), LATE_LOAD (addressed to the dispatcher of the load) and DOCUMENT_EXPIRING (threshold in days). Statuses, channels (INBOX, EMAIL, WEBHOOK) and recipients are comma separated
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param rule body swag.CreateUpdateAlertRule true "Alert rule data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateAlertRule(c *gin.Context) {
+	var ruleModel swag.CreateUpdateAlertRule
+	if !BindJSON(c, &ruleModel) {
+		return
+	}
+
+	id, err := h.service.Alert().CreateRule(c.Request.Context(), alertRuleFromSwag(ruleModel))
+	if err != nil {
+		HandleError(c, "Error while creating an alert rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alert_rules/{rule_id} [put]
+// @Summary Update an alert rule
+// @Description API for updating an alert rule
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param rule_id path string true "Alert rule ID"
+// @Param rule body swag.CreateUpdateAlertRule true "Alert rule data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateAlertRule(c *gin.Context) {
+	var ruleModel swag.CreateUpdateAlertRule
+
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid alert rule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &ruleModel) {
+		return
+	}
+
+	rule := alertRuleFromSwag(ruleModel)
+	rule.Id = ruleId
+
+	if err := h.service.Alert().UpdateRule(c.Request.Context(), rule); err != nil {
+		HandleError(c, "Error while updating the alert rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Alert rule updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alert_rules/{rule_id} [delete]
+// @Summary Delete an alert rule
+// @Description API for deleting an alert rule
+// @Tags alerts
+// @Param rule_id path string true "Alert rule ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteAlertRule(c *gin.Context) {
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid alert rule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Alert().DeleteRule(c.Request.Context(), models.RequestId{Id: ruleId})
+	if err != nil {
+		HandleError(c, "Error while deleting the alert rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Alert rule deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alert_rules/{rule_id} [get]
+// @Summary Get an alert rule by ID
+// @Description API for retrieving an alert rule by ID
+// @Tags alerts
+// @Param rule_id path string true "Alert rule ID"
+// @Success 200 {object} models.AlertRule
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAlertRule(c *gin.Context) {
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid alert rule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	rule, err := h.service.Alert().GetRule(c.Request.Context(), models.RequestId{Id: ruleId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the alert rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alert_rules [get]
+// @Summary Get all alert rules
+// @Description API for retrieving all alert rules
+// @Tags alerts
+// @Success 200 {array} models.AlertRule
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllAlertRules(c *gin.Context) {
+	rules, err := h.service.Alert().GetRules(c.Request.Context())
+	if err != nil {
+		HandleError(c, "Error while retrieving alert rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alerts [get]
+// @Summary Get all alerts
+// @Description API for retrieving alerts. With inbox=true only the alerts delivered to the in-app inbox are returned
+// @Tags alerts
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of alerts per page"
+// @Param rule_id query string false "Alert rule ID"
+// @Param status query string false "OPEN, ACKNOWLEDGED or RESOLVED"
+// @Param severity query string false "INFO, WARNING or CRITICAL"
+// @Param inbox query bool false "Only alerts of the in-app inbox"
+// @Param employee_id query string false "Only alerts addressed to this employee"
+// @Success 200 {object} models.GetAllAlertsResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllAlerts(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	ruleId, err := ParseUUIDQueryParam(c, "rule_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid alert rule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	employeeId, err := ParseUUIDQueryParam(c, "employee_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid employee ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	alerts, err := h.service.Alert().GetAll(c.Request.Context(), models.GetAllAlertsReq{
+		Page:       page,
+		Limit:      limit,
+		RuleId:     ruleId,
+		Status:     strings.ToUpper(c.Query("status")),
+		Severity:   strings.ToUpper(c.Query("severity")),
+		Inbox:      c.Query("inbox") == "true",
+		EmployeeId: employeeId,
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving alerts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alerts/{alert_id} [get]
+// @Summary Get an alert by ID
+// @Description API for retrieving an alert with its rule
+// @Tags alerts
+// @Param alert_id path string true "Alert ID"
+// @Success 200 {object} models.Alert
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAlert(c *gin.Context) {
+	alertId, err := uuid.Parse(c.Param("alert_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid alert ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	alert, err := h.service.Alert().Get(c.Request.Context(), models.RequestId{Id: alertId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the alert", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alerts/{alert_id}/ack [post]
+// @Summary Acknowledge an alert
+// @Description API for acknowledging an open alert. It is not sent again until it resolves
+// @Tags alerts
+// @Param alert_id path string true "Alert ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) AcknowledgeAlert(c *gin.Context) {
+	alertId, err := uuid.Parse(c.Param("alert_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid alert ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Alert().Acknowledge(c.Request.Context(), models.RequestId{Id: alertId}, models.RequestId{Id: userId})
+	if err != nil {
+		HandleError(c, "Error while acknowledging the alert", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Alert acknowledged successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/alerts/{alert_id}/snooze [post]
+// @Summary Snooze an alert
+// @Description API for holding back notifications of an alert for the given number of minutes
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param alert_id path string true "Alert ID"
+// @Param snooze body swag.SnoozeAlert true "Snooze duration"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) SnoozeAlert(c *gin.Context) {
+	var snoozeModel swag.SnoozeAlert
+
+	alertId, err := uuid.Parse(c.Param("alert_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid alert ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &snoozeModel) {
+		return
+	}
+
+	err = h.service.Alert().Snooze(c.Request.Context(), models.RequestId{Id: alertId}, snoozeModel.Minutes)
+	if err != nil {
+		HandleError(c, "Error while snoozing the alert", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Alert snoozed successfully",
+	})
+}
+
+func alertRuleFromSwag(m swag.CreateUpdateAlertRule) *models.AlertRule {
+	return &models.AlertRule{
+		Name:       m.Name,
+		Kind:       strings.ToUpper(m.Kind),
+		Statuses:   strings.ToUpper(m.Statuses),
+		Threshold:  m.Threshold,
+		Severity:   strings.ToUpper(m.Severity),
+		Channels:   strings.ToUpper(m.Channels),
+		Recipients: m.Recipients,
+		WebhookURL: m.WebhookURL,
+		Cooldown:   m.Cooldown,
+		Enabled:    m.Enabled,
+	}
+}
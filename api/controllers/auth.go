@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"backend/etc/apperr"
 	"backend/models"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -16,23 +17,21 @@ import (
 // @Param employee body models.AuthReq true "Employee data"
 // @Success 200 {object} models.AuthResp
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 401 {object} models.ResponseError "Invalid username or password"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) Login(c *gin.Context) {
 	var req models.AuthReq
 	if err := c.ShouldBind(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "body did not contain required fields",
-			ErrorCode:    "BAD_REQUEST",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	resp, err := h.service.Employee().Auth(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: err.Error(),
-			ErrorCode:    "SERVER_ERROR",
-		})
+		HandleError(c, "Error while logging in", err)
 		return
 	}
 
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/cargos/{cargo_id} [get]
+// @Summary Get a cargo by ID
+// @Description API for retrieving an active or historical load with its stops, driver and dispatcher
+// @Tags cargo
+// @Param cargo_id path string true "Cargo ID"
+// @Success 200 {object} models.CargoResponse
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetCargo(c *gin.Context) {
+	cargoId, err := uuid.Parse(c.Param("cargo_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid cargo ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	cargo, err := h.service.Cargo().Get(c.Request.Context(), models.RequestId{Id: cargoId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the cargo", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cargo)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/cargos [get]
+// @Summary Get all cargos
+// @Description API for listing and searching active and historical loads
+// @Tags cargo
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of cargos per page"
+// @Param search query string false "Load ID, provider or lane"
+// @Param cargo_id query string false "Load ID"
+// @Param provider query string false "Provider name"
+// @Param provider_id query string false "Provider ID"
+// @Param from query string false "Pickup location"
+// @Param to query string false "Delivery location"
+// @Param pick_up_from query string false "Pickup date from (2006-01-02)"
+// @Param pick_up_to query string false "Pickup date to, inclusive (2006-01-02)"
+// @Param employee_id query string false "Dispatcher ID"
+// @Param dispatcher_name query string false "Dispatcher name"
+// @Param driver_id query string false "Driver ID"
+// @Param status query string false "ACTIVE, DELIVERED or CANCELLED"
+// @Success 200 {object} models.GetAllCargosResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllCargos(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	req := models.GetAllCargosReq{
+		Page:           page,
+		Limit:          limit,
+		Search:         c.Query("search"),
+		CargoID:        c.Query("cargo_id"),
+		Provider:       c.Query("provider"),
+		From:           c.Query("from"),
+		To:             c.Query("to"),
+		DispatcherName: c.Query("dispatcher_name"),
+		Status:         c.Query("status"),
+	}
+
+	if req.Status != "" && req.Status != models.CargoStatusActive &&
+		req.Status != models.CargoStatusDelivered && req.Status != models.CargoStatusCancelled {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid status: " + req.Status,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	req.ProviderId, err = ParseUUIDQueryParam(c, "provider_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	req.EmployeeId, err = ParseUUIDQueryParam(c, "employee_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid employee ID: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	req.DriverId, err = ParseUUIDQueryParam(c, "driver_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if value := c.Query("pick_up_from"); value != "" {
+		pickUpFrom, err := time.Parse(validation.DateLayout, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid pick_up_from: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		req.PickUpFrom = &pickUpFrom
+	}
+
+	if value := c.Query("pick_up_to"); value != "" {
+		pickUpTo, err := time.Parse(validation.DateLayout, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid pick_up_to: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		pickUpTo = pickUpTo.AddDate(0, 0, 1)
+		req.PickUpTo = &pickUpTo
+	}
+
+	cargos, err := h.service.Cargo().GetAll(c.Request.Context(), req)
+	if err != nil {
+		HandleError(c, "Error while retrieving cargos", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cargos)
+}
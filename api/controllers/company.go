@@ -1,7 +1,8 @@
 package controllers
 
 import (
-	"backend/etc/filters"
+	"backend/etc/apperr"
+	"backend/etc/validation"
 	"backend/models"
 	"backend/models/swag"
 	"github.com/gin-gonic/gin"
@@ -20,70 +21,19 @@ import (
 // @Param company body swag.CreateUpdateCompany true "Company data"
 // @Success 200 {object} models.ResponseId
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) CreateCompany(c *gin.Context) {
 	var companyModel swag.CreateUpdateCompany
-	if err := c.ShouldBindJSON(&companyModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &companyModel) {
 		return
 	}
 
-	startDate, err := time.Parse("2006-01-02", companyModel.StartDate)
+	startDate, err := time.Parse(validation.DateLayout, companyModel.StartDate)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid Start date format: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.Name == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Name can't be empty",
-			ErrorCode:    "Bad Request - Name can't be empty",
-		})
-		return
-	}
-
-	if companyModel.DOT <= 0 {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Dot must be greater than zero",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.SCAC == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "SAC can't be empty",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if !filters.ValidatePhoneNumber(companyModel.Number) {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Invalid Number",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.MC <= 0 {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Mc can't be zero",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.Address == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Address can't be empty",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -100,10 +50,7 @@ func (h *Controller) CreateCompany(c *gin.Context) {
 
 	id, err := h.service.Company().Create(c.Request.Context(), &company)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while creating a company: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while creating a company", err)
 		return
 	}
 
@@ -121,6 +68,7 @@ func (h *Controller) CreateCompany(c *gin.Context) {
 // @Param company body swag.CreateUpdateCompany true "Company data"
 // @Success 200 {object} models.ResponseSuccess
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) UpdateCompany(c *gin.Context) {
 	var companyModel swag.CreateUpdateCompany
@@ -130,64 +78,12 @@ func (h *Controller) UpdateCompany(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid company ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if err := c.ShouldBindJSON(&companyModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.Name == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Name can't be empty",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.DOT <= 0 {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Dot must be greater than zero",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.SCAC == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "SAC can't be empty",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if !filters.ValidatePhoneNumber(companyModel.Number) {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Invalid Number",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.MC <= 0 {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Mc can't be zero",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if companyModel.Address == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Address can't be empty",
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &companyModel) {
 		return
 	}
 
@@ -202,10 +98,7 @@ func (h *Controller) UpdateCompany(c *gin.Context) {
 	}
 
 	if err := h.service.Company().Update(c.Request.Context(), &company); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while updating the company: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while updating the company", err)
 		return
 	}
 
@@ -229,17 +122,14 @@ func (h *Controller) DeleteCompany(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid company ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = h.service.Company().Delete(c.Request.Context(), models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while deleting the company: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while deleting the company", err)
 		return
 	}
 
@@ -256,6 +146,7 @@ func (h *Controller) DeleteCompany(c *gin.Context) {
 // @Param company_id path string true "Company ID"
 // @Success 200 {object} models.Company
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) GetCompany(c *gin.Context) {
 	idStr := c.Param("company_id")
@@ -263,17 +154,14 @@ func (h *Controller) GetCompany(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid company ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	company, err := h.service.Company().Get(c.Request.Context(), models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving the company: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving the company", err)
 		return
 	}
 
@@ -296,7 +184,7 @@ func (h *Controller) GetAllCompanies(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid page: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -305,7 +193,7 @@ func (h *Controller) GetAllCompanies(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid limit: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -317,10 +205,7 @@ func (h *Controller) GetAllCompanies(c *gin.Context) {
 
 	companies, err := h.service.Company().GetAll(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving companies: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving companies", err)
 		return
 	}
 
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/drivers/{driver_id}/compliance [put]
+// @Summary Save a driver's compliance documents
+// @Description API for saving CDL, medical card, drug test and MVR data of a driver. Dates use the 2006-01-02 format
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param driver_id path string true "Driver ID"
+// @Param compliance body swag.SaveDriverCompliance true "Compliance data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) SaveDriverCompliance(c *gin.Context) {
+	var complianceModel swag.SaveDriverCompliance
+
+	driverId, err := uuid.Parse(c.Param("driver_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &complianceModel) {
+		return
+	}
+
+	class := strings.ToUpper(complianceModel.CDLClass)
+	dateFields := []string{
+		complianceModel.CDLExpiry,
+		complianceModel.MedicalCardExpiry,
+		complianceModel.LastDrugTest,
+		complianceModel.NextDrugTest,
+		complianceModel.MVRReviewedAt,
+		complianceModel.MVRNextReview,
+	}
+	dates := make([]*time.Time, len(dateFields))
+	for i, dateStr := range dateFields {
+		if dateStr == "" {
+			continue
+		}
+		parsed, err := time.Parse(validation.DateLayout, dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid date format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		dates[i] = &parsed
+	}
+
+	err = h.service.Compliance().Save(c.Request.Context(), &models.DriverCompliance{
+		DriverId:          driverId,
+		CDLNumber:         complianceModel.CDLNumber,
+		CDLState:          strings.ToUpper(complianceModel.CDLState),
+		CDLClass:          class,
+		CDLExpiry:         dates[0],
+		Endorsements:      strings.ToUpper(complianceModel.Endorsements),
+		MedicalCardExpiry: dates[1],
+		LastDrugTest:      dates[2],
+		NextDrugTest:      dates[3],
+		MVRReviewedAt:     dates[4],
+		MVRNextReview:     dates[5],
+	})
+	if err != nil {
+		HandleError(c, "Error while saving driver compliance", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Driver compliance saved successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/drivers/{driver_id}/compliance [get]
+// @Summary Get a driver's compliance documents
+// @Description API for retrieving the compliance documents and status of a driver
+// @Tags compliance
+// @Param driver_id path string true "Driver ID"
+// @Success 200 {object} models.DriverCompliance
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetDriverCompliance(c *gin.Context) {
+	driverId, err := uuid.Parse(c.Param("driver_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	compliance, err := h.service.Compliance().Get(c.Request.Context(), driverId)
+	if err != nil {
+		HandleError(c, "Error while retrieving driver compliance", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, compliance)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/compliance/expiring [get]
+// @Summary Get drivers with expiring documents
+// @Description API for retrieving drivers whose documents expire within the given number of days, including already expired ones
+// @Tags compliance
+// @Param days query int false "Days ahead (default 30)"
+// @Success 200 {object} models.GetExpiringComplianceResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetExpiringCompliance(c *gin.Context) {
+	days, err := ParseIntegerQueryParam(c, "days")
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid days: " + c.Query("days"),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	if c.Query("days") == "" {
+		days = models.ComplianceWarnDays
+	}
+
+	resp, err := h.service.Compliance().Expiring(c.Request.Context(), int(days))
+	if err != nil {
+		HandleError(c, "Error while retrieving expiring documents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,9 +1,17 @@
 package controllers
 
 import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
 	"backend/service"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
 	"strconv"
+	"strings"
 )
 
 type Controller struct {
@@ -14,6 +22,67 @@ func NewController(serviceS service.IService) *Controller {
 	return &Controller{service: serviceS}
 }
 
+// HandleError responds with the status and code of the apperr kind of err.
+// Errors without one are unexpected and logged, they are a 500.
+func HandleError(c *gin.Context, message string, err error) {
+	appErr := apperr.As(err)
+	if appErr.Kind == apperr.KindInternal {
+		slog.ErrorContext(c.Request.Context(), message, "error", err)
+	}
+
+	c.JSON(apperr.Status(appErr.Kind), models.ResponseError{
+		ErrorMessage: message + ": " + appErr.Message,
+		ErrorCode:    appErr.Code,
+		Fields:       appErr.Fields,
+	})
+}
+
+// BindJSON decodes the body into req and checks its binding tags. A body
+// that is not JSON of the right shape is a 400, one that fails the checks a
+// 422 listing every invalid field. It reports whether the handler can go on.
+func BindJSON(c *gin.Context, req any) bool {
+	err := c.ShouldBindJSON(req)
+	if err == nil {
+		return true
+	}
+
+	if fields := validation.Fields(err); fields != nil {
+		HandleError(c, "Invalid request body", apperr.Invalid(fields))
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, models.ResponseError{
+		ErrorMessage: "Error while binding JSON: " + err.Error(),
+		ErrorCode:    apperr.CodeBadRequest,
+	})
+	return false
+}
+
+// ParseIfMatch returns the version the If-Match header makes an update
+// conditional on, or 0 when there is no header or it is "*". Weak tags are
+// refused, as If-Match only matches strong ones.
+func ParseIfMatch(c *gin.Context) (int64, error) {
+	etag := strings.TrimSpace(c.GetHeader("If-Match"))
+	if etag == "" || etag == "*" {
+		return 0, nil
+	}
+	if strings.HasPrefix(etag, "W/") {
+		return 0, fmt.Errorf("%s is a weak entity tag", etag)
+	}
+
+	version, err := strconv.Unquote(etag)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not an entity tag", etag)
+	}
+
+	return strconv.ParseInt(version, 10, 64)
+}
+
+// SetETag tags the response with the version of the record it returns.
+func SetETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
 func ParsePageQueryParam(c *gin.Context) (uint64, error) {
 	pageStr := c.Query("page")
 	if pageStr == "" {
@@ -57,3 +126,24 @@ func ParseIntegerQueryParam(c *gin.Context, query string) (int64, error) {
 
 	return intA, nil
 }
+
+func ParseOptionalUUID(idStr string) (*uuid.UUID, error) {
+	if idStr == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func ParseUUIDQueryParam(c *gin.Context, query string) (uuid.UUID, error) {
+	idStr := c.Query(query)
+	if idStr == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(idStr)
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{header: "", want: 0},
+		{header: "*", want: 0},
+		{header: `"7"`, want: 7},
+		{header: ` "12" `, want: 12},
+		{header: `W/"7"`, wantErr: true},
+		{header: "7", wantErr: true},
+		{header: `"seven"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPut, "/v1/logistics/1", nil)
+		if tt.header != "" {
+			c.Request.Header.Set("If-Match", tt.header)
+		}
+
+		got, err := ParseIfMatch(c)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseIfMatch(%q) = %d, %v, want %d, error %t", tt.header, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
 	"backend/models"
 	"backend/models/swag"
 	"github.com/gin-gonic/gin"
@@ -19,14 +21,11 @@ import (
 // @Param driver body swag.CreateUpdateDriver true "Driver data"
 // @Success 200 {object} models.ResponseId
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) CreateDriver(c *gin.Context) {
 	var driverModel swag.CreateUpdateDriver
-	if err := c.ShouldBindJSON(&driverModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &driverModel) {
 		return
 	}
 
@@ -34,73 +33,25 @@ func (h *Controller) CreateDriver(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid Company ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	bTime, err := time.Parse("2006-01-02", driverModel.Birthday)
+	bTime, err := time.Parse(validation.DateLayout, driverModel.Birthday)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid Birthday format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	startDate, err := time.Parse("2006-01-02", driverModel.StartDate)
+	startDate, err := time.Parse(validation.DateLayout, driverModel.StartDate)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid Start Date format: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Name == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Name field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Surname == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Surname field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.TruckNumber == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "TruckNumber field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Mail == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Mail field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Type == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Type field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Position == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Position field is required",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -120,10 +71,7 @@ func (h *Controller) CreateDriver(c *gin.Context) {
 
 	id, err := h.service.Driver().Create(c.Request.Context(), &driver)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while creating a driver: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while creating a driver", err)
 		return
 	}
 
@@ -141,6 +89,7 @@ func (h *Controller) CreateDriver(c *gin.Context) {
 // @Param driver body swag.CreateUpdateDriver true "Driver data"
 // @Success 200 {object} models.ResponseSuccess
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) UpdateDriver(c *gin.Context) {
 	var driverModel swag.CreateUpdateDriver
@@ -150,16 +99,12 @@ func (h *Controller) UpdateDriver(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid driver ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if err := c.ShouldBindJSON(&driverModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &driverModel) {
 		return
 	}
 
@@ -167,64 +112,16 @@ func (h *Controller) UpdateDriver(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid Company ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	bTime, err := time.Parse("2006-01-02", driverModel.Birthday)
+	bTime, err := time.Parse(validation.DateLayout, driverModel.Birthday)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid Birthday format: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Name == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Name field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Surname == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Surname field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.TruckNumber == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "TruckNumber field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Mail == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Mail field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Type == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Type field is required",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	if driverModel.Position == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Position field is required",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -243,10 +140,7 @@ func (h *Controller) UpdateDriver(c *gin.Context) {
 	}
 
 	if err := h.service.Driver().Update(c.Request.Context(), &driver); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while updating the driver: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while updating the driver", err)
 		return
 	}
 
@@ -270,17 +164,14 @@ func (h *Controller) DeleteDriver(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid driver ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = h.service.Driver().Delete(c.Request.Context(), models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while deleting the driver: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while deleting the driver", err)
 		return
 	}
 
@@ -297,6 +188,7 @@ func (h *Controller) DeleteDriver(c *gin.Context) {
 // @Param driver_id path string true "Driver ID"
 // @Success 200 {object} models.Driver
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) GetDriver(c *gin.Context) {
 	idStr := c.Param("driver_id")
@@ -304,17 +196,14 @@ func (h *Controller) GetDriver(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid driver ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	driver, err := h.service.Driver().Get(c.Request.Context(), models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving the driver: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving the driver", err)
 		return
 	}
 
@@ -342,7 +231,7 @@ func (h *Controller) GetAllDrivers(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid page: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -351,7 +240,7 @@ func (h *Controller) GetAllDrivers(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid limit: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -361,7 +250,7 @@ func (h *Controller) GetAllDrivers(c *gin.Context) {
 	if typeOfDriver != "" && typeOfDriver != "SOLO" && typeOfDriver != "TEAM" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid type of driver: " + typeOfDriver,
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -370,7 +259,7 @@ func (h *Controller) GetAllDrivers(c *gin.Context) {
 	if position != "" && position != "CO" && position != "OW" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid position: " + position,
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -382,7 +271,7 @@ func (h *Controller) GetAllDrivers(c *gin.Context) {
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ResponseError{
 				ErrorMessage: "Invalid company ID format: " + err.Error(),
-				ErrorCode:    "Bad Request",
+				ErrorCode:    apperr.CodeBadRequest,
 			})
 			return
 		}
@@ -402,10 +291,7 @@ func (h *Controller) GetAllDrivers(c *gin.Context) {
 
 	drivers, err := h.service.Driver().GetAll(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving drivers: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving drivers", err)
 		return
 	}
 
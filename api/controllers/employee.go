@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"backend/etc/apperr"
 	"backend/etc/helpers"
+	"backend/etc/validation"
 	"backend/models"
 	"backend/models/swag"
 	"github.com/gin-gonic/gin"
@@ -21,31 +23,28 @@ import (
 // @Param employee body swag.CreateUpdateEmployee true "Employee data"
 // @Success 200 {object} models.ResponseId
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) CreateEmployee(c *gin.Context) {
 	var employeeModel swag.CreateUpdateEmployee
-	if err := c.ShouldBindJSON(&employeeModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &employeeModel) {
 		return
 	}
 
-	bDay, err := time.Parse("2006-01-02", employeeModel.Birthday)
+	bDay, err := time.Parse(validation.DateLayout, employeeModel.Birthday)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing birthday: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	startDate, err := time.Parse("2006-01-02", employeeModel.StartDate)
+	startDate, err := time.Parse(validation.DateLayout, employeeModel.StartDate)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing start date: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -54,7 +53,7 @@ func (h *Controller) CreateEmployee(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while generating password: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -76,10 +75,7 @@ func (h *Controller) CreateEmployee(c *gin.Context) {
 
 	id, err := h.service.Employee().Create(c.Request.Context(), &employee)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while creating an employee: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while creating an employee", err)
 		return
 	}
 
@@ -97,6 +93,7 @@ func (h *Controller) CreateEmployee(c *gin.Context) {
 // @Param employee body swag.CreateUpdateEmployee true "Employee data"
 // @Success 200 {object} models.ResponseSuccess
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) UpdateEmployee(c *gin.Context) {
 	var employeeModel swag.CreateUpdateEmployee
@@ -106,16 +103,12 @@ func (h *Controller) UpdateEmployee(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid employee ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if err := c.ShouldBindJSON(&employeeModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &employeeModel) {
 		return
 	}
 
@@ -133,10 +126,7 @@ func (h *Controller) UpdateEmployee(c *gin.Context) {
 	}
 
 	if err := h.service.Employee().Update(c.Request.Context(), &employee); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while updating the employee: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while updating the employee", err)
 		return
 	}
 
@@ -160,17 +150,14 @@ func (h *Controller) DeleteEmployee(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid employee ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = h.service.Employee().Delete(c.Request.Context(), models.RequestId{Id: employeeId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while deleting the employee: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while deleting the employee", err)
 		return
 	}
 
@@ -187,6 +174,7 @@ func (h *Controller) DeleteEmployee(c *gin.Context) {
 // @Param employee_id path string true "Employee ID"
 // @Success 200 {object} models.Employee
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) GetEmployee(c *gin.Context) {
 	employeeIdStr := c.Param("employee_id")
@@ -194,17 +182,14 @@ func (h *Controller) GetEmployee(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid employee ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	employee, err := h.service.Employee().Get(c.Request.Context(), models.RequestId{Id: employeeId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving the employee: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving the employee", err)
 		return
 	}
 
@@ -228,7 +213,7 @@ func (h *Controller) GetAllEmployees(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid page: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -237,7 +222,7 @@ func (h *Controller) GetAllEmployees(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid limit: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -247,7 +232,7 @@ func (h *Controller) GetAllEmployees(c *gin.Context) {
 	if position != "Dispatcher" && position != "Updater" && position != "" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid position: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -261,10 +246,7 @@ func (h *Controller) GetAllEmployees(c *gin.Context) {
 
 	employees, err := h.service.Employee().GetAll(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving employees: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving employees", err)
 		return
 	}
 
@@ -0,0 +1,647 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/trucks [post]
+// @Summary Create a truck
+// @Description API for registering a new truck of a company
+// @Tags equipment
+// @Accept json
+// @Produce json
+// @Param truck body swag.CreateUpdateTruck true "Truck data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateTruck(c *gin.Context) {
+	var truckModel swag.CreateUpdateTruck
+	if !BindJSON(c, &truckModel) {
+		return
+	}
+
+	truck, errMsg := truckFromSwag(truckModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.Equipment().CreateTruck(c.Request.Context(), truck)
+	if err != nil {
+		HandleError(c, "Error while creating a truck", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trucks/{truck_id} [put]
+// @Summary Update a truck
+// @Description API for updating a truck
+// @Tags equipment
+// @Accept json
+// @Produce json
+// @Param truck_id path string true "Truck ID"
+// @Param truck body swag.CreateUpdateTruck true "Truck data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateTruck(c *gin.Context) {
+	var truckModel swag.CreateUpdateTruck
+
+	truckId, err := uuid.Parse(c.Param("truck_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &truckModel) {
+		return
+	}
+
+	truck, errMsg := truckFromSwag(truckModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	truck.Id = truckId
+
+	if err := h.service.Equipment().UpdateTruck(c.Request.Context(), truck); err != nil {
+		HandleError(c, "Error while updating the truck", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Truck updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trucks/{truck_id} [delete]
+// @Summary Delete a truck
+// @Description API for deleting a truck that is not assigned to a driver
+// @Tags equipment
+// @Param truck_id path string true "Truck ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteTruck(c *gin.Context) {
+	truckId, err := uuid.Parse(c.Param("truck_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Equipment().DeleteTruck(c.Request.Context(), models.RequestId{Id: truckId})
+	if err != nil {
+		HandleError(c, "Error while deleting the truck", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Truck deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trucks/{truck_id} [get]
+// @Summary Get a truck by ID
+// @Description API for retrieving a truck by ID
+// @Tags equipment
+// @Param truck_id path string true "Truck ID"
+// @Success 200 {object} models.Truck
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetTruck(c *gin.Context) {
+	truckId, err := uuid.Parse(c.Param("truck_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	truck, err := h.service.Equipment().GetTruck(c.Request.Context(), models.RequestId{Id: truckId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the truck", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, truck)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trucks [get]
+// @Summary Get all trucks
+// @Description API for retrieving trucks with pagination and filters
+// @Tags equipment
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of trucks per page"
+// @Param company_id query string false "Company ID"
+// @Param search query string false "Number, VIN or plate"
+// @Param status query string false "ACTIVE or INACTIVE"
+// @Success 200 {object} models.GetAllTrucksResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllTrucks(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	companyId, err := ParseUUIDQueryParam(c, "company_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	trucks, err := h.service.Equipment().GetAllTrucks(c.Request.Context(), models.GetAllEquipmentReq{
+		Page:      page,
+		Limit:     limit,
+		CompanyId: companyId,
+		Search:    c.Query("search"),
+		Status:    c.Query("status"),
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving trucks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trucks)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trailers [post]
+// @Summary Create a trailer
+// @Description API for registering a new trailer of a company
+// @Tags equipment
+// @Accept json
+// @Produce json
+// @Param trailer body swag.CreateUpdateTrailer true "Trailer data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateTrailer(c *gin.Context) {
+	var trailerModel swag.CreateUpdateTrailer
+	if !BindJSON(c, &trailerModel) {
+		return
+	}
+
+	trailer, errMsg := trailerFromSwag(trailerModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.Equipment().CreateTrailer(c.Request.Context(), trailer)
+	if err != nil {
+		HandleError(c, "Error while creating a trailer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trailers/{trailer_id} [put]
+// @Summary Update a trailer
+// @Description API for updating a trailer
+// @Tags equipment
+// @Accept json
+// @Produce json
+// @Param trailer_id path string true "Trailer ID"
+// @Param trailer body swag.CreateUpdateTrailer true "Trailer data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateTrailer(c *gin.Context) {
+	var trailerModel swag.CreateUpdateTrailer
+
+	trailerId, err := uuid.Parse(c.Param("trailer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid trailer ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &trailerModel) {
+		return
+	}
+
+	trailer, errMsg := trailerFromSwag(trailerModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	trailer.Id = trailerId
+
+	if err := h.service.Equipment().UpdateTrailer(c.Request.Context(), trailer); err != nil {
+		HandleError(c, "Error while updating the trailer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Trailer updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trailers/{trailer_id} [delete]
+// @Summary Delete a trailer
+// @Description API for deleting a trailer that is not assigned to a driver
+// @Tags equipment
+// @Param trailer_id path string true "Trailer ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteTrailer(c *gin.Context) {
+	trailerId, err := uuid.Parse(c.Param("trailer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid trailer ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Equipment().DeleteTrailer(c.Request.Context(), models.RequestId{Id: trailerId})
+	if err != nil {
+		HandleError(c, "Error while deleting the trailer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Trailer deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trailers/{trailer_id} [get]
+// @Summary Get a trailer by ID
+// @Description API for retrieving a trailer by ID
+// @Tags equipment
+// @Param trailer_id path string true "Trailer ID"
+// @Success 200 {object} models.Trailer
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetTrailer(c *gin.Context) {
+	trailerId, err := uuid.Parse(c.Param("trailer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid trailer ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	trailer, err := h.service.Equipment().GetTrailer(c.Request.Context(), models.RequestId{Id: trailerId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the trailer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trailer)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/trailers [get]
+// @Summary Get all trailers
+// @Description API for retrieving trailers with pagination and filters
+// @Tags equipment
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of trailers per page"
+// @Param company_id query string false "Company ID"
+// @Param search query string false "Number, VIN or plate"
+// @Param status query string false "ACTIVE or INACTIVE"
+// @Param type query string false "Trailer type"
+// @Success 200 {object} models.GetAllTrailersResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllTrailers(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	companyId, err := ParseUUIDQueryParam(c, "company_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	trailers, err := h.service.Equipment().GetAllTrailers(c.Request.Context(), models.GetAllEquipmentReq{
+		Page:      page,
+		Limit:     limit,
+		CompanyId: companyId,
+		Search:    c.Query("search"),
+		Status:    c.Query("status"),
+		Type:      c.Query("type"),
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving trailers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trailers)
+}
+
+// truckFromSwag converts the request body of a truck to the model.
+func truckFromSwag(m swag.CreateUpdateTruck) (*models.Truck, string) {
+	companyId, err := uuid.Parse(m.CompanyId)
+	if err != nil {
+		return nil, "Invalid company ID format: " + err.Error()
+	}
+
+	if m.Ownership == "" {
+		m.Ownership = models.OwnershipCompany
+	}
+
+	if m.Status == "" {
+		m.Status = models.EquipmentStatusActive
+	}
+
+	var registrationExpiry *time.Time
+	if m.RegistrationExpiry != "" {
+		parsed, err := time.Parse(validation.DateLayout, m.RegistrationExpiry)
+		if err != nil {
+			return nil, "Invalid registration expiry format: " + err.Error()
+		}
+		registrationExpiry = &parsed
+	}
+
+	return &models.Truck{
+		CompanyId:          companyId,
+		Number:             m.Number,
+		VIN:                m.VIN,
+		Make:               m.Make,
+		Model:              m.Model,
+		Year:               m.Year,
+		Plate:              m.Plate,
+		PlateState:         strings.ToUpper(m.PlateState),
+		RegistrationExpiry: registrationExpiry,
+		Ownership:          m.Ownership,
+		Status:             m.Status,
+	}, ""
+}
+
+// trailerFromSwag converts the request body of a trailer to the model.
+func trailerFromSwag(m swag.CreateUpdateTrailer) (*models.Trailer, string) {
+	companyId, err := uuid.Parse(m.CompanyId)
+	if err != nil {
+		return nil, "Invalid company ID format: " + err.Error()
+	}
+
+	if m.Length == 0 {
+		m.Length = 53
+	}
+
+	if m.Ownership == "" {
+		m.Ownership = models.OwnershipCompany
+	}
+
+	if m.Status == "" {
+		m.Status = models.EquipmentStatusActive
+	}
+
+	var registrationExpiry *time.Time
+	if m.RegistrationExpiry != "" {
+		parsed, err := time.Parse(validation.DateLayout, m.RegistrationExpiry)
+		if err != nil {
+			return nil, "Invalid registration expiry format: " + err.Error()
+		}
+		registrationExpiry = &parsed
+	}
+
+	return &models.Trailer{
+		CompanyId:          companyId,
+		Number:             m.Number,
+		VIN:                m.VIN,
+		Type:               m.Type,
+		Length:             m.Length,
+		Make:               m.Make,
+		Year:               m.Year,
+		Plate:              m.Plate,
+		PlateState:         strings.ToUpper(m.PlateState),
+		RegistrationExpiry: registrationExpiry,
+		Ownership:          m.Ownership,
+		Status:             m.Status,
+	}, ""
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/drivers/{driver_id}/equipment [put]
+// @Summary Assign equipment to a driver
+// @Description API for putting a driver on a truck and trailer. Leave an ID empty to drop that equipment. Equipment taken from another driver is removed from them, and every change is kept in the assignment history
+// @Tags equipment
+// @Accept json
+// @Produce json
+// @Param driver_id path string true "Driver ID"
+// @Param assignment body swag.AssignEquipment true "Equipment"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) AssignEquipment(c *gin.Context) {
+	var assignModel swag.AssignEquipment
+
+	driverId, err := uuid.Parse(c.Param("driver_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &assignModel) {
+		return
+	}
+
+	truckId, err := ParseOptionalUUID(assignModel.TruckId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	trailerId, err := ParseOptionalUUID(assignModel.TrailerId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid trailer ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Equipment().Assign(c.Request.Context(), driverId, truckId, trailerId, assignModel.Notes, models.RequestId{Id: userId})
+	if err != nil {
+		HandleError(c, "Error while assigning equipment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Equipment assigned successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/equipment_assignments [get]
+// @Summary Get equipment assignment history
+// @Description API for retrieving which driver ran which truck and trailer and when
+// @Tags equipment
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of assignments per page"
+// @Param driver_id query string false "Driver ID"
+// @Param truck_id query string false "Truck ID"
+// @Param trailer_id query string false "Trailer ID"
+// @Success 200 {object} models.GetAllAssignmentsResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllEquipmentAssignments(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	driverId, err := ParseUUIDQueryParam(c, "driver_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	truckId, err := ParseUUIDQueryParam(c, "truck_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	trailerId, err := ParseUUIDQueryParam(c, "trailer_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid trailer ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	assignments, err := h.service.Equipment().GetAllAssignments(c.Request.Context(), models.GetAllAssignmentsReq{
+		Page:      page,
+		Limit:     limit,
+		DriverId:  driverId,
+		TruckId:   truckId,
+		TrailerId: trailerId,
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving assignments", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/flag_rules [post]
+// @Summary Create a flag rule
+// @Description API for creating a board flag rule. Conditions are AFTER_ST_TIME (st_time passed more than threshold minutes ago) and BEFORE_ST_TIME (st_time is less than threshold minutes ahead). Empty statuses match every status, an empty company every company. Flags are shown by ascending priority
+// @Tags flags
+// @Accept json
+// @Produce json
+// @Param rule body swag.CreateUpdateFlagRule true "Flag rule data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateFlagRule(c *gin.Context) {
+	var ruleModel swag.CreateUpdateFlagRule
+	if !BindJSON(c, &ruleModel) {
+		return
+	}
+
+	rule, err := flagRuleFromSwag(ruleModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.FlagRule().Create(c.Request.Context(), rule)
+	if err != nil {
+		HandleError(c, "Error while creating a flag rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/flag_rules/{rule_id} [put]
+// @Summary Update a flag rule
+// @Description API for updating a board flag rule
+// @Tags flags
+// @Accept json
+// @Produce json
+// @Param rule_id path string true "Flag rule ID"
+// @Param rule body swag.CreateUpdateFlagRule true "Flag rule data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateFlagRule(c *gin.Context) {
+	var ruleModel swag.CreateUpdateFlagRule
+
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid flag rule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &ruleModel) {
+		return
+	}
+
+	rule, err := flagRuleFromSwag(ruleModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	rule.Id = ruleId
+
+	if err := h.service.FlagRule().Update(c.Request.Context(), rule); err != nil {
+		HandleError(c, "Error while updating the flag rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Flag rule updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/flag_rules/{rule_id} [delete]
+// @Summary Delete a flag rule
+// @Description API for deleting a board flag rule
+// @Tags flags
+// @Param rule_id path string true "Flag rule ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteFlagRule(c *gin.Context) {
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid flag rule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.FlagRule().Delete(c.Request.Context(), models.RequestId{Id: ruleId})
+	if err != nil {
+		HandleError(c, "Error while deleting the flag rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Flag rule deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/flag_rules/{rule_id} [get]
+// @Summary Get a flag rule by ID
+// @Description API for retrieving a board flag rule by ID
+// @Tags flags
+// @Param rule_id path string true "Flag rule ID"
+// @Success 200 {object} models.FlagRule
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetFlagRule(c *gin.Context) {
+	ruleId, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid flag rule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	rule, err := h.service.FlagRule().Get(c.Request.Context(), models.RequestId{Id: ruleId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the flag rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/flag_rules [get]
+// @Summary Get all flag rules
+// @Description API for retrieving all board flag rules in the order their flags are shown
+// @Tags flags
+// @Success 200 {array} models.FlagRule
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllFlagRules(c *gin.Context) {
+	rules, err := h.service.FlagRule().GetAll(c.Request.Context())
+	if err != nil {
+		HandleError(c, "Error while retrieving flag rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func flagRuleFromSwag(m swag.CreateUpdateFlagRule) (*models.FlagRule, error) {
+	companyId, err := ParseOptionalUUID(m.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := models.StringList{}
+	for _, status := range m.Statuses {
+		if status = strings.ToUpper(strings.TrimSpace(status)); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return &models.FlagRule{
+		Name:      m.Name,
+		Condition: strings.ToUpper(m.Condition),
+		Statuses:  statuses,
+		Threshold: m.Threshold,
+		Icon:      m.Icon,
+		Priority:  m.Priority,
+		CompanyId: companyId,
+		Enabled:   m.Enabled,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"backend/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// @Router /healthz [get]
+// @Summary Liveness probe
+// @Description API that answers while the process serves requests, without checking its dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.HealthCheck
+func (h *Controller) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, models.HealthCheck{Name: "live", Status: models.HealthStatusOK})
+}
+
+// @Router /readyz [get]
+// @Summary Readiness probe
+// @Description API that checks the database, the locations dataset and the background jobs
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.Readiness
+// @Failure 503 {object} models.Readiness "A check failed"
+func (h *Controller) Readyz(c *gin.Context) {
+	readiness := h.service.Health().Ready(c.Request.Context())
+	if readiness.Status != models.HealthStatusOK {
+		c.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	c.JSON(http.StatusOK, readiness)
+}
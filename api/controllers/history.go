@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"backend/etc/apperr"
 	"backend/models"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -15,6 +16,7 @@ import (
 // @Param history_id path string true "History ID"
 // @Success 200 {object} models.History
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) GetHistory(c *gin.Context) {
 	idStr := c.Param("history_id")
@@ -22,17 +24,14 @@ func (h *Controller) GetHistory(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid history ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	history, err := h.service.History().GetHistory(c.Request.Context(), models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving the history record: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving the history record", err)
 		return
 	}
 
@@ -54,7 +53,7 @@ func (h *Controller) GetAllHistories(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid page: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -63,7 +62,7 @@ func (h *Controller) GetAllHistories(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid limit: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -75,10 +74,7 @@ func (h *Controller) GetAllHistories(c *gin.Context) {
 
 	histories, err := h.service.History().GetAll(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving histories: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving histories", err)
 		return
 	}
 
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/drivers/{driver_id}/hos [put]
+// @Summary Save a driver's hours of service
+// @Description API for entering the remaining drive, on-duty and cycle hours of a driver by hand and linking the driver to the ELD. reported_at uses the 2006-01-02T15:04:05 format and defaults to now
+// @Tags hos
+// @Accept json
+// @Produce json
+// @Param driver_id path string true "Driver ID"
+// @Param hos body swag.SaveDriverHOS true "Hours of service"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) SaveDriverHOS(c *gin.Context) {
+	var hosModel swag.SaveDriverHOS
+
+	driverId, err := uuid.Parse(c.Param("driver_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &hosModel) {
+		return
+	}
+
+	var reportedAt time.Time
+	if hosModel.ReportedAt != "" {
+		parsed, err := time.Parse(validation.DateTimeLayout, hosModel.ReportedAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid reported at format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		reportedAt = Utime.Parse(parsed)
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.HOS().Save(c.Request.Context(), &models.DriverHOS{
+		DriverId:    driverId,
+		DriveLeft:   hosModel.DriveLeft,
+		OnDutyLeft:  hosModel.OnDutyLeft,
+		CycleLeft:   hosModel.CycleLeft,
+		ELDDriverId: hosModel.ELDDriverId,
+		ReportedAt:  reportedAt,
+		EmployeeId:  &userId,
+	})
+	if err != nil {
+		HandleError(c, "Error while saving hours of service", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Hours of service saved successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/drivers/{driver_id}/hos [get]
+// @Summary Get a driver's hours of service
+// @Description API for retrieving the remaining hours of a driver and where they came from
+// @Tags hos
+// @Param driver_id path string true "Driver ID"
+// @Success 200 {object} models.DriverHOS
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetDriverHOS(c *gin.Context) {
+	driverId, err := uuid.Parse(c.Param("driver_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hos, err := h.service.HOS().Get(c.Request.Context(), driverId)
+	if err != nil {
+		HandleError(c, "Error while retrieving hours of service", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hos)
+}
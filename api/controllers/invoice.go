@@ -0,0 +1,576 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices [post]
+// @Summary Generate an invoice
+// @Description API for billing a provider for delivered transactions. Every transaction becomes a linehaul line, accessorials (DETENTION, LAYOVER, LUMPER, TONU, OTHER) are added as extra lines
+// @Tags invoice
+// @Accept json
+// @Produce json
+// @Param invoice body swag.GenerateInvoice true "Invoice data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GenerateInvoice(c *gin.Context) {
+	var invoiceModel swag.GenerateInvoice
+	if !BindJSON(c, &invoiceModel) {
+		return
+	}
+
+	companyId, err := uuid.Parse(invoiceModel.CompanyId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	providerId, err := uuid.Parse(invoiceModel.ProviderId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	transactionIds := make([]uuid.UUID, 0, len(invoiceModel.TransactionIds))
+	seen := make(map[uuid.UUID]bool, len(invoiceModel.TransactionIds))
+	for _, idStr := range invoiceModel.TransactionIds {
+		transactionId, err := uuid.Parse(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid transaction ID format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		if !seen[transactionId] {
+			seen[transactionId] = true
+			transactionIds = append(transactionIds, transactionId)
+		}
+	}
+
+	accessorials := make([]models.InvoiceLine, 0, len(invoiceModel.Accessorials))
+	for _, a := range invoiceModel.Accessorials {
+		transactionId, err := ParseOptionalUUID(a.TransactionId)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid accessorial transaction ID format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+
+		description := a.Description
+		if description == "" {
+			description = a.Type
+		}
+
+		accessorials = append(accessorials, models.InvoiceLine{
+			TransactionId: transactionId,
+			Type:          a.Type,
+			Description:   description,
+			Amount:        a.Amount,
+		})
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.Invoice().Generate(c.Request.Context(), models.GenerateInvoiceReq{
+		CompanyId:      companyId,
+		ProviderId:     providerId,
+		TransactionIds: transactionIds,
+		Accessorials:   accessorials,
+		Notes:          invoiceModel.Notes,
+	}, models.RequestId{Id: userId})
+	if err != nil {
+		HandleError(c, "Error while generating an invoice", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices/{invoice_id}/status [put]
+// @Summary Update invoice status
+// @Description API for sending (SENT) or voiding (VOID) an invoice. Invoices become PAID once their payments cover the total
+// @Tags invoice
+// @Accept json
+// @Produce json
+// @Param invoice_id path string true "Invoice ID"
+// @Param status body swag.UpdateInvoiceStatus true "New status"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateInvoiceStatus(c *gin.Context) {
+	var statusModel swag.UpdateInvoiceStatus
+
+	invoiceId, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid invoice ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &statusModel) {
+		return
+	}
+
+	err = h.service.Invoice().UpdateStatus(c.Request.Context(), models.RequestId{Id: invoiceId}, statusModel.Status)
+	if err != nil {
+		HandleError(c, "Error while updating the invoice status", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Invoice status updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices/{invoice_id}/payments [post]
+// @Summary Record an invoice payment
+// @Description API for recording a (partial) payment received for a sent invoice
+// @Tags invoice
+// @Accept json
+// @Produce json
+// @Param invoice_id path string true "Invoice ID"
+// @Param payment body swag.CreateInvoicePayment true "Payment data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateInvoicePayment(c *gin.Context) {
+	var paymentModel swag.CreateInvoicePayment
+
+	invoiceId, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid invoice ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &paymentModel) {
+		return
+	}
+
+	paidAt, err := time.Parse(validation.DateLayout, paymentModel.PaidAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid paid_at format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.Invoice().AddPayment(c.Request.Context(), &models.InvoicePayment{
+		InvoiceId: invoiceId,
+		Amount:    paymentModel.Amount,
+		PaidAt:    paidAt,
+		Method:    paymentModel.Method,
+		Reference: paymentModel.Reference,
+	}, models.RequestId{Id: userId})
+	if err != nil {
+		HandleError(c, "Error while recording the payment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices/{invoice_id} [delete]
+// @Summary Delete a draft invoice
+// @Description API for deleting a draft invoice and releasing its transactions
+// @Tags invoice
+// @Param invoice_id path string true "Invoice ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteInvoice(c *gin.Context) {
+	invoiceId, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid invoice ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Invoice().Delete(c.Request.Context(), models.RequestId{Id: invoiceId})
+	if err != nil {
+		HandleError(c, "Error while deleting the invoice", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Invoice deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices/{invoice_id} [get]
+// @Summary Get an invoice by ID
+// @Description API for retrieving an invoice with its lines and payments
+// @Tags invoice
+// @Param invoice_id path string true "Invoice ID"
+// @Success 200 {object} models.Invoice
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetInvoice(c *gin.Context) {
+	invoiceId, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid invoice ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	invoice, err := h.service.Invoice().Get(c.Request.Context(), models.RequestId{Id: invoiceId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the invoice", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices/{invoice_id}/pdf [get]
+// @Summary Download an invoice as PDF
+// @Description API for rendering an invoice as a PDF document
+// @Tags invoice
+// @Produce application/pdf
+// @Param invoice_id path string true "Invoice ID"
+// @Success 200 {file} file
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetInvoicePDF(c *gin.Context) {
+	invoiceId, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid invoice ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	invoice, document, err := h.service.Invoice().PDF(c.Request.Context(), models.RequestId{Id: invoiceId})
+	if err != nil {
+		HandleError(c, "Error while rendering the invoice", err)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="`+invoice.Number+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices [get]
+// @Summary Get all invoices
+// @Description API for retrieving invoices with pagination and filters
+// @Tags invoice
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of invoices per page"
+// @Param company_id query string false "Company ID"
+// @Param provider_id query string false "Provider ID"
+// @Param status query string false "DRAFT, SENT, PAID or VOID"
+// @Param number query string false "Invoice number"
+// @Success 200 {object} models.GetAllInvoicesResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllInvoices(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	companyId, err := ParseUUIDQueryParam(c, "company_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	providerId, err := ParseUUIDQueryParam(c, "provider_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", models.InvoiceStatusDraft, models.InvoiceStatusSent, models.InvoiceStatusPaid, models.InvoiceStatusVoid:
+	default:
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid status: " + status,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	invoices, err := h.service.Invoice().GetAll(c.Request.Context(), models.GetAllInvoicesReq{
+		Page:       page,
+		Limit:      limit,
+		CompanyId:  companyId,
+		ProviderId: providerId,
+		Status:     status,
+		Number:     c.Query("number"),
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving invoices", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices/{invoice_id}/short_pay [post]
+// @Summary Close an invoice as short paid
+// @Description API for closing a sent invoice whose provider will not pay the rest, writing the open balance off as short paid
+// @Tags invoice
+// @Accept json
+// @Produce json
+// @Param invoice_id path string true "Invoice ID"
+// @Param short_pay body swag.ShortPayInvoice true "Short pay reason"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) ShortPayInvoice(c *gin.Context) {
+	var shortPayModel swag.ShortPayInvoice
+
+	invoiceId, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid invoice ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &shortPayModel) {
+		return
+	}
+
+	err = h.service.Invoice().ShortPay(c.Request.Context(), models.RequestId{Id: invoiceId}, shortPayModel.Reason)
+	if err != nil {
+		HandleError(c, "Error while short paying the invoice", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Invoice closed as short paid",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/invoices/{invoice_id}/factoring [put]
+// @Summary Update invoice factoring
+// @Description API for recording that an invoice was factored, with the advance received, the reserve held back and the fee
+// @Tags invoice
+// @Accept json
+// @Produce json
+// @Param invoice_id path string true "Invoice ID"
+// @Param factoring body swag.UpdateInvoiceFactoring true "Factoring data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateInvoiceFactoring(c *gin.Context) {
+	var factoringModel swag.UpdateInvoiceFactoring
+
+	invoiceId, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid invoice ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &factoringModel) {
+		return
+	}
+
+	dates := make([]*time.Time, 2)
+	for i, dateStr := range []string{factoringModel.FactoredAt, factoringModel.ReserveReleasedAt} {
+		if dateStr == "" {
+			continue
+		}
+		parsed, err := time.Parse(validation.DateLayout, dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid date format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		dates[i] = &parsed
+	}
+
+	invoice := models.Invoice{
+		Id:                invoiceId,
+		Factored:          factoringModel.Factored,
+		FactoringCompany:  factoringModel.FactoringCompany,
+		FactoredAt:        dates[0],
+		AdvanceAmount:     factoringModel.AdvanceAmount,
+		ReserveAmount:     factoringModel.ReserveAmount,
+		FactoringFee:      factoringModel.FactoringFee,
+		ReserveReleasedAt: dates[1],
+	}
+	if !invoice.Factored {
+		invoice = models.Invoice{Id: invoiceId}
+	}
+
+	if err = h.service.Invoice().SetFactoring(c.Request.Context(), &invoice); err != nil {
+		HandleError(c, "Error while updating invoice factoring", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Invoice factoring updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/ar/aging [get]
+// @Summary Get accounts receivable aging
+// @Description API for retrieving the open balance of sent invoices per provider in 0-30, 31-60, 61-90 and 90+ day buckets
+// @Tags invoice
+// @Param company_id query string false "Company ID"
+// @Param provider_id query string false "Provider ID"
+// @Success 200 {object} models.GetARAgingResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetARAging(c *gin.Context) {
+	companyId, err := ParseUUIDQueryParam(c, "company_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	providerId, err := ParseUUIDQueryParam(c, "provider_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	aging, err := h.service.Invoice().Aging(c.Request.Context(), models.GetARAgingReq{
+		CompanyId:  companyId,
+		ProviderId: providerId,
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving AR aging", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aging)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/providers/{provider_id}/ledger [get]
+// @Summary Get a provider's AR ledger
+// @Description API for retrieving the invoices, payments and short pays of a provider with the running balance owed
+// @Tags provider
+// @Param provider_id path string true "Provider ID"
+// @Success 200 {object} models.ProviderLedger
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetProviderLedger(c *gin.Context) {
+	providerId, err := uuid.Parse(c.Param("provider_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	ledger, err := h.service.Invoice().Ledger(c.Request.Context(), providerId)
+	if err != nil {
+		HandleError(c, "Error while retrieving the provider ledger", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ledger)
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/jobs"
+	"backend/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/jobs [get]
+// @Summary Get all background jobs
+// @Description API for listing the background jobs with their schedule and latest run
+// @Tags jobs
+// @Success 200 {array} models.JobInfo
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllJobs(c *gin.Context) {
+	infos, err := h.service.Job().GetAll(c.Request.Context())
+	if err != nil {
+		HandleError(c, "Error while retrieving jobs", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, infos)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/job_runs [get]
+// @Summary Get the runs of background jobs
+// @Description API for retrieving the runs of background jobs, latest first
+// @Tags jobs
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of runs per page"
+// @Param job query string false "Job name"
+// @Param status query string false "RUNNING, SUCCEEDED or FAILED"
+// @Success 200 {object} models.GetAllJobRunsResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllJobRuns(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	runs, err := h.service.Job().GetAllRuns(c.Request.Context(), models.GetAllJobRunsReq{
+		Page:   page,
+		Limit:  limit,
+		Job:    c.Query("job"),
+		Status: strings.ToUpper(c.Query("status")),
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving job runs", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/jobs/{name}/run [post]
+// @Summary Run a background job now
+// @Description API for starting a background job right away. The run is returned as soon as it starts, follow it in the job runs
+// @Tags jobs
+// @Param name path string true "Job name"
+// @Success 200 {object} models.JobRun
+// @Failure 404 {object} models.ResponseError "Unknown job"
+// @Failure 409 {object} models.ResponseError "Job is already running"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) TriggerJob(c *gin.Context) {
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	run, err := h.service.Job().Trigger(c.Request.Context(), c.Param("name"), userId)
+	if err != nil {
+		if errors.Is(err, jobs.ErrUnknownJob) {
+			c.JSON(http.StatusNotFound, models.ResponseError{
+				ErrorMessage: err.Error() + ": " + c.Param("name"),
+				ErrorCode:    apperr.CodeNotFound,
+			})
+			return
+		}
+
+		if errors.Is(err, jobs.ErrJobRunning) {
+			c.JSON(http.StatusConflict, models.ResponseError{
+				ErrorMessage: err.Error(),
+				ErrorCode:    "JOB_RUNNING",
+			})
+			return
+		}
+
+		HandleError(c, "Error while starting the job", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
@@ -2,8 +2,13 @@ package controllers
 
 import (
 	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/etc/validation"
 	"backend/models"
 	"backend/models/swag"
+	"backend/service/services"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
@@ -21,14 +26,11 @@ import (
 // @Param logistic body swag.CreateUpdateLogistic true "Logistic data"
 // @Success 200 {object} models.ResponseId
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) CreateLogistic(c *gin.Context) {
 	var logisticModel swag.CreateUpdateLogistic
-	if err := c.ShouldBindJSON(&logisticModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &logisticModel) {
 		return
 	}
 
@@ -36,7 +38,7 @@ func (h *Controller) CreateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing driver id: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -45,29 +47,21 @@ func (h *Controller) CreateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing cargo id: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	stTime, err := time.Parse("2006-01-02T15:04:05", logisticModel.StTime)
+	stTime, err := time.Parse(validation.DateTimeLayout, logisticModel.StTime)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing start time: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	parts := strings.Split(logisticModel.Location, ",")
-	if len(parts) != 2 {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing location: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
+	_, state, _ := strings.Cut(logisticModel.Location, ",")
 	logistic := models.Logistic{
 		DriverId:   driverId,
 		CargoId:    &cargoId,
@@ -75,17 +69,14 @@ func (h *Controller) CreateLogistic(c *gin.Context) {
 		StTime:     &stTime,
 		UpdateTime: Utime.Now(),
 		Location:   logisticModel.Location,
-		State:      strings.TrimSpace(parts[1]),
+		State:      strings.TrimSpace(state),
 		Notion:     logisticModel.Notion,
 		Post:       logisticModel.Post,
 	}
 
 	id, err := h.service.Logistic().Create(c.Request.Context(), &logistic)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while creating a logistic record: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while creating a logistic record", err)
 		return
 	}
 
@@ -101,8 +92,12 @@ func (h *Controller) CreateLogistic(c *gin.Context) {
 // @Produce json
 // @Param logistic_id path string true "Logistic ID"
 // @Param logistic body swag.CreateUpdateLogistic true "Logistic data"
+// @Param If-Match header string false "ETag of the version the update is based on, weak tags are refused. Without it the update overwrites the current version"
 // @Success 200 {object} models.ResponseSuccess
+// @Header 200 {string} ETag "Version of the updated logistic record"
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 409 {object} models.LogisticConflictResp "Logistic record changed since the If-Match version (VERSION_CONFLICT)"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) UpdateLogistic(c *gin.Context) {
 	var logisticModel swag.CreateUpdateLogistic
@@ -112,7 +107,7 @@ func (h *Controller) UpdateLogistic(c *gin.Context) {
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.ResponseError{
 			ErrorMessage: "No user id found in context",
-			ErrorCode:    "Unauthorized",
+			ErrorCode:    apperr.CodeUnauthorized,
 		})
 		return
 	}
@@ -120,7 +115,7 @@ func (h *Controller) UpdateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -129,46 +124,43 @@ func (h *Controller) UpdateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid logistic ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if err := c.ShouldBindJSON(&logisticModel); err != nil {
+	version, err := ParseIfMatch(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorMessage: "Invalid If-Match header: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
+	if !BindJSON(c, &logisticModel) {
+		return
+	}
+
 	cargoId, err := uuid.Parse(logisticModel.CargoId)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing cargo id: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	stTime, err := time.Parse("2006-01-02T15:04:05", logisticModel.StTime)
+	stTime, err := time.Parse(validation.DateTimeLayout, logisticModel.StTime)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing start time: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
-	parts := strings.Split(logisticModel.Location, ",")
-	if len(parts) != 2 {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing location: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
+	_, state, _ := strings.Cut(logisticModel.Location, ",")
 	logistic := models.Logistic{
 		Id:         logisticId,
 		CargoId:    &cargoId,
@@ -176,19 +168,22 @@ func (h *Controller) UpdateLogistic(c *gin.Context) {
 		StTime:     &stTime,
 		UpdateTime: Utime.Now(),
 		Location:   logisticModel.Location,
-		State:      strings.TrimSpace(parts[1]),
+		State:      strings.TrimSpace(state),
 		Notion:     logisticModel.Notion,
 		Post:       logisticModel.Post,
+		Version:    version,
 	}
 
 	if err := h.service.Logistic().Update(c.Request.Context(), &logistic, models.RequestId{Id: id}); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while updating the logistic record: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		if versionConflict(c, err) {
+			return
+		}
+
+		HandleError(c, "Error while updating the logistic record", err)
 		return
 	}
 
+	SetETag(c, logistic.Version)
 	c.JSON(http.StatusOK, models.ResponseSuccess{
 		Message: "Logistic record updated successfully",
 	})
@@ -209,17 +204,14 @@ func (h *Controller) DeleteLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid logistic ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = h.service.Logistic().Delete(c.Request.Context(), models.RequestId{Id: logisticId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while deleting the logistic record: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while deleting the logistic record", err)
 		return
 	}
 
@@ -235,7 +227,9 @@ func (h *Controller) DeleteLogistic(c *gin.Context) {
 // @Tags logistic
 // @Param logistic_id path string true "Logistic ID"
 // @Success 200 {object} models.Logistic
+// @Header 200 {string} ETag "Version of the logistic record, for If-Match on updates"
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) GetLogistic(c *gin.Context) {
 	logisticIdStr := c.Param("logistic_id")
@@ -243,20 +237,18 @@ func (h *Controller) GetLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing logistic ID: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	logistic, err := h.service.Logistic().Get(c.Request.Context(), models.RequestId{Id: logisticId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving the logistic record: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving the logistic record", err)
 		return
 	}
 
+	SetETag(c, logistic.Version)
 	c.JSON(http.StatusOK, logistic)
 }
 
@@ -274,6 +266,7 @@ func (h *Controller) GetLogistic(c *gin.Context) {
 // @Param status query string false "Status"
 // @Param location query string false "Location"
 // @Param state query string false "state"
+// @Param trailer_type query string false "Trailer type (DRY_VAN, REEFER, FLATBED, STEP_DECK, TANKER, OTHER)"
 // @Param company_ids query array false "Company IDs"
 // @Success 200 {object} models.GetAllLogisticsResp
 // @Failure 400 {object} models.ResponseError "Invalid input"
@@ -283,7 +276,7 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid page: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -292,7 +285,7 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid limit: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -301,7 +294,7 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 	if post != "" && post != "true" && post != "false" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid post: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -310,7 +303,7 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 	if driverType != "" && driverType != "SOLO" && driverType != "TEAM" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid type: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -319,7 +312,7 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 	if position != "" && position != "OW" && position != "CO" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid position: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -333,7 +326,7 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 			if err != nil {
 				c.JSON(http.StatusBadRequest, models.ResponseError{
 					ErrorMessage: "Invalid company ID: " + err.Error(),
-					ErrorCode:    "Bad Request",
+					ErrorCode:    apperr.CodeBadRequest,
 				})
 				return
 			}
@@ -347,24 +340,22 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 	state := c.Query("state")
 
 	req := models.GetAllLogisticsReq{
-		Page:       page,
-		Limit:      limit,
-		Post:       post,
-		Type:       driverType,
-		Position:   position,
-		Name:       name,
-		Status:     status,
-		Location:   location,
-		State:      state,
-		CompanyIds: companyIds,
+		Page:        page,
+		Limit:       limit,
+		Post:        post,
+		Type:        driverType,
+		Position:    position,
+		Name:        name,
+		Status:      status,
+		Location:    location,
+		State:       state,
+		TrailerType: c.Query("trailer_type"),
+		CompanyIds:  companyIds,
 	}
 
 	logistics, err := h.service.Logistic().GetAll(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving logistics: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving logistics", err)
 		return
 	}
 
@@ -380,27 +371,41 @@ func (h *Controller) GetAllLogistics(c *gin.Context) {
 // @Produce json
 // @Param logistic_id path string true "Logistic ID"
 // @Param logistic body swag.UpdateLogisticWithCargo true "Logistic data"
-// @Success 200 {object} models.ResponseSuccess
+// @Param If-Match header string false "ETag of the version the update is based on, weak tags are refused. Without it the update overwrites the current version"
+// @Success 200 {object} models.UpdateWithCargoResp
+// @Header 200 {string} ETag "Version of the updated logistic record"
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 409 {object} models.DuplicateLoadResp "Load already booked (DUPLICATE_LOAD), driver has expired documents (DRIVER_NOT_COMPLIANT), not enough hours of service (HOS_NOT_FEASIBLE) or logistic changed since the If-Match version or cargo since cargo_version (VERSION_CONFLICT, body is a models.LogisticConflictResp)"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 	var logisticModel swag.UpdateLogisticWithCargo
 	logisticIdStr := c.Param("logistic_id")
 
-	err := c.ShouldBindJSON(&logisticModel)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing json body: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &logisticModel) {
 		return
 	}
 
+	if n := len(logisticModel.Stops); n > 0 {
+		if logisticModel.PickUpTime == "" {
+			logisticModel.PickUpTime = logisticModel.Stops[0].AppointmentTime
+		}
+		if logisticModel.DeliveryTime == "" {
+			logisticModel.DeliveryTime = logisticModel.Stops[n-1].AppointmentTime
+		}
+		if logisticModel.From == "" {
+			logisticModel.From = logisticModel.Stops[0].Location
+		}
+		if logisticModel.To == "" {
+			logisticModel.To = logisticModel.Stops[n-1].Location
+		}
+	}
+
 	idStr, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.ResponseError{
 			ErrorMessage: "No user id found in context",
-			ErrorCode:    "Unauthorized",
+			ErrorCode:    apperr.CodeUnauthorized,
 		})
 		return
 	}
@@ -408,7 +413,7 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -417,7 +422,16 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid logistic ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	version, err := ParseIfMatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid If-Match header: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -428,37 +442,37 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ResponseError{
 				ErrorMessage: "Error while parsing cargo id: " + err.Error(),
-				ErrorCode:    "Bad Request",
+				ErrorCode:    apperr.CodeBadRequest,
 			})
 			return
 		}
 	}
 
-	stTime, err := time.Parse("2006-01-02T15:04:05", logisticModel.StTime)
+	stTime, err := time.Parse(validation.DateTimeLayout, logisticModel.StTime)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing start time: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	var updateTime time.Time
 	if logisticModel.Status == "COVERED" {
-		updateTime, err = time.Parse("2006-01-02T15:04:05", logisticModel.PickUpTime)
+		updateTime, err = time.Parse(validation.DateTimeLayout, logisticModel.PickUpTime)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ResponseError{
 				ErrorMessage: "Error while parsing pick up time: " + err.Error(),
-				ErrorCode:    "Bad Request",
+				ErrorCode:    apperr.CodeBadRequest,
 			})
 			return
 		}
-	} else if logisticModel.Status == "ETA" || logisticModel.Status == "ETA WILL BE LATE" {
-		updateTime, err = time.Parse("2006-01-02T15:04:05", logisticModel.DeliveryTime)
+	} else if logisticModel.Status == "ETA" || logisticModel.Status == "ETA WILL BE LATE" || logisticModel.Status == models.LogisticStatusLate {
+		updateTime, err = time.Parse(validation.DateTimeLayout, logisticModel.DeliveryTime)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ResponseError{
 				ErrorMessage: "Error while parsing delivery time: " + err.Error(),
-				ErrorCode:    "Bad Request",
+				ErrorCode:    apperr.CodeBadRequest,
 			})
 			return
 		}
@@ -466,15 +480,7 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 		updateTime = Utime.Now()
 	}
 
-	parts := strings.Split(logisticModel.Location, ",")
-	if len(parts) != 2 {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing location: ",
-			ErrorCode:    "Bad Request",
-		})
-		return
-	}
-
+	_, state, _ := strings.Cut(logisticModel.Location, ",")
 	logistic := models.Logistic{
 		Id:         logisticId,
 		CargoId:    &cargoId,
@@ -484,40 +490,44 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 		UpdateTime: updateTime,
 		StTime:     &stTime,
 		Location:   logisticModel.Location,
-		State:      strings.TrimSpace(parts[1]),
+		State:      strings.TrimSpace(state),
+		Version:    version,
 	}
 
 	if logisticModel.Status != "COVERED" && cargoId == uuid.Nil {
 		logistic.CargoId = nil
 		errUpd := h.service.Logistic().Update(c.Request.Context(), &logistic, models.RequestId{Id: id})
 		if errUpd != nil {
-			c.JSON(http.StatusInternalServerError, models.ResponseError{
-				ErrorMessage: "Error while updating logistic: " + errUpd.Error(),
-				ErrorCode:    "Internal Server Error",
-			})
+			if versionConflict(c, errUpd) {
+				return
+			}
+
+			HandleError(c, "Error while updating logistic", errUpd)
 			return
 		}
 
+		SetETag(c, logistic.Version)
+
 		c.JSON(http.StatusOK, models.ResponseSuccess{
 			Message: "Logistic updated",
 		})
 		return
 	}
 
-	pickUpTime, err := time.Parse("2006-01-02T15:04:05", logisticModel.PickUpTime)
+	pickUpTime, err := time.Parse(validation.DateTimeLayout, logisticModel.PickUpTime)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing pick up time for cargo: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	deliveryTime, err := time.Parse("2006-01-02T15:04:05", logisticModel.DeliveryTime)
+	deliveryTime, err := time.Parse(validation.DateTimeLayout, logisticModel.DeliveryTime)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing delivery time: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -526,15 +536,24 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing employee ID: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	providerId, err := ParseOptionalUUID(logisticModel.ProviderId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Error while parsing provider ID: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if logisticModel.Provider == "" {
+	if logisticModel.Provider == "" && providerId == nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing provider: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -544,6 +563,7 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 		PickUpTime:   pickUpTime,
 		DeliveryTime: deliveryTime,
 		Provider:     logisticModel.Provider,
+		ProviderId:   providerId,
 		FreeMiles:    logisticModel.FreeMiles,
 		LoadedMiles:  logisticModel.LoadedMiles,
 		From:         logisticModel.From,
@@ -552,30 +572,88 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 		Rate:         logisticModel.Rate,
 		EmployeeId:   employeeId,
 		CargoID:      logisticModel.LoadId,
+		Version:      logisticModel.CargoVersion,
+	}
+
+	for i, stopModel := range logisticModel.Stops {
+		appointmentTime, err := time.Parse(validation.DateTimeLayout, stopModel.AppointmentTime)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: fmt.Sprintf("Error while parsing appointment time of stop %d: %s", i+1, err.Error()),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+
+		var stopId uuid.UUID
+		if stopModel.Id != "" {
+			stopId, err = uuid.Parse(stopModel.Id)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, models.ResponseError{
+					ErrorMessage: fmt.Sprintf("Error while parsing id of stop %d: %s", i+1, err.Error()),
+					ErrorCode:    apperr.CodeBadRequest,
+				})
+				return
+			}
+		}
+
+		cargo.Stops = append(cargo.Stops, models.Stop{
+			Id:              stopId,
+			Type:            strings.ToUpper(stopModel.Type),
+			Location:        stopModel.Location,
+			AppointmentTime: appointmentTime,
+			Status:          strings.ToUpper(stopModel.Status),
+		})
 	}
 
 	if logisticModel.Status != "ETA, WILL BE LATE" {
 		if stTime.After(deliveryTime) {
 			c.JSON(http.StatusBadRequest, models.ResponseError{
 				ErrorMessage: "Error delivery time cannot be less than ETA",
-				ErrorCode:    "Bad Request",
+				ErrorCode:    apperr.CodeBadRequest,
 			})
 			return
 		}
 	}
 
-	_, err = h.service.Logistic().UpdateWithCargo(c.Request.Context(), &logistic, &cargo, logisticModel.Create, models.RequestId{Id: id})
+	opts := models.UpdateWithCargoOptions{
+		Create:            logisticModel.Create,
+		OverrideDuplicate: logisticModel.OverrideDuplicate,
+		OverrideHOS:       logisticModel.OverrideHOS,
+	}
+
+	resp, err := h.service.Logistic().UpdateWithCargo(c.Request.Context(), &logistic, &cargo, opts, models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while updating logistic: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		var duplicateErr *services.DuplicateLoadError
+		if errors.As(err, &duplicateErr) {
+			c.JSON(http.StatusConflict, models.DuplicateLoadResp{
+				ErrorMessage: err.Error() + ", resend with override_duplicate to book it anyway",
+				ErrorCode:    "DUPLICATE_LOAD",
+				Duplicates:   duplicateErr.Duplicates,
+			})
+			return
+		}
+
+		var hosErr *services.HOSError
+		if errors.As(err, &hosErr) {
+			c.JSON(http.StatusConflict, models.ResponseError{
+				ErrorMessage: err.Error() + ", resend with override_hos to book it anyway",
+				ErrorCode:    "HOS_NOT_FEASIBLE",
+			})
+			return
+		}
+
+		if versionConflict(c, err) {
+			return
+		}
+
+		HandleError(c, "Error while updating logistic", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ResponseSuccess{
-		Message: "Logistic updated with Cargo successfully",
-	})
+	SetETag(c, logistic.Version)
+	resp.Message = "Logistic updated with Cargo successfully"
+	c.JSON(http.StatusOK, resp)
 }
 
 // @Security ApiKeyAuth
@@ -588,15 +666,12 @@ func (h *Controller) UpdateLogisticCargo(c *gin.Context) {
 // @Param logistic body swag.TerminateLogistic true "Logistic data"
 // @Success 200 {object} models.ResponseSuccess
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) TerminateLogistic(c *gin.Context) {
 	var req swag.TerminateLogistic
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &req) {
 		return
 	}
 
@@ -604,7 +679,7 @@ func (h *Controller) TerminateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -612,7 +687,7 @@ func (h *Controller) TerminateLogistic(c *gin.Context) {
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.ResponseError{
 			ErrorMessage: "No user id found in context",
-			ErrorCode:    "Unauthorized",
+			ErrorCode:    apperr.CodeUnauthorized,
 		})
 		return
 	}
@@ -620,17 +695,14 @@ func (h *Controller) TerminateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = h.service.Logistic().Terminate(c.Request.Context(), models.RequestId{Id: logisticId}, req.Success, models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while terminating logistic", err)
 		return
 	}
 
@@ -649,14 +721,11 @@ func (h *Controller) TerminateLogistic(c *gin.Context) {
 // @Param logistic body swag.CancelLogistic true "Logistic data"
 // @Success 200 {object} models.ResponseSuccess
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) CancelLateLogistic(c *gin.Context) {
 	var req swag.CancelLogistic
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &req) {
 		return
 	}
 
@@ -664,7 +733,7 @@ func (h *Controller) CancelLateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -673,69 +742,108 @@ func (h *Controller) CancelLateLogistic(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if req.Section == "" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing section: ",
-			ErrorCode:    "Bad Request",
+	empIdStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "Not authorized",
+			ErrorCode:    apperr.CodeUnauthorized,
 		})
 		return
 	}
-
-	if req.Status != "success" && req.Status != "canceled" {
+	empId, err := uuid.Parse(empIdStr.(string))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing status: ",
-			ErrorCode:    "Bad Request",
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if req.WhoseFault != "Dispatcher" && req.WhoseFault != "Driver" && req.WhoseFault != "Company" {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing whose fault: ",
-			ErrorCode:    "Bad Request",
-		})
+	err = h.service.Logistic().CancelLate(c.Request.Context(), req, models.RequestId{Id: logisticId}, models.RequestId{Id: empId}, models.RequestId{Id: compId})
+	if err != nil {
+		HandleError(c, "Error while cancelling logistic", err)
 		return
 	}
 
-	if req.Reason == "" {
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Logistic Cancelled or made late",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/stops/{stop_id} [put]
+// @Summary Update the status of a cargo stop
+// @Description API for marking a stop as pending, arrived or completed
+// @Tags logistic
+// @Accept json
+// @Produce json
+// @Param stop_id path string true "Stop ID"
+// @Param stop body swag.UpdateStopStatus true "Stop status"
+// @Success 200 {object} models.Stop
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateStopStatus(c *gin.Context) {
+	var req swag.UpdateStopStatus
+
+	stopId, err := uuid.Parse(c.Param("stop_id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while parsing reason: ",
-			ErrorCode:    "Bad Request",
+			ErrorMessage: "Invalid stop ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	empIdStr, exists := c.Get("user_id")
+	if !BindJSON(c, &req) {
+		return
+	}
+
+	idStr, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Not authorized",
-			ErrorCode:    "Not authorized",
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
 		})
+		return
 	}
-	empId, err := uuid.Parse(empIdStr.(string))
+	id, err := uuid.Parse(idStr.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	err = h.service.Logistic().CancelLate(c.Request.Context(), req, models.RequestId{Id: logisticId}, models.RequestId{Id: empId}, models.RequestId{Id: compId})
+	status := strings.ToUpper(req.Status)
+	stop, err := h.service.Logistic().UpdateStopStatus(c.Request.Context(), models.RequestId{Id: stopId}, status, models.RequestId{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while updating stop", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ResponseSuccess{
-		Message: "Logistic Cancelled or made late",
+	c.JSON(http.StatusOK, stop)
+}
+
+// versionConflict responds with the current logistic when err is a version
+// conflict. It reports whether it did.
+func versionConflict(c *gin.Context, err error) bool {
+	var conflictErr *services.VersionConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+
+	SetETag(c, conflictErr.Current.Version)
+	c.JSON(http.StatusConflict, models.LogisticConflictResp{
+		ErrorMessage: err.Error() + ", merge your change into current and resend",
+		ErrorCode:    "VERSION_CONFLICT",
+		Current:      conflictErr.Current,
 	})
+	return true
 }
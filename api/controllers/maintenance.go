@@ -0,0 +1,634 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_records [post]
+// @Summary Create a maintenance record
+// @Description API for recording a service done on a truck. A completed service restarts the matching schedules
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param record body swag.CreateUpdateMaintenanceRecord true "Maintenance record data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateMaintenanceRecord(c *gin.Context) {
+	var recordModel swag.CreateUpdateMaintenanceRecord
+	if !BindJSON(c, &recordModel) {
+		return
+	}
+
+	record, errMsg := maintenanceRecordFromSwag(recordModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	record.EmployeeId = userId
+
+	id, err := h.service.Maintenance().CreateRecord(c.Request.Context(), record)
+	if err != nil {
+		HandleError(c, "Error while creating a maintenance record", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_records/{record_id} [put]
+// @Summary Update a maintenance record
+// @Description API for updating a maintenance record. The truck of the record can't be changed
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param record_id path string true "Maintenance record ID"
+// @Param record body swag.CreateUpdateMaintenanceRecord true "Maintenance record data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateMaintenanceRecord(c *gin.Context) {
+	var recordModel swag.CreateUpdateMaintenanceRecord
+
+	recordId, err := uuid.Parse(c.Param("record_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid maintenance record ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &recordModel) {
+		return
+	}
+
+	record, errMsg := maintenanceRecordFromSwag(recordModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	record.Id = recordId
+
+	if err := h.service.Maintenance().UpdateRecord(c.Request.Context(), record); err != nil {
+		HandleError(c, "Error while updating the maintenance record", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Maintenance record updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_records/{record_id} [delete]
+// @Summary Delete a maintenance record
+// @Description API for deleting a maintenance record
+// @Tags maintenance
+// @Param record_id path string true "Maintenance record ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteMaintenanceRecord(c *gin.Context) {
+	recordId, err := uuid.Parse(c.Param("record_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid maintenance record ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Maintenance().DeleteRecord(c.Request.Context(), models.RequestId{Id: recordId})
+	if err != nil {
+		HandleError(c, "Error while deleting the maintenance record", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Maintenance record deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_records/{record_id} [get]
+// @Summary Get a maintenance record by ID
+// @Description API for retrieving a maintenance record by ID
+// @Tags maintenance
+// @Param record_id path string true "Maintenance record ID"
+// @Success 200 {object} models.MaintenanceRecord
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetMaintenanceRecord(c *gin.Context) {
+	recordId, err := uuid.Parse(c.Param("record_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid maintenance record ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	record, err := h.service.Maintenance().GetRecord(c.Request.Context(), models.RequestId{Id: recordId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the maintenance record", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_records [get]
+// @Summary Get all maintenance records
+// @Description API for retrieving the maintenance history of trucks
+// @Tags maintenance
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of records per page"
+// @Param truck_id query string false "Truck ID"
+// @Param service_type query string false "Service type"
+// @Success 200 {object} models.GetAllMaintenanceRecordsResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllMaintenanceRecords(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	truckId, err := ParseUUIDQueryParam(c, "truck_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	records, err := h.service.Maintenance().GetAllRecords(c.Request.Context(), models.GetAllMaintenanceReq{
+		Page:        page,
+		Limit:       limit,
+		TruckId:     truckId,
+		ServiceType: c.Query("service_type"),
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving maintenance records", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_schedules [post]
+// @Summary Create a maintenance schedule
+// @Description API for scheduling a service of a truck every given number of miles and/or days
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param schedule body swag.CreateUpdateMaintenanceSchedule true "Maintenance schedule data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateMaintenanceSchedule(c *gin.Context) {
+	var scheduleModel swag.CreateUpdateMaintenanceSchedule
+	if !BindJSON(c, &scheduleModel) {
+		return
+	}
+
+	schedule, errMsg := maintenanceScheduleFromSwag(scheduleModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.Maintenance().CreateSchedule(c.Request.Context(), schedule)
+	if err != nil {
+		HandleError(c, "Error while creating a maintenance schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_schedules/{schedule_id} [put]
+// @Summary Update a maintenance schedule
+// @Description API for updating the intervals and the last service of a maintenance schedule
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param schedule_id path string true "Maintenance schedule ID"
+// @Param schedule body swag.CreateUpdateMaintenanceSchedule true "Maintenance schedule data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateMaintenanceSchedule(c *gin.Context) {
+	var scheduleModel swag.CreateUpdateMaintenanceSchedule
+
+	scheduleId, err := uuid.Parse(c.Param("schedule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid maintenance schedule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &scheduleModel) {
+		return
+	}
+
+	schedule, errMsg := maintenanceScheduleFromSwag(scheduleModel)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: errMsg,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	schedule.Id = scheduleId
+
+	if err := h.service.Maintenance().UpdateSchedule(c.Request.Context(), schedule); err != nil {
+		HandleError(c, "Error while updating the maintenance schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Maintenance schedule updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_schedules/{schedule_id} [delete]
+// @Summary Delete a maintenance schedule
+// @Description API for deleting a maintenance schedule
+// @Tags maintenance
+// @Param schedule_id path string true "Maintenance schedule ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteMaintenanceSchedule(c *gin.Context) {
+	scheduleId, err := uuid.Parse(c.Param("schedule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid maintenance schedule ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Maintenance().DeleteSchedule(c.Request.Context(), models.RequestId{Id: scheduleId})
+	if err != nil {
+		HandleError(c, "Error while deleting the maintenance schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Maintenance schedule deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance_schedules [get]
+// @Summary Get all maintenance schedules
+// @Description API for retrieving maintenance schedules of trucks
+// @Tags maintenance
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of schedules per page"
+// @Param truck_id query string false "Truck ID"
+// @Param service_type query string false "Service type"
+// @Success 200 {object} models.GetAllMaintenanceSchedulesResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllMaintenanceSchedules(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	truckId, err := ParseUUIDQueryParam(c, "truck_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	schedules, err := h.service.Maintenance().GetAllSchedules(c.Request.Context(), models.GetAllMaintenanceReq{
+		Page:        page,
+		Limit:       limit,
+		TruckId:     truckId,
+		ServiceType: c.Query("service_type"),
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving maintenance schedules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/maintenance/due [get]
+// @Summary Get due maintenance
+// @Description API for retrieving the scheduled services of active trucks that are overdue or due within the given days or miles
+// @Tags maintenance
+// @Param days query int false "Days ahead, 14 by default"
+// @Param miles query int false "Miles ahead, 1000 by default"
+// @Success 200 {array} models.MaintenanceDue
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetDueMaintenance(c *gin.Context) {
+	days, err := ParseIntegerQueryParam(c, "days")
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid days: " + c.Query("days"),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	if c.Query("days") == "" {
+		days = 14
+	}
+
+	miles, err := ParseIntegerQueryParam(c, "miles")
+	if err != nil || miles < 0 {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid miles: " + c.Query("miles"),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	if c.Query("miles") == "" {
+		miles = 1000
+	}
+
+	due, err := h.service.Maintenance().Due(c.Request.Context(), int(days), miles)
+	if err != nil {
+		HandleError(c, "Error while retrieving due maintenance", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, due)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/downtimes [get]
+// @Summary Get all downtimes
+// @Description API for retrieving the periods trucks spent in TRUCK ISSUES
+// @Tags maintenance
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of downtimes per page"
+// @Param truck_id query string false "Truck ID"
+// @Param company_id query string false "Company ID"
+// @Param open query bool false "Only downtimes that have not ended"
+// @Success 200 {object} models.GetAllDowntimesResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllDowntimes(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	truckId, err := ParseUUIDQueryParam(c, "truck_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid truck ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	companyId, err := ParseUUIDQueryParam(c, "company_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	downtimes, err := h.service.Maintenance().GetAllDowntimes(c.Request.Context(), models.GetAllDowntimesReq{
+		Page:      page,
+		Limit:     limit,
+		TruckId:   truckId,
+		CompanyId: companyId,
+		Open:      c.Query("open") == "true",
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving downtimes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, downtimes)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/downtimes/report [get]
+// @Summary Get the downtime report
+// @Description API for summing the downtime hours per truck and per company between two dates. Both default to the current month
+// @Tags maintenance
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param company_id query string false "Company ID"
+// @Success 200 {object} models.DowntimeReport
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetDowntimeReport(c *gin.Context) {
+	var (
+		now  = time.Now()
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		to   = from.AddDate(0, 1, -1)
+		err  error
+	)
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse(validation.DateLayout, fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid from date format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse(validation.DateLayout, toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid to date format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+	}
+
+	companyId, err := ParseUUIDQueryParam(c, "company_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	report, err := h.service.Maintenance().DowntimeReport(c.Request.Context(), models.DowntimeReportReq{
+		From:      from,
+		To:        to,
+		CompanyId: companyId,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Error while building the downtime report: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// maintenanceRecordFromSwag converts the request body of a maintenance record
+// to the model.
+func maintenanceRecordFromSwag(m swag.CreateUpdateMaintenanceRecord) (*models.MaintenanceRecord, string) {
+	truckId, err := uuid.Parse(m.TruckId)
+	if err != nil {
+		return nil, "Invalid truck ID format: " + err.Error()
+	}
+
+	startedAt, err := time.Parse(validation.DateLayout, m.StartedAt)
+	if err != nil {
+		return nil, "Invalid started at format: " + err.Error()
+	}
+
+	var completedAt *time.Time
+	if m.CompletedAt != "" {
+		parsed, err := time.Parse(validation.DateLayout, m.CompletedAt)
+		if err != nil {
+			return nil, "Invalid completed at format: " + err.Error()
+		}
+		if parsed.Before(startedAt) {
+			return nil, "Completed at can't be before started at"
+		}
+		completedAt = &parsed
+	}
+
+	return &models.MaintenanceRecord{
+		TruckId:     truckId,
+		ServiceType: m.ServiceType,
+		Description: m.Description,
+		Odometer:    m.Odometer,
+		Cost:        m.Cost,
+		Shop:        m.Shop,
+		StartedAt:   startedAt,
+		CompletedAt: completedAt,
+	}, ""
+}
+
+func maintenanceScheduleFromSwag(m swag.CreateUpdateMaintenanceSchedule) (*models.MaintenanceSchedule, string) {
+	truckId, err := uuid.Parse(m.TruckId)
+	if err != nil {
+		return nil, "Invalid truck ID format: " + err.Error()
+	}
+
+	var lastDate *time.Time
+	if m.LastDate != "" {
+		parsed, err := time.Parse(validation.DateLayout, m.LastDate)
+		if err != nil {
+			return nil, "Invalid last date format: " + err.Error()
+		}
+		lastDate = &parsed
+	}
+
+	return &models.MaintenanceSchedule{
+		TruckId:       truckId,
+		ServiceType:   m.ServiceType,
+		IntervalMiles: m.IntervalMiles,
+		IntervalDays:  m.IntervalDays,
+		LastOdometer:  m.LastOdometer,
+		LastDate:      lastDate,
+	}, ""
+}
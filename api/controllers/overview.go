@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"backend/models"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -18,10 +17,7 @@ import (
 func (h *Controller) Overview(c *gin.Context) {
 	resp, err := h.service.Logistic().GetOverview(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: err.Error(),
-			ErrorCode:    "InternalError",
-		})
+		HandleError(c, "Error while getting overview", err)
 		return
 	}
 
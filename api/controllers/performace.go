@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"backend/etc/apperr"
 	"backend/models"
 	"backend/models/swag"
 	"github.com/gin-gonic/gin"
@@ -18,14 +19,11 @@ import (
 // @Param performance body swag.CreateUpdatePerformance true "Performance data"
 // @Success 200 {object} models.ResponseId
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) CreatePerformance(c *gin.Context) {
 	var performanceModel swag.CreateUpdatePerformance
-	if err := c.ShouldBindJSON(&performanceModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &performanceModel) {
 		return
 	}
 
@@ -33,7 +31,7 @@ func (h *Controller) CreatePerformance(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing employee id: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -42,7 +40,7 @@ func (h *Controller) CreatePerformance(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing company id: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -59,10 +57,7 @@ func (h *Controller) CreatePerformance(c *gin.Context) {
 
 	id, err := h.service.Performance().Create(c.Request.Context(), &performance)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while creating a performance: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while creating a performance", err)
 		return
 	}
 
@@ -80,6 +75,7 @@ func (h *Controller) CreatePerformance(c *gin.Context) {
 // @Param performance body swag.CreateUpdatePerformance true "Performance data"
 // @Success 200 {object} models.ResponseSuccess
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) UpdatePerformance(c *gin.Context) {
 	var performanceModel swag.CreateUpdatePerformance
@@ -89,16 +85,12 @@ func (h *Controller) UpdatePerformance(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid performance ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if err := c.ShouldBindJSON(&performanceModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &performanceModel) {
 		return
 	}
 
@@ -106,7 +98,7 @@ func (h *Controller) UpdatePerformance(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing employee id: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -115,7 +107,7 @@ func (h *Controller) UpdatePerformance(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Error while parsing company id: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -132,10 +124,7 @@ func (h *Controller) UpdatePerformance(c *gin.Context) {
 	}
 
 	if err := h.service.Performance().Update(c.Request.Context(), &performance); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while updating the performance: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while updating the performance", err)
 		return
 	}
 
@@ -159,17 +148,14 @@ func (h *Controller) DeletePerformance(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid performance ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = h.service.Performance().Delete(c.Request.Context(), models.RequestId{Id: performanceId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while deleting the performance: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while deleting the performance", err)
 		return
 	}
 
@@ -186,6 +172,7 @@ func (h *Controller) DeletePerformance(c *gin.Context) {
 // @Param performance_id path string true "Performance ID"
 // @Success 200 {object} models.Performance
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) GetPerformance(c *gin.Context) {
 	performanceIdStr := c.Param("performance_id")
@@ -193,17 +180,14 @@ func (h *Controller) GetPerformance(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid performance ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	performance, err := h.service.Performance().Get(c.Request.Context(), models.RequestId{Id: performanceId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving the performance: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving the performance", err)
 		return
 	}
 
@@ -222,6 +206,7 @@ func (h *Controller) GetPerformance(c *gin.Context) {
 // @Param status query string false "Status"
 // @Param section query string false "Section"
 // @Param disputed_by query string false "Disputed by"
+// @Param draft query bool false "Only drafts opened by the late watcher, or only completed ones"
 // @Success 200 {object} models.GetAllPerformancesResp
 // @Failure 400 {object} models.ResponseError "Invalid input"
 // @Failure 500 {object} models.ResponseError "Internal server error"
@@ -230,7 +215,7 @@ func (h *Controller) GetAllPerformances(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid page: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -239,7 +224,7 @@ func (h *Controller) GetAllPerformances(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid limit: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -251,7 +236,7 @@ func (h *Controller) GetAllPerformances(c *gin.Context) {
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ResponseError{
 				ErrorMessage: "Invalid company ID format: " + err.Error(),
-				ErrorCode:    "Bad Request",
+				ErrorCode:    apperr.CodeBadRequest,
 			})
 			return
 		}
@@ -264,7 +249,7 @@ func (h *Controller) GetAllPerformances(c *gin.Context) {
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ResponseError{
 				ErrorMessage: "Invalid employee ID format: " + err.Error(),
-				ErrorCode:    "Bad Request",
+				ErrorCode:    apperr.CodeBadRequest,
 			})
 			return
 		}
@@ -275,7 +260,7 @@ func (h *Controller) GetAllPerformances(c *gin.Context) {
 	if whoseFault != "" && whoseFault != "Driver" && whoseFault != "Dispatcher" && whoseFault != "Company" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid query parameter: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -284,7 +269,7 @@ func (h *Controller) GetAllPerformances(c *gin.Context) {
 	if status != "" && status != "success" && status != "canceled" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid query parameter: ",
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -296,14 +281,12 @@ func (h *Controller) GetAllPerformances(c *gin.Context) {
 		WhoseFault: whoseFault,
 		Status:     status,
 		EmployeeId: employeeId,
+		Draft:      c.Query("draft"),
 	}
 
 	performances, err := h.service.Performance().GetAll(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving performances: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving performances", err)
 		return
 	}
 
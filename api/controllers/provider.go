@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/providers [post]
+// @Summary Create a provider
+// @Description API for creating a new broker/provider
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Param provider body swag.CreateUpdateProvider true "Provider data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateProvider(c *gin.Context) {
+	var providerModel swag.CreateUpdateProvider
+	if !BindJSON(c, &providerModel) {
+		return
+	}
+
+	provider := models.Provider{
+		Name:         providerModel.Name,
+		MC:           providerModel.MC,
+		DOT:          providerModel.DOT,
+		ContactName:  providerModel.ContactName,
+		ContactPhone: providerModel.ContactPhone,
+		ContactEmail: providerModel.ContactEmail,
+		PaymentTerms: providerModel.PaymentTerms,
+		CreditNotes:  providerModel.CreditNotes,
+		Blacklisted:  providerModel.Blacklisted,
+	}
+
+	id, err := h.service.Provider().Create(c.Request.Context(), &provider)
+	if err != nil {
+		HandleError(c, "Error while creating a provider", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/providers/{provider_id} [put]
+// @Summary Update a provider
+// @Description API for updating a broker/provider
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Param provider_id path string true "Provider ID"
+// @Param provider body swag.CreateUpdateProvider true "Provider data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateProvider(c *gin.Context) {
+	var providerModel swag.CreateUpdateProvider
+
+	providerId, err := uuid.Parse(c.Param("provider_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &providerModel) {
+		return
+	}
+
+	provider := models.Provider{
+		Id:           providerId,
+		Name:         providerModel.Name,
+		MC:           providerModel.MC,
+		DOT:          providerModel.DOT,
+		ContactName:  providerModel.ContactName,
+		ContactPhone: providerModel.ContactPhone,
+		ContactEmail: providerModel.ContactEmail,
+		PaymentTerms: providerModel.PaymentTerms,
+		CreditNotes:  providerModel.CreditNotes,
+		Blacklisted:  providerModel.Blacklisted,
+	}
+
+	if err := h.service.Provider().Update(c.Request.Context(), &provider); err != nil {
+		HandleError(c, "Error while updating the provider", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Provider updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/providers/{provider_id} [delete]
+// @Summary Delete a provider
+// @Description API for deleting a broker/provider
+// @Tags provider
+// @Param provider_id path string true "Provider ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteProvider(c *gin.Context) {
+	providerId, err := uuid.Parse(c.Param("provider_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Provider().Delete(c.Request.Context(), models.RequestId{Id: providerId})
+	if err != nil {
+		HandleError(c, "Error while deleting the provider", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Provider deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/providers/{provider_id} [get]
+// @Summary Get a provider by ID
+// @Description API for retrieving a broker/provider by ID together with its receivables aging
+// @Tags provider
+// @Param provider_id path string true "Provider ID"
+// @Success 200 {object} models.Provider
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetProvider(c *gin.Context) {
+	providerId, err := uuid.Parse(c.Param("provider_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	provider, err := h.service.Provider().Get(c.Request.Context(), models.RequestId{Id: providerId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the provider", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/providers [get]
+// @Summary Get all providers
+// @Description API for retrieving all brokers/providers with pagination and search
+// @Tags provider
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of providers per page"
+// @Param search query string false "Name, MC or DOT"
+// @Param blacklisted query bool false "Blacklisted"
+// @Success 200 {object} models.GetAllProvidersResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllProviders(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	blacklisted := c.Query("blacklisted")
+	if blacklisted != "" && blacklisted != "true" && blacklisted != "false" {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid blacklisted: " + blacklisted,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	req := models.GetAllProvidersReq{
+		Page:        page,
+		Limit:       limit,
+		Search:      c.Query("search"),
+		Blacklisted: blacklisted,
+	}
+
+	providers, err := h.service.Provider().GetAll(c.Request.Context(), req)
+	if err != nil {
+		HandleError(c, "Error while retrieving providers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, providers)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/providers/{provider_id}/stats [get]
+// @Summary Get provider statistics
+// @Description API for retrieving loads, gross, average RPM and cancellation rate of a provider
+// @Tags provider
+// @Param provider_id path string true "Provider ID"
+// @Success 200 {object} models.ProviderStats
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetProviderStats(c *gin.Context) {
+	providerId, err := uuid.Parse(c.Param("provider_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	stats, err := h.service.Provider().Stats(c.Request.Context(), models.RequestId{Id: providerId})
+	if err != nil {
+		HandleError(c, "Error while retrieving provider stats", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,483 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/drivers/{driver_id}/pay_profile [put]
+// @Summary Save a driver's pay profile
+// @Description API for setting how a driver is paid: CPM (loaded_rate and empty_rate per mile) or PERCENT (percent of the load gross)
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param driver_id path string true "Driver ID"
+// @Param profile body swag.SavePayProfile true "Pay profile"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) SavePayProfile(c *gin.Context) {
+	var profileModel swag.SavePayProfile
+
+	driverId, err := uuid.Parse(c.Param("driver_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &profileModel) {
+		return
+	}
+
+	err = h.service.Settlement().SavePayProfile(c.Request.Context(), &models.PayProfile{
+		DriverId:   driverId,
+		Method:     profileModel.Method,
+		LoadedRate: profileModel.LoadedRate,
+		EmptyRate:  profileModel.EmptyRate,
+		Percent:    profileModel.Percent,
+	})
+	if err != nil {
+		HandleError(c, "Error while saving the pay profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Pay profile saved successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/drivers/{driver_id}/pay_profile [get]
+// @Summary Get a driver's pay profile
+// @Description API for retrieving how a driver is paid
+// @Tags settlement
+// @Param driver_id path string true "Driver ID"
+// @Success 200 {object} models.PayProfile
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetPayProfile(c *gin.Context) {
+	driverId, err := uuid.Parse(c.Param("driver_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	profile, err := h.service.Settlement().GetPayProfile(c.Request.Context(), driverId)
+	if err != nil {
+		HandleError(c, "Error while retrieving the pay profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlement_adjustments [post]
+// @Summary Create a settlement adjustment
+// @Description API for adding a deduction, advance or reimbursement to a driver's settlements. Recurring adjustments apply every week from date until end_date
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param adjustment body swag.CreateSettlementAdjustment true "Adjustment data"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateSettlementAdjustment(c *gin.Context) {
+	var adjustmentModel swag.CreateSettlementAdjustment
+	if !BindJSON(c, &adjustmentModel) {
+		return
+	}
+
+	driverId, err := uuid.Parse(adjustmentModel.DriverId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	date, err := time.Parse(validation.DateLayout, adjustmentModel.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid date format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	var endDate *time.Time
+	if adjustmentModel.EndDate != "" {
+		parsed, err := time.Parse(validation.DateLayout, adjustmentModel.EndDate)
+		if err != nil || parsed.Before(date) {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid end date: " + adjustmentModel.EndDate,
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		endDate = &parsed
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.Settlement().CreateAdjustment(c.Request.Context(), &models.SettlementAdjustment{
+		DriverId:    driverId,
+		Type:        adjustmentModel.Type,
+		Description: adjustmentModel.Description,
+		Amount:      adjustmentModel.Amount,
+		Date:        date,
+		Recurring:   adjustmentModel.Recurring,
+		EndDate:     endDate,
+		EmployeeId:  userId,
+	})
+	if err != nil {
+		HandleError(c, "Error while creating an adjustment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlement_adjustments/{adjustment_id} [delete]
+// @Summary Delete a settlement adjustment
+// @Description API for deleting an adjustment that is not on a locked settlement
+// @Tags settlement
+// @Param adjustment_id path string true "Adjustment ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteSettlementAdjustment(c *gin.Context) {
+	adjustmentId, err := uuid.Parse(c.Param("adjustment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid adjustment ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Settlement().DeleteAdjustment(c.Request.Context(), models.RequestId{Id: adjustmentId})
+	if err != nil {
+		HandleError(c, "Error while deleting the adjustment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Adjustment deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlement_adjustments [get]
+// @Summary Get all settlement adjustments
+// @Description API for retrieving deductions, advances and reimbursements
+// @Tags settlement
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of adjustments per page"
+// @Param driver_id query string false "Driver ID"
+// @Param pending query bool false "Only adjustments not yet settled"
+// @Success 200 {object} models.GetAllAdjustmentsResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllSettlementAdjustments(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	driverId, err := ParseUUIDQueryParam(c, "driver_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	adjustments, err := h.service.Settlement().GetAllAdjustments(c.Request.Context(), models.GetAllAdjustmentsReq{
+		Page:     page,
+		Limit:    limit,
+		DriverId: driverId,
+		Pending:  c.Query("pending") == "true",
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving adjustments", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, adjustments)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlements/run [post]
+// @Summary Run weekly settlements
+// @Description API for computing the settlements of all drivers with a pay profile for a week (Monday to Sunday, by delivery time). Earlier loads no settlement has paid yet, like ones entered after their week was locked, are added to the week. Draft settlements are recomputed, locked ones are skipped
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param run body swag.RunSettlements true "Week start (Monday, 2006-01-02)"
+// @Success 200 {object} models.SettlementRunResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) RunSettlements(c *gin.Context) {
+	var runModel swag.RunSettlements
+	if !BindJSON(c, &runModel) {
+		return
+	}
+
+	weekStart, err := time.Parse(validation.DateLayout, runModel.WeekStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid week start format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if weekStart.Weekday() != time.Monday {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Week start must be a Monday",
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	resp, err := h.service.Settlement().Run(c.Request.Context(), weekStart)
+	if err != nil {
+		HandleError(c, "Error while running settlements", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlements/{settlement_id}/lock [put]
+// @Summary Lock a settlement
+// @Description API for locking a settlement once it is paid. Locked settlements are never recomputed
+// @Tags settlement
+// @Param settlement_id path string true "Settlement ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) LockSettlement(c *gin.Context) {
+	settlementId, err := uuid.Parse(c.Param("settlement_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid settlement ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	idStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ResponseError{
+			ErrorMessage: "No user id found in context",
+			ErrorCode:    apperr.CodeUnauthorized,
+		})
+		return
+	}
+	userId, err := uuid.Parse(idStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Settlement().Lock(c.Request.Context(), models.RequestId{Id: settlementId}, models.RequestId{Id: userId})
+	if err != nil {
+		HandleError(c, "Error while locking the settlement", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Settlement locked successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlements/{settlement_id} [get]
+// @Summary Get a settlement by ID
+// @Description API for retrieving a settlement with its lines
+// @Tags settlement
+// @Param settlement_id path string true "Settlement ID"
+// @Success 200 {object} models.Settlement
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetSettlement(c *gin.Context) {
+	settlementId, err := uuid.Parse(c.Param("settlement_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid settlement ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	settlement, err := h.service.Settlement().Get(c.Request.Context(), models.RequestId{Id: settlementId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the settlement", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settlement)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlements/{settlement_id}/export [get]
+// @Summary Export a settlement statement
+// @Description API for downloading a locked settlement as a CSV pay statement
+// @Tags settlement
+// @Produce text/csv
+// @Param settlement_id path string true "Settlement ID"
+// @Success 200 {file} file
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) ExportSettlement(c *gin.Context) {
+	settlementId, err := uuid.Parse(c.Param("settlement_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid settlement ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	settlement, statement, err := h.service.Settlement().Export(c.Request.Context(), models.RequestId{Id: settlementId})
+	if err != nil {
+		HandleError(c, "Error while exporting the settlement", err)
+		return
+	}
+
+	filename := settlement.Driver.Name + "_" + settlement.Driver.Surname + "_" + settlement.WeekStart.Format("2006-01-02") + ".csv"
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "text/csv", statement)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/settlements [get]
+// @Summary Get all settlements
+// @Description API for retrieving settlements with pagination and filters
+// @Tags settlement
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of settlements per page"
+// @Param driver_id query string false "Driver ID"
+// @Param week_start query string false "Week start (2006-01-02)"
+// @Param status query string false "DRAFT or LOCKED"
+// @Success 200 {object} models.GetAllSettlementsResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllSettlements(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	driverId, err := ParseUUIDQueryParam(c, "driver_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid driver ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	var weekStart *time.Time
+	if weekStartStr := c.Query("week_start"); weekStartStr != "" {
+		parsed, err := time.Parse(validation.DateLayout, weekStartStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseError{
+				ErrorMessage: "Invalid week start format: " + err.Error(),
+				ErrorCode:    apperr.CodeBadRequest,
+			})
+			return
+		}
+		weekStart = &parsed
+	}
+
+	status := c.Query("status")
+	if status != "" && status != models.SettlementStatusDraft && status != models.SettlementStatusLocked {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid status: " + status,
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	settlements, err := h.service.Settlement().GetAll(c.Request.Context(), models.GetAllSettlementsReq{
+		Page:      page,
+		Limit:     limit,
+		DriverId:  driverId,
+		WeekStart: weekStart,
+		Status:    status,
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving settlements", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settlements)
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"backend/etc/apperr"
+	"backend/etc/validation"
 	"backend/models"
 	"backend/models/swag"
 	"github.com/gin-gonic/gin"
@@ -19,31 +21,28 @@ import (
 // @Param transaction body swag.CreateUpdateTransaction true "Transaction data"
 // @Success 200 {object} models.ResponseId
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) CreateTransaction(c *gin.Context) {
 	var transactionModel swag.CreateUpdateTransaction
-	if err := c.ShouldBindJSON(&transactionModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &transactionModel) {
 		return
 	}
 
-	puTime, err := time.Parse("2006-01-02T15:04:05", transactionModel.PuTime)
+	puTime, err := time.Parse(validation.DateTimeLayout, transactionModel.PuTime)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid pickup time format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	deliveryTime, err := time.Parse("2006-01-02T15:04:05", transactionModel.DeliveryTime)
+	deliveryTime, err := time.Parse(validation.DateTimeLayout, transactionModel.DeliveryTime)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid delivery time format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -52,7 +51,7 @@ func (h *Controller) CreateTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid driver ID: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -61,7 +60,16 @@ func (h *Controller) CreateTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid employee ID: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	providerId, err := ParseOptionalUUID(transactionModel.ProviderId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -75,6 +83,7 @@ func (h *Controller) CreateTransaction(c *gin.Context) {
 		LoadedMiles:  transactionModel.LoadedMiles,
 		TotalMiles:   transactionModel.TotalMiles,
 		Provider:     transactionModel.Provider,
+		ProviderId:   providerId,
 		Cost:         transactionModel.Cost,
 		Rate:         transactionModel.Rate,
 		DriverId:     driverId,
@@ -84,10 +93,7 @@ func (h *Controller) CreateTransaction(c *gin.Context) {
 
 	id, err := h.service.Transaction().Create(c.Request.Context(), &transaction)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while creating a transaction: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while creating a transaction", err)
 		return
 	}
 
@@ -102,28 +108,25 @@ func (h *Controller) CreateTransaction(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param transaction_id path string true "Transaction ID"
-// @Param transaction body swag.CreateUpdateTransaction true "Transaction data"
+// @Param transaction body swag.UpdateTransaction true "Transaction data, with the times in RFC 3339"
 // @Success 200 {object} models.ResponseSuccess
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) UpdateTransaction(c *gin.Context) {
-	var transactionModel swag.CreateUpdateTransaction
+	var transactionModel swag.UpdateTransaction
 	transactionIdStr := c.Param("transaction_id")
 
 	transactionId, err := uuid.Parse(transactionIdStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid transaction ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	if err := c.ShouldBindJSON(&transactionModel); err != nil {
-		c.JSON(http.StatusBadRequest, models.ResponseError{
-			ErrorMessage: "Error while binding JSON: " + err.Error(),
-			ErrorCode:    "Bad Request",
-		})
+	if !BindJSON(c, &transactionModel) {
 		return
 	}
 
@@ -131,7 +134,7 @@ func (h *Controller) UpdateTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid pickup time format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -140,7 +143,7 @@ func (h *Controller) UpdateTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid delivery time format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -149,7 +152,7 @@ func (h *Controller) UpdateTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid driver ID: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -158,7 +161,16 @@ func (h *Controller) UpdateTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid employee ID: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	providerId, err := ParseOptionalUUID(transactionModel.ProviderId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -173,6 +185,7 @@ func (h *Controller) UpdateTransaction(c *gin.Context) {
 		LoadedMiles:  transactionModel.LoadedMiles,
 		TotalMiles:   transactionModel.TotalMiles,
 		Provider:     transactionModel.Provider,
+		ProviderId:   providerId,
 		Cost:         transactionModel.Cost,
 		Rate:         transactionModel.Rate,
 		DriverId:     driverId,
@@ -181,10 +194,7 @@ func (h *Controller) UpdateTransaction(c *gin.Context) {
 	}
 
 	if err := h.service.Transaction().Update(c.Request.Context(), &transaction); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while updating the transaction: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while updating the transaction", err)
 		return
 	}
 
@@ -208,17 +218,14 @@ func (h *Controller) DeleteTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid transaction ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = h.service.Transaction().Delete(c.Request.Context(), models.RequestId{Id: transactionId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while deleting the transaction: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while deleting the transaction", err)
 		return
 	}
 
@@ -235,6 +242,7 @@ func (h *Controller) DeleteTransaction(c *gin.Context) {
 // @Param transaction_id path string true "Transaction ID"
 // @Success 200 {object} models.Transaction
 // @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
 // @Failure 500 {object} models.ResponseError "Internal server error"
 func (h *Controller) GetTransaction(c *gin.Context) {
 	transactionIdStr := c.Param("transaction_id")
@@ -242,17 +250,14 @@ func (h *Controller) GetTransaction(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid transaction ID format: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	transaction, err := h.service.Transaction().Get(c.Request.Context(), models.RequestId{Id: transactionId})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving the transaction: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving the transaction", err)
 		return
 	}
 
@@ -267,6 +272,7 @@ func (h *Controller) GetTransaction(c *gin.Context) {
 // @Param page query int false "Page number"
 // @Param limit query int false "Number of transactions per page"
 // @Param provider query string false "Service Provider"
+// @Param provider_id query string false "Provider ID"
 // @Param success query bool false "Success"
 // @Param cargo_id query string false "Cargo Id"
 // @Param driver_name query string false "Driver Name"
@@ -279,7 +285,7 @@ func (h *Controller) GetAllTransactions(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid page: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -288,7 +294,7 @@ func (h *Controller) GetAllTransactions(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid limit: " + err.Error(),
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -296,13 +302,23 @@ func (h *Controller) GetAllTransactions(c *gin.Context) {
 	cargoId := c.Query("cargo_id")
 
 	provider := c.Query("provider")
+
+	providerId, err := ParseUUIDQueryParam(c, "provider_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid provider ID: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
 	driverName := c.Query("driver_name")
 	dispatcherName := c.Query("dispatcher_name")
 	success := c.Query("success")
 	if success != "" && success != "true" && success != "false" {
 		c.JSON(http.StatusBadRequest, models.ResponseError{
 			ErrorMessage: "Invalid success: " + success,
-			ErrorCode:    "Bad Request",
+			ErrorCode:    apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -311,6 +327,7 @@ func (h *Controller) GetAllTransactions(c *gin.Context) {
 		Page:           page,
 		Limit:          limit,
 		Provider:       provider,
+		ProviderId:     providerId,
 		CargoID:        cargoId,
 		DriverName:     driverName,
 		DispatcherName: dispatcherName,
@@ -319,10 +336,7 @@ func (h *Controller) GetAllTransactions(c *gin.Context) {
 
 	transactions, err := h.service.Transaction().GetAll(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ResponseError{
-			ErrorMessage: "Error while retrieving transactions: " + err.Error(),
-			ErrorCode:    "Internal Server Error",
-		})
+		HandleError(c, "Error while retrieving transactions", err)
 		return
 	}
 
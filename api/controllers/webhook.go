@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	"backend/models/swag"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_subscriptions [post]
+// @Summary Create a webhook subscription
+// @Description API for subscribing a URL to load events (load.covered, load.picked_up, load.delivered, load.cancelled), comma separated or empty for all. Requests are signed with HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" in X-Webhook-Signature. The secret is generated unless given and only returned here
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription body swag.CreateUpdateWebhookSubscription true "Subscription data"
+// @Success 200 {object} models.CreateSubscriptionResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) CreateWebhookSubscription(c *gin.Context) {
+	var subscriptionModel swag.CreateUpdateWebhookSubscription
+	if !BindJSON(c, &subscriptionModel) {
+		return
+	}
+
+	subscription, err := subscriptionFromSwag(subscriptionModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	resp, err := h.service.Webhook().CreateSubscription(c.Request.Context(), subscription)
+	if err != nil {
+		HandleError(c, "Error while creating a webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_subscriptions/{subscription_id} [put]
+// @Summary Update a webhook subscription
+// @Description API for updating a webhook subscription. The secret is kept when left empty
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription_id path string true "Subscription ID"
+// @Param subscription body swag.CreateUpdateWebhookSubscription true "Subscription data"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 422 {object} models.ResponseError "Invalid fields"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) UpdateWebhookSubscription(c *gin.Context) {
+	var subscriptionModel swag.CreateUpdateWebhookSubscription
+
+	subscriptionId, err := uuid.Parse(c.Param("subscription_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid subscription ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !BindJSON(c, &subscriptionModel) {
+		return
+	}
+
+	subscription, err := subscriptionFromSwag(subscriptionModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid company ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+	subscription.Id = subscriptionId
+
+	if err := h.service.Webhook().UpdateSubscription(c.Request.Context(), subscription); err != nil {
+		HandleError(c, "Error while updating the webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Webhook subscription updated successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_subscriptions/{subscription_id} [delete]
+// @Summary Delete a webhook subscription
+// @Description API for deleting a webhook subscription. Its pending deliveries fail
+// @Tags webhooks
+// @Param subscription_id path string true "Subscription ID"
+// @Success 200 {object} models.ResponseSuccess
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) DeleteWebhookSubscription(c *gin.Context) {
+	subscriptionId, err := uuid.Parse(c.Param("subscription_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid subscription ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	err = h.service.Webhook().DeleteSubscription(c.Request.Context(), models.RequestId{Id: subscriptionId})
+	if err != nil {
+		HandleError(c, "Error while deleting the webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseSuccess{
+		Message: "Webhook subscription deleted successfully",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_subscriptions/{subscription_id} [get]
+// @Summary Get a webhook subscription by ID
+// @Description API for retrieving a webhook subscription by ID
+// @Tags webhooks
+// @Param subscription_id path string true "Subscription ID"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetWebhookSubscription(c *gin.Context) {
+	subscriptionId, err := uuid.Parse(c.Param("subscription_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid subscription ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	subscription, err := h.service.Webhook().GetSubscription(c.Request.Context(), models.RequestId{Id: subscriptionId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_subscriptions [get]
+// @Summary Get all webhook subscriptions
+// @Description API for retrieving all webhook subscriptions
+// @Tags webhooks
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllWebhookSubscriptions(c *gin.Context) {
+	subscriptions, err := h.service.Webhook().GetSubscriptions(c.Request.Context())
+	if err != nil {
+		HandleError(c, "Error while retrieving webhook subscriptions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_deliveries [get]
+// @Summary Get the webhook delivery log
+// @Description API for retrieving webhook deliveries with their attempts and last outcome
+// @Tags webhooks
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of deliveries per page"
+// @Param subscription_id query string false "Subscription ID"
+// @Param event_id query string false "Event ID"
+// @Param status query string false "PENDING, SUCCEEDED or FAILED"
+// @Success 200 {object} models.GetAllDeliveriesResp
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetAllWebhookDeliveries(c *gin.Context) {
+	page, err := ParsePageQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid page: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	limit, err := ParseLimitQueryParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid limit: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	subscriptionId, err := ParseUUIDQueryParam(c, "subscription_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid subscription ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	eventId, err := ParseUUIDQueryParam(c, "event_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid event ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	deliveries, err := h.service.Webhook().GetAllDeliveries(c.Request.Context(), models.GetAllDeliveriesReq{
+		Page:           page,
+		Limit:          limit,
+		SubscriptionId: subscriptionId,
+		EventId:        eventId,
+		Status:         strings.ToUpper(c.Query("status")),
+	})
+	if err != nil {
+		HandleError(c, "Error while retrieving webhook deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_deliveries/{delivery_id} [get]
+// @Summary Get a webhook delivery by ID
+// @Description API for retrieving a webhook delivery with its event
+// @Tags webhooks
+// @Param delivery_id path string true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 404 {object} models.ResponseError "Not found"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) GetWebhookDelivery(c *gin.Context) {
+	deliveryId, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid delivery ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	delivery, err := h.service.Webhook().GetDelivery(c.Request.Context(), models.RequestId{Id: deliveryId})
+	if err != nil {
+		HandleError(c, "Error while retrieving the webhook delivery", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// @Security ApiKeyAuth
+// @Router /v1/webhook_deliveries/{delivery_id}/replay [post]
+// @Summary Replay a webhook delivery
+// @Description API for sending the event of a delivery to its subscription again as a new delivery
+// @Tags webhooks
+// @Param delivery_id path string true "Delivery ID"
+// @Success 200 {object} models.ResponseId
+// @Failure 400 {object} models.ResponseError "Invalid input"
+// @Failure 500 {object} models.ResponseError "Internal server error"
+func (h *Controller) ReplayWebhookDelivery(c *gin.Context) {
+	deliveryId, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseError{
+			ErrorMessage: "Invalid delivery ID format: " + err.Error(),
+			ErrorCode:    apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	id, err := h.service.Webhook().Replay(c.Request.Context(), models.RequestId{Id: deliveryId})
+	if err != nil {
+		HandleError(c, "Error while replaying the webhook delivery", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseId{Id: id})
+}
+
+func subscriptionFromSwag(m swag.CreateUpdateWebhookSubscription) (*models.WebhookSubscription, error) {
+	companyId, err := ParseOptionalUUID(m.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebhookSubscription{
+		Name:       m.Name,
+		URL:        m.URL,
+		Secret:     m.Secret,
+		EventTypes: strings.ToLower(m.EventTypes),
+		CompanyId:  companyId,
+		Active:     m.Active,
+	}, nil
+}
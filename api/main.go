@@ -4,6 +4,10 @@ import (
 	"backend/api/controllers"
 	"backend/api/middleware"
 	_ "backend/docs" //for swagger
+	"backend/etc/config"
+	"backend/etc/logging"
+	"backend/etc/metrics"
+	"backend/etc/tracing"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -13,15 +17,18 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
-func Construct(cont controllers.Controller) *gin.Engine {
-	r := gin.Default()
+func Construct(cont controllers.Controller, corsConfig config.CORSConfig, metricsConfig config.MetricsConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(tracing.Middleware(), logging.Middleware(), logging.Recovery(), metrics.Middleware())
 
+	// "*" is taken literally on requests with credentials, so the headers of
+	// conditional updates are listed as well.
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
+		AllowOrigins:     corsConfig.AllowOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"*"},
-		ExposeHeaders:    []string{"*"},
-		AllowCredentials: true,
+		AllowHeaders:     []string{"*", "Authorization", "Content-Type", "If-Match"},
+		ExposeHeaders:    []string{"*", "ETag"},
+		AllowCredentials: corsConfig.AllowCredentials,
 	}))
 
 	// To start again
@@ -30,6 +37,13 @@ func Construct(cont controllers.Controller) *gin.Engine {
 	api := r.Group("/v1")
 	{
 		r.GET("/", func(c *gin.Context) { c.JSON(200, gin.H{"message": "pong"}) })
+		r.GET("/healthz", cont.Healthz)
+		r.GET("/readyz", cont.Readyz)
+		if metricsConfig.Token != "" {
+			r.GET("/metrics", middleware.TokenMiddleware(metricsConfig.Token), metrics.Handler())
+		} else {
+			r.GET("/metrics", metrics.Handler())
+		}
 
 		//Auth endpoints
 		api.POST("/login", cont.Login)
@@ -68,6 +82,20 @@ func Construct(cont controllers.Controller) *gin.Engine {
 		api.POST("/terminate_logistics", middleware.AuthMiddleware(3), cont.TerminateLogistic)
 		api.POST("/cancel_late_logistics", middleware.AuthMiddleware(3), cont.CancelLateLogistic)
 		api.GET("/logistics/overview", middleware.AuthMiddleware(3), cont.Overview)
+		api.PUT("/stops/:stop_id", middleware.AuthMiddleware(3), cont.UpdateStopStatus)
+
+		// Provider endpoints
+		api.POST("/providers", middleware.AuthMiddleware(2), cont.CreateProvider)
+		api.PUT("/providers/:provider_id", middleware.AuthMiddleware(2), cont.UpdateProvider)
+		api.DELETE("/providers/:provider_id", middleware.AuthMiddleware(2), cont.DeleteProvider)
+		api.GET("/providers/:provider_id", middleware.AuthMiddleware(3), cont.GetProvider)
+		api.GET("/providers/:provider_id/stats", middleware.AuthMiddleware(3), cont.GetProviderStats)
+		api.GET("/providers/:provider_id/ledger", middleware.AuthMiddleware(2), cont.GetProviderLedger)
+		api.GET("/providers", middleware.AuthMiddleware(3), cont.GetAllProviders)
+
+		// Cargo endpoints
+		api.GET("/cargos/:cargo_id", middleware.AuthMiddleware(3), cont.GetCargo)
+		api.GET("/cargos", middleware.AuthMiddleware(3), cont.GetAllCargos)
 
 		// Transaction endpoints
 		api.POST("/transactions", middleware.AuthMiddleware(1), cont.CreateTransaction)
@@ -76,6 +104,88 @@ func Construct(cont controllers.Controller) *gin.Engine {
 		api.GET("/transactions/:transaction_id", middleware.AuthMiddleware(2), cont.GetTransaction)
 		api.GET("/transactions", middleware.AuthMiddleware(2), cont.GetAllTransactions)
 
+		// Invoice endpoints
+		api.POST("/invoices", middleware.AuthMiddleware(2), cont.GenerateInvoice)
+		api.PUT("/invoices/:invoice_id/status", middleware.AuthMiddleware(2), cont.UpdateInvoiceStatus)
+		api.POST("/invoices/:invoice_id/payments", middleware.AuthMiddleware(2), cont.CreateInvoicePayment)
+		api.POST("/invoices/:invoice_id/short_pay", middleware.AuthMiddleware(2), cont.ShortPayInvoice)
+		api.PUT("/invoices/:invoice_id/factoring", middleware.AuthMiddleware(2), cont.UpdateInvoiceFactoring)
+		api.DELETE("/invoices/:invoice_id", middleware.AuthMiddleware(2), cont.DeleteInvoice)
+		api.GET("/invoices/:invoice_id", middleware.AuthMiddleware(2), cont.GetInvoice)
+		api.GET("/invoices/:invoice_id/pdf", middleware.AuthMiddleware(2), cont.GetInvoicePDF)
+		api.GET("/invoices", middleware.AuthMiddleware(2), cont.GetAllInvoices)
+		api.GET("/ar/aging", middleware.AuthMiddleware(2), cont.GetARAging)
+
+		// Settlement endpoints
+		api.PUT("/drivers/:driver_id/pay_profile", middleware.AuthMiddleware(1), cont.SavePayProfile)
+		api.GET("/drivers/:driver_id/pay_profile", middleware.AuthMiddleware(2), cont.GetPayProfile)
+		api.POST("/settlement_adjustments", middleware.AuthMiddleware(1), cont.CreateSettlementAdjustment)
+		api.DELETE("/settlement_adjustments/:adjustment_id", middleware.AuthMiddleware(1), cont.DeleteSettlementAdjustment)
+		api.GET("/settlement_adjustments", middleware.AuthMiddleware(2), cont.GetAllSettlementAdjustments)
+		api.POST("/settlements/run", middleware.AuthMiddleware(1), cont.RunSettlements)
+		api.PUT("/settlements/:settlement_id/lock", middleware.AuthMiddleware(1), cont.LockSettlement)
+		api.GET("/settlements/:settlement_id", middleware.AuthMiddleware(2), cont.GetSettlement)
+		api.GET("/settlements/:settlement_id/export", middleware.AuthMiddleware(2), cont.ExportSettlement)
+		api.GET("/settlements", middleware.AuthMiddleware(2), cont.GetAllSettlements)
+
+		// Compliance endpoints
+		api.PUT("/drivers/:driver_id/compliance", middleware.AuthMiddleware(2), cont.SaveDriverCompliance)
+		api.GET("/drivers/:driver_id/compliance", middleware.AuthMiddleware(3), cont.GetDriverCompliance)
+		api.GET("/compliance/expiring", middleware.AuthMiddleware(3), cont.GetExpiringCompliance)
+		api.PUT("/drivers/:driver_id/hos", middleware.AuthMiddleware(2), cont.SaveDriverHOS)
+		api.GET("/drivers/:driver_id/hos", middleware.AuthMiddleware(3), cont.GetDriverHOS)
+
+		// Equipment endpoints
+		api.POST("/trucks", middleware.AuthMiddleware(2), cont.CreateTruck)
+		api.PUT("/trucks/:truck_id", middleware.AuthMiddleware(2), cont.UpdateTruck)
+		api.DELETE("/trucks/:truck_id", middleware.AuthMiddleware(2), cont.DeleteTruck)
+		api.GET("/trucks/:truck_id", middleware.AuthMiddleware(3), cont.GetTruck)
+		api.GET("/trucks", middleware.AuthMiddleware(3), cont.GetAllTrucks)
+		api.POST("/trailers", middleware.AuthMiddleware(2), cont.CreateTrailer)
+		api.PUT("/trailers/:trailer_id", middleware.AuthMiddleware(2), cont.UpdateTrailer)
+		api.DELETE("/trailers/:trailer_id", middleware.AuthMiddleware(2), cont.DeleteTrailer)
+		api.GET("/trailers/:trailer_id", middleware.AuthMiddleware(3), cont.GetTrailer)
+		api.GET("/trailers", middleware.AuthMiddleware(3), cont.GetAllTrailers)
+		api.PUT("/drivers/:driver_id/equipment", middleware.AuthMiddleware(2), cont.AssignEquipment)
+		api.GET("/equipment_assignments", middleware.AuthMiddleware(3), cont.GetAllEquipmentAssignments)
+		api.POST("/maintenance_records", middleware.AuthMiddleware(2), cont.CreateMaintenanceRecord)
+		api.PUT("/maintenance_records/:record_id", middleware.AuthMiddleware(2), cont.UpdateMaintenanceRecord)
+		api.DELETE("/maintenance_records/:record_id", middleware.AuthMiddleware(2), cont.DeleteMaintenanceRecord)
+		api.GET("/maintenance_records/:record_id", middleware.AuthMiddleware(3), cont.GetMaintenanceRecord)
+		api.GET("/maintenance_records", middleware.AuthMiddleware(3), cont.GetAllMaintenanceRecords)
+		api.POST("/maintenance_schedules", middleware.AuthMiddleware(2), cont.CreateMaintenanceSchedule)
+		api.PUT("/maintenance_schedules/:schedule_id", middleware.AuthMiddleware(2), cont.UpdateMaintenanceSchedule)
+		api.DELETE("/maintenance_schedules/:schedule_id", middleware.AuthMiddleware(2), cont.DeleteMaintenanceSchedule)
+		api.GET("/maintenance_schedules", middleware.AuthMiddleware(3), cont.GetAllMaintenanceSchedules)
+		api.GET("/maintenance/due", middleware.AuthMiddleware(3), cont.GetDueMaintenance)
+		api.GET("/downtimes", middleware.AuthMiddleware(3), cont.GetAllDowntimes)
+		api.GET("/downtimes/report", middleware.AuthMiddleware(2), cont.GetDowntimeReport)
+		api.POST("/alert_rules", middleware.AuthMiddleware(1), cont.CreateAlertRule)
+		api.PUT("/alert_rules/:rule_id", middleware.AuthMiddleware(1), cont.UpdateAlertRule)
+		api.DELETE("/alert_rules/:rule_id", middleware.AuthMiddleware(1), cont.DeleteAlertRule)
+		api.GET("/alert_rules/:rule_id", middleware.AuthMiddleware(2), cont.GetAlertRule)
+		api.GET("/alert_rules", middleware.AuthMiddleware(2), cont.GetAllAlertRules)
+		api.POST("/flag_rules", middleware.AuthMiddleware(1), cont.CreateFlagRule)
+		api.PUT("/flag_rules/:rule_id", middleware.AuthMiddleware(1), cont.UpdateFlagRule)
+		api.DELETE("/flag_rules/:rule_id", middleware.AuthMiddleware(1), cont.DeleteFlagRule)
+		api.GET("/flag_rules/:rule_id", middleware.AuthMiddleware(2), cont.GetFlagRule)
+		api.GET("/flag_rules", middleware.AuthMiddleware(2), cont.GetAllFlagRules)
+		api.GET("/jobs", middleware.AuthMiddleware(1), cont.GetAllJobs)
+		api.POST("/jobs/:name/run", middleware.AuthMiddleware(1), cont.TriggerJob)
+		api.GET("/job_runs", middleware.AuthMiddleware(1), cont.GetAllJobRuns)
+		api.GET("/alerts", middleware.AuthMiddleware(3), cont.GetAllAlerts)
+		api.GET("/alerts/:alert_id", middleware.AuthMiddleware(3), cont.GetAlert)
+		api.POST("/alerts/:alert_id/ack", middleware.AuthMiddleware(3), cont.AcknowledgeAlert)
+		api.POST("/alerts/:alert_id/snooze", middleware.AuthMiddleware(3), cont.SnoozeAlert)
+		api.POST("/webhook_subscriptions", middleware.AuthMiddleware(1), cont.CreateWebhookSubscription)
+		api.PUT("/webhook_subscriptions/:subscription_id", middleware.AuthMiddleware(1), cont.UpdateWebhookSubscription)
+		api.DELETE("/webhook_subscriptions/:subscription_id", middleware.AuthMiddleware(1), cont.DeleteWebhookSubscription)
+		api.GET("/webhook_subscriptions/:subscription_id", middleware.AuthMiddleware(1), cont.GetWebhookSubscription)
+		api.GET("/webhook_subscriptions", middleware.AuthMiddleware(1), cont.GetAllWebhookSubscriptions)
+		api.GET("/webhook_deliveries", middleware.AuthMiddleware(1), cont.GetAllWebhookDeliveries)
+		api.GET("/webhook_deliveries/:delivery_id", middleware.AuthMiddleware(1), cont.GetWebhookDelivery)
+		api.POST("/webhook_deliveries/:delivery_id/replay", middleware.AuthMiddleware(1), cont.ReplayWebhookDelivery)
+
 		// Performance endpoints
 		api.POST("/performances", middleware.AuthMiddleware(2), cont.CreatePerformance)
 		api.PUT("/performances/:performance_id", middleware.AuthMiddleware(2), cont.UpdatePerformance)
@@ -1,7 +1,11 @@
 package middleware
 
 import (
+	"backend/etc/apperr"
 	"backend/etc/jwt"
+	"backend/etc/logging"
+	"backend/models"
+	"crypto/subtle"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strings"
@@ -11,27 +15,39 @@ func AuthMiddleware(requiredAccessLevel int) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
+			c.JSON(http.StatusUnauthorized, models.ResponseError{
+				ErrorMessage: "Authorization header is missing",
+				ErrorCode:    apperr.CodeUnauthorized,
+			})
 			c.Abort()
 			return
 		}
 
 		tokenString := extractToken(authHeader)
 		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			c.JSON(http.StatusUnauthorized, models.ResponseError{
+				ErrorMessage: "Invalid authorization header format",
+				ErrorCode:    apperr.CodeUnauthorized,
+			})
 			c.Abort()
 			return
 		}
 
 		claims, err := jwt.ParseToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.JSON(http.StatusUnauthorized, models.ResponseError{
+				ErrorMessage: "Invalid or expired token",
+				ErrorCode:    apperr.CodeUnauthorized,
+			})
 			c.Abort()
 			return
 		}
 
 		if claims.AccessLevel > requiredAccessLevel {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Access level too low"})
+			c.JSON(http.StatusForbidden, models.ResponseError{
+				ErrorMessage: "Access level too low",
+				ErrorCode:    apperr.CodeForbidden,
+			})
 			c.Abort()
 			return
 		}
@@ -39,6 +55,25 @@ func AuthMiddleware(requiredAccessLevel int) gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("role", claims.Role)
 		c.Set("access_level", claims.AccessLevel)
+		logging.SetActor(c.Request.Context(), claims.UserID, claims.AccessLevel)
+
+		c.Next()
+	}
+}
+
+// TokenMiddleware lets through requests carrying token as their bearer token,
+// for machine clients like the metrics scraper that have no user to log in.
+func TokenMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := extractToken(c.GetHeader("Authorization"))
+		if subtle.ConstantTimeCompare([]byte(tokenString), []byte(token)) != 1 {
+			c.JSON(http.StatusUnauthorized, models.ResponseError{
+				ErrorMessage: "Invalid or missing token",
+				ErrorCode:    apperr.CodeUnauthorized,
+			})
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
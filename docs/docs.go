@@ -15,100 +15,72 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/cancel_late_logistics": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "API for canceling or marking late logistic record",
-                "consumes": [
-                    "application/json"
-                ],
+        "/healthz": {
+            "get": {
+                "description": "API that answers while the process serves requests, without checking its dependencies",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "logistic"
+                    "health"
                 ],
-                "summary": "Cancel or late logistics",
-                "parameters": [
-                    {
-                        "description": "Logistic data",
-                        "name": "logistic",
-                        "in": "body",
-                        "required": true,
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swag.CancelLogistic"
+                            "$ref": "#/definitions/models.HealthCheck"
                         }
                     }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "API that checks the database, the locations dataset and the background jobs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
                 ],
+                "summary": "Readiness probe",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSuccess"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
-                        "schema": {
-                            "$ref": "#/definitions/models.ResponseError"
+                            "$ref": "#/definitions/models.Readiness"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "A check failed",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseError"
+                            "$ref": "#/definitions/models.Readiness"
                         }
                     }
                 }
             }
         },
-        "/v1/companies": {
+        "/v1/alert_rules": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving all companies with pagination and search",
+                "description": "API for retrieving all alert rules",
                 "tags": [
-                    "company"
-                ],
-                "summary": "Get all companies",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of companies per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
-                    }
+                    "alerts"
                 ],
+                "summary": "Get all alert rules",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetAllCompaniesResp"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
-                        "schema": {
-                            "$ref": "#/definitions/models.ResponseError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AlertRule"
+                            }
                         }
                     },
                     "500": {
@@ -125,7 +97,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for creating a new company",
+                "description": "API for creating an alert rule. Kinds are STATUS_DURATION, ETA_NEAR_PICKUP, ETA_PASSED (threshold in minutes), LATE_LOAD (addressed to the dispatcher of the load) and DOCUMENT_EXPIRING (threshold in days). Statuses, channels (INBOX, EMAIL, WEBHOOK) and recipients are comma separated",
                 "consumes": [
                     "application/json"
                 ],
@@ -133,17 +105,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "company"
+                    "alerts"
                 ],
-                "summary": "Create a company",
+                "summary": "Create an alert rule",
                 "parameters": [
                     {
-                        "description": "Company data",
-                        "name": "company",
+                        "description": "Alert rule data",
+                        "name": "rule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateCompany"
+                            "$ref": "#/definitions/swag.CreateUpdateAlertRule"
                         }
                     }
                 ],
@@ -160,6 +132,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -169,23 +147,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/companies/{company_id}": {
+        "/v1/alert_rules/{rule_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving a company by ID",
+                "description": "API for retrieving an alert rule by ID",
                 "tags": [
-                    "company"
+                    "alerts"
                 ],
-                "summary": "Get a company by ID",
+                "summary": "Get an alert rule by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Company ID",
-                        "name": "company_id",
+                        "description": "Alert rule ID",
+                        "name": "rule_id",
                         "in": "path",
                         "required": true
                     }
@@ -194,7 +172,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Company"
+                            "$ref": "#/definitions/models.AlertRule"
                         }
                     },
                     "400": {
@@ -203,6 +181,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -217,7 +201,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for updating a company",
+                "description": "API for updating an alert rule",
                 "consumes": [
                     "application/json"
                 ],
@@ -225,24 +209,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "company"
+                    "alerts"
                 ],
-                "summary": "Update a company",
+                "summary": "Update an alert rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Company ID",
-                        "name": "company_id",
+                        "description": "Alert rule ID",
+                        "name": "rule_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Company data",
-                        "name": "company",
+                        "description": "Alert rule data",
+                        "name": "rule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateCompany"
+                            "$ref": "#/definitions/swag.CreateUpdateAlertRule"
                         }
                     }
                 ],
@@ -259,6 +243,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -273,16 +263,16 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for deleting a company",
+                "description": "API for deleting an alert rule",
                 "tags": [
-                    "company"
+                    "alerts"
                 ],
-                "summary": "Delete a company",
+                "summary": "Delete an alert rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Company ID",
-                        "name": "company_id",
+                        "description": "Alert rule ID",
+                        "name": "rule_id",
                         "in": "path",
                         "required": true
                     }
@@ -309,18 +299,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/drivers": {
+        "/v1/alerts": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving all drivers with pagination and search",
+                "description": "API for retrieving alerts. With inbox=true only the alerts delivered to the in-app inbox are returned",
                 "tags": [
-                    "driver"
+                    "alerts"
                 ],
-                "summary": "Get all drivers",
+                "summary": "Get all alerts",
                 "parameters": [
                     {
                         "type": "integer",
@@ -330,44 +320,38 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Number of drivers per page",
+                        "description": "Number of alerts per page",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Type of driver",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Position of driver",
-                        "name": "position",
+                        "description": "Alert rule ID",
+                        "name": "rule_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Company id",
-                        "name": "company_id",
+                        "description": "OPEN, ACKNOWLEDGED or RESOLVED",
+                        "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Drivers Name",
-                        "name": "name",
+                        "description": "INFO, WARNING or CRITICAL",
+                        "name": "severity",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Truck Number",
-                        "name": "truck_number",
+                        "type": "boolean",
+                        "description": "Only alerts of the in-app inbox",
+                        "name": "inbox",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "search",
+                        "description": "Only alerts addressed to this employee",
+                        "name": "employee_id",
                         "in": "query"
                     }
                 ],
@@ -375,7 +359,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetAllDriversResp"
+                            "$ref": "#/definitions/models.GetAllAlertsResp"
                         }
                     },
                     "400": {
@@ -391,40 +375,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/alerts/{alert_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for creating a new driver",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "API for retrieving an alert with its rule",
                 "tags": [
-                    "driver"
+                    "alerts"
                 ],
-                "summary": "Create a driver",
+                "summary": "Get an alert by ID",
                 "parameters": [
                     {
-                        "description": "Driver data",
-                        "name": "driver",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateDriver"
-                        }
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "alert_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseId"
+                            "$ref": "#/definitions/models.Alert"
                         }
                     },
                     "400": {
@@ -433,6 +411,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -442,23 +426,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/drivers/{driver_id}": {
-            "get": {
+        "/v1/alerts/{alert_id}/ack": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving a driver by ID",
+                "description": "API for acknowledging an open alert. It is not sent again until it resolves",
                 "tags": [
-                    "driver"
+                    "alerts"
                 ],
-                "summary": "Get a driver by ID",
+                "summary": "Acknowledge an alert",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Driver ID",
-                        "name": "driver_id",
+                        "description": "Alert ID",
+                        "name": "alert_id",
                         "in": "path",
                         "required": true
                     }
@@ -467,7 +451,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Driver"
+                            "$ref": "#/definitions/models.ResponseSuccess"
                         }
                     },
                     "400": {
@@ -483,14 +467,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/alerts/{alert_id}/snooze": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for updating a driver",
+                "description": "API for holding back notifications of an alert for the given number of minutes",
                 "consumes": [
                     "application/json"
                 ],
@@ -498,24 +484,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "driver"
+                    "alerts"
                 ],
-                "summary": "Update a driver",
+                "summary": "Snooze an alert",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Driver ID",
-                        "name": "driver_id",
+                        "description": "Alert ID",
+                        "name": "alert_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Driver data",
-                        "name": "driver",
+                        "description": "Snooze duration",
+                        "name": "snooze",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateDriver"
+                            "$ref": "#/definitions/swag.SnoozeAlert"
                         }
                     }
                 ],
@@ -532,43 +518,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ResponseError"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "API for deleting a driver",
-                "tags": [
-                    "driver"
-                ],
-                "summary": "Delete a driver",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Driver ID",
-                        "name": "driver_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.ResponseSuccess"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
+                    "422": {
+                        "description": "Invalid fields",
                         "schema": {
                             "$ref": "#/definitions/models.ResponseError"
                         }
@@ -582,41 +533,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/employees": {
+        "/v1/ar/aging": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving all employees with pagination and search",
+                "description": "API for retrieving the open balance of sent invoices per provider in 0-30, 31-60, 61-90 and 90+ day buckets",
                 "tags": [
-                    "employee"
+                    "invoice"
                 ],
-                "summary": "Get all employees",
+                "summary": "Get accounts receivable aging",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of employees per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Employee name",
-                        "name": "name",
+                        "description": "Company ID",
+                        "name": "company_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Employee position",
-                        "name": "position",
+                        "description": "Provider ID",
+                        "name": "provider_id",
                         "in": "query"
                     }
                 ],
@@ -624,7 +563,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetAllEmployeesResp"
+                            "$ref": "#/definitions/models.GetARAgingResp"
                         }
                     },
                     "400": {
@@ -640,14 +579,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/cancel_late_logistics": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for creating a new employee",
+                "description": "API for canceling or marking late logistic record",
                 "consumes": [
                     "application/json"
                 ],
@@ -655,17 +596,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "employee"
+                    "logistic"
                 ],
-                "summary": "Create an employee",
+                "summary": "Cancel or late logistics",
                 "parameters": [
                     {
-                        "description": "Employee data",
-                        "name": "employee",
+                        "description": "Logistic data",
+                        "name": "logistic",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateEmployee"
+                            "$ref": "#/definitions/swag.CancelLogistic"
                         }
                     }
                 ],
@@ -673,7 +614,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseId"
+                            "$ref": "#/definitions/models.ResponseSuccess"
                         }
                     },
                     "400": {
@@ -682,6 +623,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -691,32 +638,109 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/employees/{employee_id}": {
+        "/v1/cargos": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving an employee by ID",
+                "description": "API for listing and searching active and historical loads",
                 "tags": [
-                    "employee"
+                    "cargo"
                 ],
-                "summary": "Get an employee by ID",
+                "summary": "Get all cargos",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of cargos per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Employee ID",
+                        "description": "Load ID, provider or lane",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Load ID",
+                        "name": "cargo_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pickup location",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery location",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pickup date from (2006-01-02)",
+                        "name": "pick_up_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pickup date to, inclusive (2006-01-02)",
+                        "name": "pick_up_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dispatcher ID",
                         "name": "employee_id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dispatcher name",
+                        "name": "dispatcher_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ACTIVE, DELIVERED or CANCELLED",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.GetAllCargosResp"
                         }
                     },
                     "400": {
@@ -732,47 +756,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/cargos/{cargo_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for updating an employee",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "API for retrieving an active or historical load with its stops, driver and dispatcher",
                 "tags": [
-                    "employee"
+                    "cargo"
                 ],
-                "summary": "Update an employee",
+                "summary": "Get a cargo by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Employee ID",
-                        "name": "employee_id",
+                        "description": "Cargo ID",
+                        "name": "cargo_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Employee data",
-                        "name": "employee",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateEmployee"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSuccess"
+                            "$ref": "#/definitions/models.CargoResponse"
                         }
                     },
                     "400": {
@@ -781,6 +792,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -788,32 +805,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/companies": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for deleting an employee",
+                "description": "API for retrieving all companies with pagination and search",
                 "tags": [
-                    "employee"
+                    "company"
                 ],
-                "summary": "Delete an employee",
+                "summary": "Get all companies",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of companies per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Employee ID",
-                        "name": "employee_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSuccess"
+                            "$ref": "#/definitions/models.GetAllCompaniesResp"
                         }
                     },
                     "400": {
@@ -829,39 +859,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/histories": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving all history records with pagination and filters",
+                "description": "API for creating a new company",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "history"
+                    "company"
                 ],
-                "summary": "Get all history records",
+                "summary": "Create a company",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of records per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Company data",
+                        "name": "company",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateCompany"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetAllHistoryResp"
+                            "$ref": "#/definitions/models.ResponseId"
                         }
                     },
                     "400": {
@@ -870,6 +901,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -879,23 +916,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/histories/{history_id}": {
+        "/v1/companies/{company_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving a single history record by ID",
+                "description": "API for retrieving a company by ID",
                 "tags": [
-                    "history"
+                    "company"
                 ],
-                "summary": "Get a history record by ID",
+                "summary": "Get a company by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "History ID",
-                        "name": "history_id",
+                        "description": "Company ID",
+                        "name": "company_id",
                         "in": "path",
                         "required": true
                     }
@@ -904,7 +941,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.History"
+                            "$ref": "#/definitions/models.Company"
                         }
                     },
                     "400": {
@@ -913,6 +950,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -920,16 +963,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/login": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for login",
+                "description": "API for updating a company",
                 "consumes": [
                     "application/json"
                 ],
@@ -937,17 +978,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "company"
                 ],
-                "summary": "Log in",
+                "summary": "Update a company",
                 "parameters": [
                     {
-                        "description": "Employee data",
-                        "name": "employee",
+                        "type": "string",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Company data",
+                        "name": "company",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AuthReq"
+                            "$ref": "#/definitions/swag.CreateUpdateCompany"
                         }
                     }
                 ],
@@ -955,7 +1003,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AuthResp"
+                            "$ref": "#/definitions/models.ResponseSuccess"
                         }
                     },
                     "400": {
@@ -964,6 +1012,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -971,87 +1025,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/logistics": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving all logistic records with pagination and search",
+                "description": "API for deleting a company",
                 "tags": [
-                    "logistic"
+                    "company"
                 ],
-                "summary": "Get all logistic records",
+                "summary": "Delete a company",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of logistics per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Post",
-                        "name": "post",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Driver Type",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Driver Position",
-                        "name": "position",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Driver Name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Location",
-                        "name": "location",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "state",
-                        "name": "state",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "description": "Company IDs",
-                        "name": "company_ids",
-                        "in": "query"
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetAllLogisticsResp"
+                            "$ref": "#/definitions/models.ResponseSuccess"
                         }
                     },
                     "400": {
@@ -1067,40 +1066,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/compliance/expiring": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for creating a new logistic record",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "API for retrieving drivers whose documents expire within the given number of days, including already expired ones",
                 "tags": [
-                    "logistic"
+                    "compliance"
                 ],
-                "summary": "Create a logistic record",
+                "summary": "Get drivers with expiring documents",
                 "parameters": [
                     {
-                        "description": "Logistic data",
-                        "name": "logistic",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateLogistic"
-                        }
+                        "type": "integer",
+                        "description": "Days ahead (default 30)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseId"
+                            "$ref": "#/definitions/models.GetExpiringComplianceResp"
                         }
                     },
                     "400": {
@@ -1118,29 +1110,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/logistics/overview": {
+        "/v1/downtimes": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API to get an overview of driver statuses by company",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "API for retrieving the periods trucks spent in TRUCK ISSUES",
                 "tags": [
-                    "logistic"
+                    "maintenance"
+                ],
+                "summary": "Get all downtimes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of downtimes per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Truck ID",
+                        "name": "truck_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only downtimes that have not ended",
+                        "name": "open",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get driver overview",
                 "responses": {
                     "200": {
-                        "description": "Overview data",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetOverview"
+                            "$ref": "#/definitions/models.GetAllDowntimesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
                         }
                     },
                     "500": {
@@ -1152,32 +1176,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/logistics/{logistic_id}": {
+        "/v1/downtimes/report": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving a logistic record by ID",
+                "description": "API for summing the downtime hours per truck and per company between two dates. Both default to the current month",
                 "tags": [
-                    "logistic"
+                    "maintenance"
                 ],
-                "summary": "Get a logistic record by ID",
+                "summary": "Get the downtime report",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Logistic ID",
-                        "name": "logistic_id",
-                        "in": "path",
-                        "required": true
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Logistic"
+                            "$ref": "#/definitions/models.DowntimeReport"
                         }
                     },
                     "400": {
@@ -1193,47 +1228,75 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/drivers": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for updating a logistic record",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "API for retrieving all drivers with pagination and search",
                 "tags": [
-                    "logistic"
+                    "driver"
                 ],
-                "summary": "Update a logistic record",
+                "summary": "Get all drivers",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of drivers per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Logistic ID",
-                        "name": "logistic_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Type of driver",
+                        "name": "type",
+                        "in": "query"
                     },
                     {
-                        "description": "Logistic data",
-                        "name": "logistic",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateLogistic"
-                        }
+                        "type": "string",
+                        "description": "Position of driver",
+                        "name": "position",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Drivers Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Truck Number",
+                        "name": "truck_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSuccess"
+                            "$ref": "#/definitions/models.GetAllDriversResp"
                         }
                     },
                     "400": {
@@ -1250,31 +1313,39 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for deleting a logistic record",
+                "description": "API for creating a new driver",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "logistic"
+                    "driver"
                 ],
-                "summary": "Delete a logistic record",
+                "summary": "Create a driver",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Logistic ID",
-                        "name": "logistic_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Driver data",
+                        "name": "driver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateDriver"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSuccess"
+                            "$ref": "#/definitions/models.ResponseId"
                         }
                     },
                     "400": {
@@ -1283,6 +1354,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1292,14 +1369,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/logistics_with_cargo/{logistic_id}": {
+        "/v1/drivers/{driver_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a driver by ID",
+                "tags": [
+                    "driver"
+                ],
+                "summary": "Get a driver by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Driver"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for updating a logistic record with a cargo",
+                "description": "API for updating a driver",
                 "consumes": [
                     "application/json"
                 ],
@@ -1307,24 +1431,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "logistic"
+                    "driver"
                 ],
-                "summary": "Update a logistic record with a cargo",
+                "summary": "Update a driver",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Logistic ID",
-                        "name": "logistic_id",
+                        "description": "Driver ID",
+                        "name": "driver_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Logistic data",
-                        "name": "logistic",
+                        "description": "Driver data",
+                        "name": "driver",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.UpdateLogisticWithCargo"
+                            "$ref": "#/definitions/swag.CreateUpdateDriver"
                         }
                     }
                 ],
@@ -1341,6 +1465,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1348,69 +1478,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/performances": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving all performances with pagination and search",
+                "description": "API for deleting a driver",
                 "tags": [
-                    "performance"
+                    "driver"
                 ],
-                "summary": "Get all performances",
+                "summary": "Delete a driver",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of performances per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Company",
-                        "name": "company",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Whose fault",
-                        "name": "whose_fault",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Section",
-                        "name": "section",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Disputed by",
-                        "name": "disputed_by",
-                        "in": "query"
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetAllPerformancesResp"
+                            "$ref": "#/definitions/models.ResponseSuccess"
                         }
                     },
                     "400": {
@@ -1426,40 +1519,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/drivers/{driver_id}/compliance": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for creating a new performance",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "API for retrieving the compliance documents and status of a driver",
                 "tags": [
-                    "performance"
+                    "compliance"
                 ],
-                "summary": "Create a performance",
+                "summary": "Get a driver's compliance documents",
                 "parameters": [
                     {
-                        "description": "Performance data",
-                        "name": "performance",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdatePerformance"
-                        }
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseId"
+                            "$ref": "#/definitions/models.DriverCompliance"
                         }
                     },
                     "400": {
@@ -1475,34 +1562,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/performances/{performance_id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving a performance by ID",
+                "description": "API for saving CDL, medical card, drug test and MVR data of a driver. Dates use the 2006-01-02 format",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "performance"
+                    "compliance"
                 ],
-                "summary": "Get a performance by ID",
+                "summary": "Save a driver's compliance documents",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Performance ID",
-                        "name": "performance_id",
+                        "description": "Driver ID",
+                        "name": "driver_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Compliance data",
+                        "name": "compliance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.SaveDriverCompliance"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Performance"
+                            "$ref": "#/definitions/models.ResponseSuccess"
                         }
                     },
                     "400": {
@@ -1511,6 +1611,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1518,14 +1624,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/drivers/{driver_id}/equipment": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for updating a performance",
+                "description": "API for putting a driver on a truck and trailer. Leave an ID empty to drop that equipment. Equipment taken from another driver is removed from them, and every change is kept in the assignment history",
                 "consumes": [
                     "application/json"
                 ],
@@ -1533,24 +1641,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "performance"
+                    "equipment"
                 ],
-                "summary": "Update a performance",
+                "summary": "Assign equipment to a driver",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Performance ID",
-                        "name": "performance_id",
+                        "description": "Driver ID",
+                        "name": "driver_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Performance data",
-                        "name": "performance",
+                        "description": "Equipment",
+                        "name": "assignment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdatePerformance"
+                            "$ref": "#/definitions/swag.AssignEquipment"
                         }
                     }
                 ],
@@ -1567,6 +1675,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1574,23 +1688,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/drivers/{driver_id}/hos": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for deleting a performance",
+                "description": "API for retrieving the remaining hours of a driver and where they came from",
                 "tags": [
-                    "performance"
+                    "hos"
                 ],
-                "summary": "Delete a performance",
+                "summary": "Get a driver's hours of service",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Performance ID",
-                        "name": "performance_id",
+                        "description": "Driver ID",
+                        "name": "driver_id",
                         "in": "path",
                         "required": true
                     }
@@ -1599,7 +1715,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSuccess"
+                            "$ref": "#/definitions/models.DriverHOS"
                         }
                     },
                     "400": {
@@ -1615,16 +1731,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/terminate_logistics": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for terminating logistic record",
+                "description": "API for entering the remaining drive, on-duty and cycle hours of a driver by hand and linking the driver to the ELD. reported_at uses the 2006-01-02T15:04:05 format and defaults to now",
                 "consumes": [
                     "application/json"
                 ],
@@ -1632,17 +1746,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "logistic"
+                    "hos"
                 ],
-                "summary": "Terminate logistics",
+                "summary": "Save a driver's hours of service",
                 "parameters": [
                     {
-                        "description": "Logistic data",
-                        "name": "logistic",
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hours of service",
+                        "name": "hos",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.TerminateLogistic"
+                            "$ref": "#/definitions/swag.SaveDriverHOS"
                         }
                     }
                 ],
@@ -1659,6 +1780,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1668,59 +1795,146 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transactions": {
+        "/v1/drivers/{driver_id}/pay_profile": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving all transactions with pagination and search",
+                "description": "API for retrieving how a driver is paid",
                 "tags": [
-                    "transaction"
+                    "settlement"
                 ],
-                "summary": "Get all transactions",
+                "summary": "Get a driver's pay profile",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of transactions per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Service Provider",
-                        "name": "provider",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Success",
-                        "name": "success",
-                        "in": "query"
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PayProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for setting how a driver is paid: CPM (loaded_rate and empty_rate per mile) or PERCENT (percent of the load gross)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Save a driver's pay profile",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Cargo Id",
-                        "name": "cargo_id",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pay profile",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.SavePayProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/employees": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all employees with pagination and search",
+                "tags": [
+                    "employee"
+                ],
+                "summary": "Get all employees",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of employees per page",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Driver Name",
-                        "name": "driver_name",
+                        "description": "Employee name",
+                        "name": "name",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Dispatcher Name",
-                        "name": "dispatcher_name",
+                        "description": "Employee position",
+                        "name": "position",
                         "in": "query"
                     }
                 ],
@@ -1728,7 +1942,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetAllTransResp"
+                            "$ref": "#/definitions/models.GetAllEmployeesResp"
                         }
                     },
                     "400": {
@@ -1751,7 +1965,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for creating a new transaction",
+                "description": "API for creating a new employee",
                 "consumes": [
                     "application/json"
                 ],
@@ -1759,17 +1973,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "employee"
                 ],
-                "summary": "Create a transaction",
+                "summary": "Create an employee",
                 "parameters": [
                     {
-                        "description": "Transaction data",
-                        "name": "transaction",
+                        "description": "Employee data",
+                        "name": "employee",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateTransaction"
+                            "$ref": "#/definitions/swag.CreateUpdateEmployee"
                         }
                     }
                 ],
@@ -1786,6 +2000,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1795,23 +2015,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transactions/{transaction_id}": {
+        "/v1/employees/{employee_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for retrieving a transaction by ID",
+                "description": "API for retrieving an employee by ID",
                 "tags": [
-                    "transaction"
+                    "employee"
                 ],
-                "summary": "Get a transaction by ID",
+                "summary": "Get an employee by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transaction ID",
-                        "name": "transaction_id",
+                        "description": "Employee ID",
+                        "name": "employee_id",
                         "in": "path",
                         "required": true
                     }
@@ -1820,7 +2040,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Transaction"
+                            "$ref": "#/definitions/models.Employee"
                         }
                     },
                     "400": {
@@ -1829,6 +2049,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1843,7 +2069,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for updating a transaction",
+                "description": "API for updating an employee",
                 "consumes": [
                     "application/json"
                 ],
@@ -1851,24 +2077,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "employee"
                 ],
-                "summary": "Update a transaction",
+                "summary": "Update an employee",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transaction ID",
-                        "name": "transaction_id",
+                        "description": "Employee ID",
+                        "name": "employee_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Transaction data",
-                        "name": "transaction",
+                        "description": "Employee data",
+                        "name": "employee",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swag.CreateUpdateTransaction"
+                            "$ref": "#/definitions/swag.CreateUpdateEmployee"
                         }
                     }
                 ],
@@ -1885,6 +2111,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ResponseError"
                         }
                     },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1899,16 +2131,16 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "API for deleting a transaction",
+                "description": "API for deleting an employee",
                 "tags": [
-                    "transaction"
+                    "employee"
                 ],
-                "summary": "Delete a transaction",
+                "summary": "Delete an employee",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transaction ID",
-                        "name": "transaction_id",
+                        "description": "Employee ID",
+                        "name": "employee_id",
                         "in": "path",
                         "required": true
                     }
@@ -1934,496 +2166,7393 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AuthReq": {
-            "type": "object",
-            "properties": {
-                "password": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
         },
-        "models.AuthResp": {
-            "type": "object",
-            "properties": {
-                "employee": {
-                    "$ref": "#/definitions/models.Employee"
-                },
-                "token": {
-                    "type": "string"
+        "/v1/equipment_assignments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving which driver ran which truck and trailer and when",
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Get equipment assignment history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of assignments per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Truck ID",
+                        "name": "truck_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trailer ID",
+                        "name": "trailer_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllAssignmentsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
                 }
             }
         },
-        "models.ByCompany": {
+        "/v1/flag_rules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all board flag rules in the order their flags are shown",
+                "tags": [
+                    "flags"
+                ],
+                "summary": "Get all flag rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FlagRule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for creating a board flag rule. Conditions are AFTER_ST_TIME (st_time passed more than threshold minutes ago) and BEFORE_ST_TIME (st_time is less than threshold minutes ahead). Empty statuses match every status, an empty company every company. Flags are shown by ascending priority",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "flags"
+                ],
+                "summary": "Create a flag rule",
+                "parameters": [
+                    {
+                        "description": "Flag rule data",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateFlagRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flag_rules/{rule_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a board flag rule by ID",
+                "tags": [
+                    "flags"
+                ],
+                "summary": "Get a flag rule by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Flag rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FlagRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a board flag rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "flags"
+                ],
+                "summary": "Update a flag rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Flag rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Flag rule data",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateFlagRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a board flag rule",
+                "tags": [
+                    "flags"
+                ],
+                "summary": "Delete a flag rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Flag rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/histories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all history records with pagination and filters",
+                "tags": [
+                    "history"
+                ],
+                "summary": "Get all history records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllHistoryResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/histories/{history_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a single history record by ID",
+                "tags": [
+                    "history"
+                ],
+                "summary": "Get a history record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "History ID",
+                        "name": "history_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.History"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving invoices with pagination and filters",
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Get all invoices",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of invoices per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DRAFT, SENT, PAID or VOID",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invoice number",
+                        "name": "number",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllInvoicesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for billing a provider for delivered transactions. Every transaction becomes a linehaul line, accessorials (DETENTION, LAYOVER, LUMPER, TONU, OTHER) are added as extra lines",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Generate an invoice",
+                "parameters": [
+                    {
+                        "description": "Invoice data",
+                        "name": "invoice",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.GenerateInvoice"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices/{invoice_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving an invoice with its lines and payments",
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Get an invoice by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a draft invoice and releasing its transactions",
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Delete a draft invoice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices/{invoice_id}/factoring": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for recording that an invoice was factored, with the advance received, the reserve held back and the fee",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Update invoice factoring",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Factoring data",
+                        "name": "factoring",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.UpdateInvoiceFactoring"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices/{invoice_id}/payments": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for recording a (partial) payment received for a sent invoice",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Record an invoice payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment data",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateInvoicePayment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices/{invoice_id}/pdf": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for rendering an invoice as a PDF document",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Download an invoice as PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices/{invoice_id}/short_pay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for closing a sent invoice whose provider will not pay the rest, writing the open balance off as short paid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Close an invoice as short paid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Short pay reason",
+                        "name": "short_pay",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.ShortPayInvoice"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices/{invoice_id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for sending (SENT) or voiding (VOID) an invoice. Invoices become PAID once their payments cover the total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Update invoice status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "invoice_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.UpdateInvoiceStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/job_runs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving the runs of background jobs, latest first",
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get the runs of background jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of runs per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUNNING, SUCCEEDED or FAILED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllJobRunsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for listing the background jobs with their schedule and latest run",
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get all background jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.JobInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/{name}/run": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for starting a background job right away. The run is returned as soon as it starts, follow it in the job runs",
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Run a background job now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobRun"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown job",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Job is already running",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/login": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Employee data",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/logistics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all logistic records with pagination and search",
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Get all logistic records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of logistics per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Post",
+                        "name": "post",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver Type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver Position",
+                        "name": "position",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trailer type (DRY_VAN, REEFER, FLATBED, STEP_DECK, TANKER, OTHER)",
+                        "name": "trailer_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "description": "Company IDs",
+                        "name": "company_ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllLogisticsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for creating a new logistic record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Create a logistic record",
+                "parameters": [
+                    {
+                        "description": "Logistic data",
+                        "name": "logistic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateLogistic"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/logistics/overview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API to get an overview of driver statuses by company",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Get driver overview",
+                "responses": {
+                    "200": {
+                        "description": "Overview data",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetOverview"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/logistics/{logistic_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a logistic record by ID",
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Get a logistic record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Logistic ID",
+                        "name": "logistic_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Logistic"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the logistic record, for If-Match on updates"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a logistic record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Update a logistic record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Logistic ID",
+                        "name": "logistic_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Logistic data",
+                        "name": "logistic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateLogistic"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the update is based on, weak tags are refused. Without it the update overwrites the current version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated logistic record"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Logistic record changed since the If-Match version (VERSION_CONFLICT)",
+                        "schema": {
+                            "$ref": "#/definitions/models.LogisticConflictResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a logistic record",
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Delete a logistic record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Logistic ID",
+                        "name": "logistic_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/logistics_with_cargo/{logistic_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a logistic record with a cargo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Update a logistic record with a cargo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Logistic ID",
+                        "name": "logistic_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Logistic data",
+                        "name": "logistic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.UpdateLogisticWithCargo"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the update is based on, weak tags are refused. Without it the update overwrites the current version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateWithCargoResp"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated logistic record"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Load already booked (DUPLICATE_LOAD), driver has expired documents (DRIVER_NOT_COMPLIANT), not enough hours of service (HOS_NOT_FEASIBLE) or logistic changed since the If-Match version or cargo since cargo_version (VERSION_CONFLICT, body is a models.LogisticConflictResp)",
+                        "schema": {
+                            "$ref": "#/definitions/models.DuplicateLoadResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/maintenance/due": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving the scheduled services of active trucks that are overdue or due within the given days or miles",
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Get due maintenance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days ahead, 14 by default",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Miles ahead, 1000 by default",
+                        "name": "miles",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MaintenanceDue"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/maintenance_records": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving the maintenance history of trucks",
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Get all maintenance records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Truck ID",
+                        "name": "truck_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service type",
+                        "name": "service_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllMaintenanceRecordsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for recording a service done on a truck. A completed service restarts the matching schedules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Create a maintenance record",
+                "parameters": [
+                    {
+                        "description": "Maintenance record data",
+                        "name": "record",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateMaintenanceRecord"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/maintenance_records/{record_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a maintenance record by ID",
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Get a maintenance record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Maintenance record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MaintenanceRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a maintenance record. The truck of the record can't be changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Update a maintenance record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Maintenance record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Maintenance record data",
+                        "name": "record",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateMaintenanceRecord"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a maintenance record",
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Delete a maintenance record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Maintenance record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/maintenance_schedules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving maintenance schedules of trucks",
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Get all maintenance schedules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of schedules per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Truck ID",
+                        "name": "truck_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service type",
+                        "name": "service_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllMaintenanceSchedulesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for scheduling a service of a truck every given number of miles and/or days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Create a maintenance schedule",
+                "parameters": [
+                    {
+                        "description": "Maintenance schedule data",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateMaintenanceSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/maintenance_schedules/{schedule_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating the intervals and the last service of a maintenance schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Update a maintenance schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Maintenance schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Maintenance schedule data",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateMaintenanceSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a maintenance schedule",
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Delete a maintenance schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Maintenance schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/performances": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all performances with pagination and search",
+                "tags": [
+                    "performance"
+                ],
+                "summary": "Get all performances",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of performances per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Whose fault",
+                        "name": "whose_fault",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Section",
+                        "name": "section",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disputed by",
+                        "name": "disputed_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only drafts opened by the late watcher, or only completed ones",
+                        "name": "draft",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllPerformancesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for creating a new performance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "performance"
+                ],
+                "summary": "Create a performance",
+                "parameters": [
+                    {
+                        "description": "Performance data",
+                        "name": "performance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdatePerformance"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/performances/{performance_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a performance by ID",
+                "tags": [
+                    "performance"
+                ],
+                "summary": "Get a performance by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Performance ID",
+                        "name": "performance_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Performance"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a performance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "performance"
+                ],
+                "summary": "Update a performance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Performance ID",
+                        "name": "performance_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Performance data",
+                        "name": "performance",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdatePerformance"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a performance",
+                "tags": [
+                    "performance"
+                ],
+                "summary": "Delete a performance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Performance ID",
+                        "name": "performance_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/providers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all brokers/providers with pagination and search",
+                "tags": [
+                    "provider"
+                ],
+                "summary": "Get all providers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of providers per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name, MC or DOT",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Blacklisted",
+                        "name": "blacklisted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllProvidersResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for creating a new broker/provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "provider"
+                ],
+                "summary": "Create a provider",
+                "parameters": [
+                    {
+                        "description": "Provider data",
+                        "name": "provider",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateProvider"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/providers/{provider_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a broker/provider by ID together with its receivables aging",
+                "tags": [
+                    "provider"
+                ],
+                "summary": "Get a provider by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Provider"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a broker/provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "provider"
+                ],
+                "summary": "Update a provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Provider data",
+                        "name": "provider",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateProvider"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a broker/provider",
+                "tags": [
+                    "provider"
+                ],
+                "summary": "Delete a provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/providers/{provider_id}/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving the invoices, payments and short pays of a provider with the running balance owed",
+                "tags": [
+                    "provider"
+                ],
+                "summary": "Get a provider's AR ledger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProviderLedger"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/providers/{provider_id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving loads, gross, average RPM and cancellation rate of a provider",
+                "tags": [
+                    "provider"
+                ],
+                "summary": "Get provider statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProviderStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlement_adjustments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving deductions, advances and reimbursements",
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Get all settlement adjustments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of adjustments per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only adjustments not yet settled",
+                        "name": "pending",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllAdjustmentsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for adding a deduction, advance or reimbursement to a driver's settlements. Recurring adjustments apply every week from date until end_date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Create a settlement adjustment",
+                "parameters": [
+                    {
+                        "description": "Adjustment data",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateSettlementAdjustment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlement_adjustments/{adjustment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting an adjustment that is not on a locked settlement",
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Delete a settlement adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Adjustment ID",
+                        "name": "adjustment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving settlements with pagination and filters",
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Get all settlements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of settlements per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Week start (2006-01-02)",
+                        "name": "week_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DRAFT or LOCKED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllSettlementsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements/run": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for computing the settlements of all drivers with a pay profile for a week (Monday to Sunday, by delivery time). Earlier loads no settlement has paid yet, like ones entered after their week was locked, are added to the week. Draft settlements are recomputed, locked ones are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Run weekly settlements",
+                "parameters": [
+                    {
+                        "description": "Week start (Monday, 2006-01-02)",
+                        "name": "run",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.RunSettlements"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SettlementRunResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements/{settlement_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a settlement with its lines",
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Get a settlement by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Settlement ID",
+                        "name": "settlement_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Settlement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements/{settlement_id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for downloading a locked settlement as a CSV pay statement",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Export a settlement statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Settlement ID",
+                        "name": "settlement_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements/{settlement_id}/lock": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for locking a settlement once it is paid. Locked settlements are never recomputed",
+                "tags": [
+                    "settlement"
+                ],
+                "summary": "Lock a settlement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Settlement ID",
+                        "name": "settlement_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stops/{stop_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for marking a stop as pending, arrived or completed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Update the status of a cargo stop",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stop ID",
+                        "name": "stop_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stop status",
+                        "name": "stop",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.UpdateStopStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Stop"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/terminate_logistics": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for terminating logistic record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logistic"
+                ],
+                "summary": "Terminate logistics",
+                "parameters": [
+                    {
+                        "description": "Logistic data",
+                        "name": "logistic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.TerminateLogistic"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trailers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving trailers with pagination and filters",
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Get all trailers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of trailers per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number, VIN or plate",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ACTIVE or INACTIVE",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trailer type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllTrailersResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for registering a new trailer of a company",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Create a trailer",
+                "parameters": [
+                    {
+                        "description": "Trailer data",
+                        "name": "trailer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateTrailer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trailers/{trailer_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a trailer by ID",
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Get a trailer by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trailer ID",
+                        "name": "trailer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Trailer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a trailer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Update a trailer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trailer ID",
+                        "name": "trailer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trailer data",
+                        "name": "trailer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateTrailer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a trailer that is not assigned to a driver",
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Delete a trailer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trailer ID",
+                        "name": "trailer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all transactions with pagination and search",
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Get all transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of transactions per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service Provider",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Success",
+                        "name": "success",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cargo Id",
+                        "name": "cargo_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver Name",
+                        "name": "driver_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dispatcher Name",
+                        "name": "dispatcher_name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllTransResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for creating a new transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Create a transaction",
+                "parameters": [
+                    {
+                        "description": "Transaction data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{transaction_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a transaction by ID",
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Get a transaction by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Update a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction data, with the times in RFC 3339",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.UpdateTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a transaction",
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Delete a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trucks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving trucks with pagination and filters",
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Get all trucks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of trucks per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company ID",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number, VIN or plate",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ACTIVE or INACTIVE",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllTrucksResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for registering a new truck of a company",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Create a truck",
+                "parameters": [
+                    {
+                        "description": "Truck data",
+                        "name": "truck",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateTruck"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trucks/{truck_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a truck by ID",
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Get a truck by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Truck ID",
+                        "name": "truck_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Truck"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a truck",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Update a truck",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Truck ID",
+                        "name": "truck_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Truck data",
+                        "name": "truck",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateTruck"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a truck that is not assigned to a driver",
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Delete a truck",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Truck ID",
+                        "name": "truck_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhook_deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving webhook deliveries with their attempts and last outcome",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get the webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, SUCCEEDED or FAILED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetAllDeliveriesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhook_deliveries/{delivery_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a webhook delivery with its event",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook delivery by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhook_deliveries/{delivery_id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for sending the event of a delivery to its subscription again as a new delivery",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Replay a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseId"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhook_subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving all webhook subscriptions",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get all webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for subscribing a URL to load events (load.covered, load.picked_up, load.delivered, load.cancelled), comma separated or empty for all. Requests are signed with HMAC-SHA256 of \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\" in X-Webhook-Signature. The secret is generated unless given and only returned here",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription data",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateWebhookSubscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSubscriptionResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhook_subscriptions/{subscription_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for retrieving a webhook subscription by ID",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook subscription by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscription_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for updating a webhook subscription. The secret is kept when left empty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscription_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription data",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swag.CreateUpdateWebhookSubscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "API for deleting a webhook subscription. Its pending deliveries fail",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscription_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperr.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ARAging": {
+            "type": "object",
+            "properties": {
+                "days_0_30": {
+                    "type": "number"
+                },
+                "days_31_60": {
+                    "type": "number"
+                },
+                "days_61_90": {
+                    "type": "number"
+                },
+                "days_90_plus": {
+                    "type": "number"
+                },
+                "invoices": {
+                    "type": "integer"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "provider_name": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Alert": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
+                    "type": "string"
+                },
+                "acknowledged_by": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dedup_key": {
+                    "type": "string"
+                },
+                "delivery_error": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inbox": {
+                    "type": "boolean"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "logistic_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "notified_at": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "snoozed_until": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AlertRule": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "string"
+                },
+                "cooldown": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "statuses": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuthReq": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuthResp": {
+            "type": "object",
+            "properties": {
+                "employee": {
+                    "$ref": "#/definitions/models.Employee"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ByCompany": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "company_name": {
+                    "type": "string"
+                },
+                "logistics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LogisticResponse"
+                    }
+                }
+            }
+        },
+        "models.CargoResponse": {
+            "type": "object",
+            "properties": {
+                "cargo_id": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_time": {
+                    "type": "string"
+                },
+                "dispatcher_name": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "driver_name": {
+                    "type": "string"
+                },
+                "driver_surname": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "free_miles": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "loaded_miles": {
+                    "type": "integer"
+                },
+                "logistic_id": {
+                    "type": "string"
+                },
+                "pick_up_time": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Stop"
+                    }
+                },
+                "terminated_at": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Company": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dot": {
+                    "type": "integer"
+                },
+                "drivers_number": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mc": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "scac": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompanyDowntime": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "company_name": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "integer"
+                },
+                "hours": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateSubscriptionResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Downtime": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "logistic_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "truck_id": {
+                    "type": "string"
+                },
+                "truck_number": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DowntimeReport": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CompanyDowntime"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "trucks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TruckDowntime"
+                    }
+                }
+            }
+        },
+        "models.Driver": {
+            "type": "object",
+            "properties": {
+                "birthday": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "trailer_id": {
+                    "type": "string"
+                },
+                "truck_id": {
+                    "type": "string"
+                },
+                "truck_number": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DriverCompliance": {
+            "type": "object",
+            "properties": {
+                "cdl_class": {
+                    "type": "string"
+                },
+                "cdl_expiry": {
+                    "type": "string"
+                },
+                "cdl_number": {
+                    "type": "string"
+                },
+                "cdl_state": {
+                    "type": "string"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "endorsements": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issues": {
+                    "type": "string"
+                },
+                "last_drug_test": {
+                    "type": "string"
+                },
+                "medical_card_expiry": {
+                    "type": "string"
+                },
+                "mvr_next_review": {
+                    "type": "string"
+                },
+                "mvr_reviewed_at": {
+                    "type": "string"
+                },
+                "next_drug_test": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DriverHOS": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "cycle_left": {
+                    "type": "number"
+                },
+                "drive_left": {
+                    "type": "number"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "eld_driver_id": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "on_duty_left": {
+                    "type": "number"
+                },
+                "reported_at": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DuplicateLoad": {
+            "type": "object",
+            "properties": {
+                "cargo_id": {
+                    "type": "string"
+                },
+                "cargo_link": {
+                    "type": "string"
+                },
+                "exact": {
+                    "type": "boolean"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "load_id": {
+                    "type": "string"
+                },
+                "logistic_id": {
+                    "type": "string"
+                },
+                "logistic_link": {
+                    "type": "string"
+                },
+                "pick_up_time": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "transaction_link": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DuplicateLoadResp": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateLoad"
+                    }
+                },
+                "error_code": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Employee": {
+            "type": "object",
+            "properties": {
+                "access_level": {
+                    "type": "integer"
+                },
+                "birthday": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "logo_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EquipmentAssignment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "trailer": {
+                    "$ref": "#/definitions/models.Trailer"
+                },
+                "trailer_id": {
+                    "type": "string"
+                },
+                "truck": {
+                    "$ref": "#/definitions/models.Truck"
+                },
+                "truck_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExpiringCompliance": {
+            "type": "object",
+            "properties": {
+                "compliance": {
+                    "$ref": "#/definitions/models.DriverCompliance"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExpiringDocument"
+                    }
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "driver_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExpiringDocument": {
+            "type": "object",
+            "properties": {
+                "days_left": {
+                    "type": "integer"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Flag": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FlagRule": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GetARAgingResp": {
+            "type": "object",
+            "properties": {
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ARAging"
+                    }
+                },
+                "totals": {
+                    "$ref": "#/definitions/models.ARAging"
+                }
+            }
+        },
+        "models.GetAllAdjustmentsResp": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SettlementAdjustment"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GetAllAlertsResp": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Alert"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GetAllAssignmentsResp": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EquipmentAssignment"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GetAllCargosResp": {
+            "type": "object",
+            "properties": {
+                "cargos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CargoResponse"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GetAllCompaniesResp": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Company"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GetAllDeliveriesResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookDelivery"
+                    }
+                }
+            }
+        },
+        "models.GetAllDowntimesResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "downtimes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Downtime"
+                    }
+                }
+            }
+        },
+        "models.GetAllDriversResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Driver"
+                    }
+                }
+            }
+        },
+        "models.GetAllEmployeesResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "employees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Employee"
+                    }
+                }
+            }
+        },
+        "models.GetAllHistoryResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "histories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.History"
+                    }
+                }
+            }
+        },
+        "models.GetAllInvoicesResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "invoices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Invoice"
+                    }
+                }
+            }
+        },
+        "models.GetAllJobRunsResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JobRun"
+                    }
+                }
+            }
+        },
+        "models.GetAllLogisticsResp": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ByCompany"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GetAllMaintenanceRecordsResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MaintenanceRecord"
+                    }
+                }
+            }
+        },
+        "models.GetAllMaintenanceSchedulesResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MaintenanceSchedule"
+                    }
+                }
+            }
+        },
+        "models.GetAllPerformancesResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "performances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Performance"
+                    }
+                }
+            }
+        },
+        "models.GetAllProvidersResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Provider"
+                    }
+                }
+            }
+        },
+        "models.GetAllSettlementsResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "settlements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Settlement"
+                    }
+                }
+            }
+        },
+        "models.GetAllTrailersResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "trailers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Trailer"
+                    }
+                }
+            }
+        },
+        "models.GetAllTransResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Transaction"
+                    }
+                }
+            }
+        },
+        "models.GetAllTrucksResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "trucks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Truck"
+                    }
+                }
+            }
+        },
+        "models.GetExpiringComplianceResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExpiringCompliance"
+                    }
+                }
+            }
+        },
+        "models.GetOverview": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "freeDrivers": {
+                                "type": "integer"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            },
+                            "notWorking": {
+                                "type": "integer"
+                            },
+                            "occupiedDrivers": {
+                                "type": "integer"
+                            },
+                            "overdue": {
+                                "type": "integer"
+                            },
+                            "willBeSoonDrivers": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "models.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.History": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "driver_name": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "from_cargo": {
+                    "$ref": "#/definitions/models.JSONBCargo"
+                },
+                "from_logistics": {
+                    "$ref": "#/definitions/models.JSONBLogistic"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "logistic_id": {
+                    "type": "string"
+                },
+                "to_cargo": {
+                    "$ref": "#/definitions/models.JSONBCargo"
+                },
+                "to_logistics": {
+                    "$ref": "#/definitions/models.JSONBLogistic"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Invoice": {
+            "type": "object",
+            "properties": {
+                "advance_amount": {
+                    "type": "number"
+                },
+                "amount_paid": {
+                    "type": "number"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "factored": {
+                    "type": "boolean"
+                },
+                "factored_at": {
+                    "type": "string"
+                },
+                "factoring_company": {
+                    "type": "string"
+                },
+                "factoring_fee": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issue_date": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoiceLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoicePayment"
+                    }
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "reserve_amount": {
+                    "type": "number"
+                },
+                "reserve_released_at": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "short_paid": {
+                    "type": "number"
+                },
+                "short_paid_at": {
+                    "type": "string"
+                },
+                "short_pay_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvoiceLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invoice_id": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvoicePayment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invoice_id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JSONBCargo": {
+            "type": "object",
+            "properties": {
+                "cargo_id": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "delivery_time": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "free_miles": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "loaded_miles": {
+                    "type": "integer"
+                },
+                "pick_up_time": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JSONBStop"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JSONBLogistic": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "notion": {
+                    "type": "string"
+                },
+                "post": {
+                    "type": "boolean"
+                },
+                "st_time": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "update_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JSONBStop": {
+            "type": "object",
+            "properties": {
+                "appointment_time": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JobInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "every": {
+                    "type": "string"
+                },
+                "last_run": {
+                    "$ref": "#/definitions/models.JobRun"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "running": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.JobRun": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "job": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "trigger": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "credit": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "debit": {
+                    "type": "number"
+                },
+                "invoice_id": {
+                    "type": "string"
+                },
+                "invoice_number": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Logistic": {
+            "type": "object",
+            "properties": {
+                "cargo_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "emoji": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Flag"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "notion": {
+                    "type": "string"
+                },
+                "post": {
+                    "type": "boolean"
+                },
+                "st_time": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "update_time": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LogisticConflictResp": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/models.Logistic"
+                },
+                "error_code": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LogisticResponse": {
+            "type": "object",
+            "properties": {
+                "cargo_id": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "company_name": {
+                    "type": "string"
+                },
+                "countdown": {
+                    "type": "string"
+                },
+                "current_stop": {
+                    "$ref": "#/definitions/models.Stop"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "driver_name": {
+                    "type": "string"
+                },
+                "driver_position": {
+                    "type": "string"
+                },
+                "driver_surname": {
+                    "type": "string"
+                },
+                "driver_type": {
+                    "type": "string"
+                },
+                "emoji": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Flag"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "notion": {
+                    "type": "string"
+                },
+                "post": {
+                    "type": "boolean"
+                },
+                "st_time": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "trailer_number": {
+                    "type": "string"
+                },
+                "trailer_type": {
+                    "type": "string"
+                },
+                "truck_number": {
+                    "type": "string"
+                },
+                "update_time": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MaintenanceDue": {
+            "type": "object",
+            "properties": {
+                "days_left": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "due_odometer": {
+                    "type": "integer"
+                },
+                "miles_left": {
+                    "type": "integer"
+                },
+                "odometer": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/models.MaintenanceSchedule"
+                },
+                "truck_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MaintenanceRecord": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "odometer": {
+                    "type": "integer"
+                },
+                "service_type": {
+                    "type": "string"
+                },
+                "shop": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "truck_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MaintenanceSchedule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interval_days": {
+                    "type": "integer"
+                },
+                "interval_miles": {
+                    "type": "integer"
+                },
+                "last_date": {
+                    "type": "string"
+                },
+                "last_odometer": {
+                    "type": "integer"
+                },
+                "service_type": {
+                    "type": "string"
+                },
+                "truck_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PayProfile": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "empty_rate": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "loaded_rate": {
+                    "type": "number"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Performance": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "draft": {
+                    "type": "boolean"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "load_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "whose_fault": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Provider": {
+            "type": "object",
+            "properties": {
+                "aging": {
+                    "$ref": "#/definitions/models.ARAging"
+                },
+                "blacklisted": {
+                    "type": "boolean"
+                },
+                "contact_email": {
+                    "type": "string"
+                },
+                "contact_name": {
+                    "type": "string"
+                },
+                "contact_phone": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_notes": {
+                    "type": "string"
+                },
+                "dot": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mc": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "normalized_name": {
+                    "type": "string"
+                },
+                "payment_terms": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProviderLedger": {
             "type": "object",
             "properties": {
-                "company_id": {
+                "balance": {
+                    "type": "number"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LedgerEntry"
+                    }
+                },
+                "factored": {
+                    "type": "number"
+                },
+                "invoiced": {
+                    "type": "number"
+                },
+                "paid": {
+                    "type": "number"
+                },
+                "provider_id": {
                     "type": "string"
                 },
-                "company_name": {
+                "reserve": {
+                    "type": "number"
+                },
+                "short_paid": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ProviderStats": {
+            "type": "object",
+            "properties": {
+                "average_rpm": {
+                    "type": "number"
+                },
+                "cancellation_rate": {
+                    "type": "number"
+                },
+                "cancelled_loads": {
+                    "type": "integer"
+                },
+                "gross": {
+                    "type": "integer"
+                },
+                "loads": {
+                    "type": "integer"
+                },
+                "provider_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Readiness": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HealthCheck"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResponseError": {
+            "type": "object",
+            "properties": {
+                "error_code": {
                     "type": "string"
                 },
-                "logistics": {
+                "error_message": {
+                    "type": "string"
+                },
+                "fields": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.LogisticResponse"
+                        "$ref": "#/definitions/apperr.FieldError"
+                    }
+                }
+            }
+        },
+        "models.ResponseId": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResponseSuccess": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Settlement": {
+            "type": "object",
+            "properties": {
+                "advances": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deductions": {
+                    "type": "number"
+                },
+                "driver": {
+                    "$ref": "#/definitions/models.Driver"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "empty_miles": {
+                    "type": "integer"
+                },
+                "gross": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SettlementLine"
                     }
+                },
+                "loaded_miles": {
+                    "type": "integer"
+                },
+                "loads": {
+                    "type": "integer"
+                },
+                "locked_at": {
+                    "type": "string"
+                },
+                "locked_by": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "net_pay": {
+                    "type": "number"
+                },
+                "pay": {
+                    "type": "number"
+                },
+                "reimbursements": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "week_end": {
+                    "type": "string"
+                },
+                "week_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SettlementAdjustment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "recurring": {
+                    "type": "boolean"
+                },
+                "settlement_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SettlementLine": {
+            "type": "object",
+            "properties": {
+                "adjustment_id": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "miles": {
+                    "type": "integer"
+                },
+                "settlement_id": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SettlementRunResp": {
+            "type": "object",
+            "properties": {
+                "settlements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Settlement"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "week_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Stop": {
+            "type": "object",
+            "properties": {
+                "appointment_time": {
+                    "type": "string"
+                },
+                "arrived_at": {
+                    "type": "string"
+                },
+                "cargo_id": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.Company": {
+        "models.Trailer": {
             "type": "object",
             "properties": {
-                "address": {
+                "company_id": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "dot": {
-                    "type": "integer"
-                },
-                "drivers_number": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "string"
                 },
-                "mc": {
+                "length": {
                     "type": "integer"
                 },
-                "name": {
+                "make": {
                     "type": "string"
                 },
                 "number": {
                     "type": "string"
                 },
-                "scac": {
+                "ownership": {
                     "type": "string"
                 },
-                "start_date": {
+                "plate": {
+                    "type": "string"
+                },
+                "plate_state": {
+                    "type": "string"
+                },
+                "registration_expiry": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "vin": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Driver": {
+        "models.Transaction": {
             "type": "object",
             "properties": {
-                "birthday": {
+                "cargo_id": {
                     "type": "string"
                 },
-                "company_id": {
-                    "type": "string"
+                "cost": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "delivery_time": {
                     "type": "string"
                 },
-                "mail": {
+                "driver": {
+                    "$ref": "#/definitions/models.Driver"
+                },
+                "driver_id": {
                     "type": "string"
                 },
-                "name": {
+                "employee_id": {
                     "type": "string"
                 },
-                "phone_number": {
+                "from": {
                     "type": "string"
                 },
-                "position": {
+                "id": {
                     "type": "string"
                 },
-                "start_date": {
+                "invoice_id": {
                     "type": "string"
                 },
-                "surname": {
+                "loaded_miles": {
+                    "type": "integer"
+                },
+                "provider": {
                     "type": "string"
                 },
-                "truck_number": {
+                "provider_id": {
                     "type": "string"
                 },
-                "type": {
+                "pu_time": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JSONBStop"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "to": {
                     "type": "string"
                 },
+                "total_miles": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.Employee": {
+        "models.Truck": {
             "type": "object",
             "properties": {
-                "access_level": {
-                    "type": "integer"
-                },
-                "birthday": {
-                    "type": "string"
-                },
-                "company": {
+                "company_id": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "make": {
                     "type": "string"
                 },
-                "logo_id": {
+                "model": {
                     "type": "string"
                 },
-                "name": {
+                "number": {
                     "type": "string"
                 },
-                "password": {
+                "odometer": {
+                    "type": "integer"
+                },
+                "ownership": {
                     "type": "string"
                 },
-                "phone_number": {
+                "plate": {
                     "type": "string"
                 },
-                "position": {
+                "plate_state": {
                     "type": "string"
                 },
-                "start_date": {
+                "registration_expiry": {
                     "type": "string"
                 },
-                "surname": {
+                "status": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "username": {
+                "vin": {
                     "type": "string"
-                }
-            }
-        },
-        "models.GetAllCompaniesResp": {
-            "type": "object",
-            "properties": {
-                "companies": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Company"
-                    }
                 },
-                "count": {
+                "year": {
                     "type": "integer"
                 }
             }
         },
-        "models.GetAllDriversResp": {
+        "models.TruckDowntime": {
             "type": "object",
             "properties": {
-                "count": {
+                "company_id": {
+                    "type": "string"
+                },
+                "events": {
                     "type": "integer"
                 },
-                "drivers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Driver"
-                    }
+                "hours": {
+                    "type": "number"
+                },
+                "truck_number": {
+                    "type": "string"
                 }
             }
         },
-        "models.GetAllEmployeesResp": {
+        "models.UpdateWithCargoResp": {
             "type": "object",
             "properties": {
-                "count": {
+                "cargo_version": {
                     "type": "integer"
                 },
-                "employees": {
+                "duplicates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Employee"
+                        "$ref": "#/definitions/models.DuplicateLoad"
                     }
-                }
-            }
-        },
-        "models.GetAllHistoryResp": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
                 },
-                "histories": {
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "warnings": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.History"
+                        "type": "string"
                     }
                 }
             }
         },
-        "models.GetAllLogisticsResp": {
+        "models.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "companies": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ByCompany"
-                    }
+                "attempts": {
+                    "type": "integer"
                 },
-                "count": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
                     "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.GetAllPerformancesResp": {
+        "models.WebhookSubscription": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "active": {
+                    "type": "boolean"
                 },
-                "performances": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Performance"
-                    }
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "models.GetAllTransResp": {
+        "swag.AssignEquipment": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "notes": {
+                    "type": "string"
                 },
-                "transactions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Transaction"
-                    }
+                "trailer_id": {
+                    "type": "string"
+                },
+                "truck_id": {
+                    "type": "string"
                 }
             }
         },
-        "models.GetOverview": {
+        "swag.CancelLogistic": {
             "type": "object",
+            "required": [
+                "company_id",
+                "logistic_id",
+                "reason",
+                "section",
+                "status",
+                "whose_fault"
+            ],
             "properties": {
-                "companies": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "freeDrivers": {
-                                "type": "integer"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            },
-                            "notWorking": {
-                                "type": "integer"
-                            },
-                            "occupiedDrivers": {
-                                "type": "integer"
-                            },
-                            "willBeSoonDrivers": {
-                                "type": "integer"
-                            }
-                        }
-                    }
+                "cancel": {
+                    "type": "boolean"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "logistic_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "success",
+                        "canceled"
+                    ]
+                },
+                "whose_fault": {
+                    "type": "string",
+                    "enum": [
+                        "Dispatcher",
+                        "Driver",
+                        "Company"
+                    ]
                 }
             }
         },
-        "models.History": {
+        "swag.CargoStop": {
             "type": "object",
+            "required": [
+                "appointment_time",
+                "location",
+                "type"
+            ],
             "properties": {
-                "created_at": {
+                "appointment_time": {
                     "type": "string"
                 },
-                "driver_name": {
+                "id": {
                     "type": "string"
                 },
-                "employee_id": {
+                "location": {
                     "type": "string"
                 },
-                "from_cargo": {
-                    "$ref": "#/definitions/models.JSONBCargo"
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "ARRIVED",
+                        "COMPLETED",
+                        "pending",
+                        "arrived",
+                        "completed"
+                    ]
                 },
-                "from_logistics": {
-                    "$ref": "#/definitions/models.JSONBLogistic"
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "swag.CreateInvoicePayment": {
+            "type": "object",
+            "required": [
+                "paid_at"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
                 },
-                "id": {
+                "method": {
                     "type": "string"
                 },
-                "logistic_id": {
+                "paid_at": {
                     "type": "string"
                 },
-                "to_cargo": {
-                    "$ref": "#/definitions/models.JSONBCargo"
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "swag.CreateSettlementAdjustment": {
+            "type": "object",
+            "required": [
+                "date",
+                "description",
+                "driver_id",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
                 },
-                "to_logistics": {
-                    "$ref": "#/definitions/models.JSONBLogistic"
+                "date": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "description": {
                     "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "recurring": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "DEDUCTION",
+                        "ADVANCE",
+                        "REIMBURSEMENT"
+                    ]
                 }
             }
         },
-        "models.JSONBCargo": {
+        "swag.CreateUpdateAlertRule": {
             "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
             "properties": {
-                "cargo_id": {
+                "channels": {
                     "type": "string"
                 },
-                "cost": {
-                    "type": "integer"
+                "cooldown": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "delivery_time": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "kind": {
                     "type": "string"
                 },
-                "employee_id": {
+                "name": {
                     "type": "string"
                 },
-                "free_miles": {
-                    "type": "integer"
+                "recipients": {
+                    "type": "string"
                 },
-                "from": {
+                "severity": {
                     "type": "string"
                 },
-                "id": {
+                "statuses": {
                     "type": "string"
                 },
-                "loaded_miles": {
+                "threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "webhook_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "swag.CreateUpdateCompany": {
+            "type": "object",
+            "required": [
+                "address",
+                "dot",
+                "mc",
+                "name",
+                "number",
+                "scac"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "dot": {
                     "type": "integer"
                 },
-                "pick_up_time": {
+                "mc": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "provider": {
+                "number": {
                     "type": "string"
                 },
-                "rate": {
-                    "type": "number"
+                "scac": {
+                    "type": "string"
                 },
-                "to": {
+                "start_date": {
                     "type": "string"
                 }
             }
         },
-        "models.JSONBLogistic": {
+        "swag.CreateUpdateDriver": {
             "type": "object",
+            "required": [
+                "birthday",
+                "company_id",
+                "mail",
+                "name",
+                "position",
+                "surname",
+                "truck_number",
+                "type"
+            ],
             "properties": {
-                "location": {
+                "birthday": {
                     "type": "string"
                 },
-                "notion": {
+                "company_id": {
                     "type": "string"
                 },
-                "post": {
-                    "type": "boolean"
+                "mail": {
+                    "type": "string"
                 },
-                "st_time": {
+                "name": {
                     "type": "string"
                 },
-                "state": {
+                "phone_number": {
                     "type": "string"
                 },
-                "status": {
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "CO",
+                        "OW"
+                    ]
+                },
+                "start_date": {
                     "type": "string"
                 },
-                "update_time": {
+                "surname": {
+                    "type": "string"
+                },
+                "truck_number": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "SOLO",
+                        "TEAM"
+                    ]
                 }
             }
         },
-        "models.Logistic": {
+        "swag.CreateUpdateEmployee": {
             "type": "object",
+            "required": [
+                "name",
+                "surname",
+                "username"
+            ],
             "properties": {
-                "cargo_id": {
-                    "type": "string"
+                "access_level": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "created_at": {
+                "birthday": {
                     "type": "string"
                 },
-                "driver_id": {
+                "company": {
                     "type": "string"
                 },
-                "emoji": {
+                "email": {
                     "type": "string"
                 },
-                "id": {
+                "logo_id": {
                     "type": "string"
                 },
-                "location": {
+                "name": {
                     "type": "string"
                 },
-                "notion": {
+                "password": {
                     "type": "string"
                 },
-                "post": {
-                    "type": "boolean"
-                },
-                "st_time": {
+                "phone_number": {
                     "type": "string"
                 },
-                "state": {
+                "position": {
                     "type": "string"
                 },
-                "status": {
+                "start_date": {
                     "type": "string"
                 },
-                "update_time": {
+                "surname": {
                     "type": "string"
                 },
-                "updated_at": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "models.LogisticResponse": {
+        "swag.CreateUpdateFlagRule": {
             "type": "object",
+            "required": [
+                "condition",
+                "icon",
+                "name"
+            ],
             "properties": {
-                "cargo_id": {
-                    "type": "string"
-                },
                 "company_id": {
                     "type": "string"
                 },
-                "company_name": {
-                    "type": "string"
-                },
-                "countdown": {
+                "condition": {
                     "type": "string"
                 },
-                "driver_id": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "driver_name": {
+                "icon": {
                     "type": "string"
                 },
-                "driver_position": {
+                "name": {
                     "type": "string"
                 },
-                "driver_surname": {
-                    "type": "string"
+                "priority": {
+                    "type": "integer"
                 },
-                "driver_type": {
-                    "type": "string"
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "emoji": {
+                "threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "swag.CreateUpdateLogistic": {
+            "type": "object",
+            "required": [
+                "cargo_id",
+                "location",
+                "st_time",
+                "status"
+            ],
+            "properties": {
+                "cargo_id": {
                     "type": "string"
                 },
-                "id": {
+                "driver_id": {
                     "type": "string"
                 },
                 "location": {
                     "type": "string"
                 },
                 "notion": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 },
                 "post": {
                     "type": "boolean"
@@ -2431,33 +9560,110 @@ const docTemplate = `{
                 "st_time": {
                     "type": "string"
                 },
-                "state": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "swag.CreateUpdateMaintenanceRecord": {
+            "type": "object",
+            "required": [
+                "service_type",
+                "started_at",
+                "truck_id"
+            ],
+            "properties": {
+                "completed_at": {
                     "type": "string"
                 },
-                "status": {
+                "cost": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "description": {
                     "type": "string"
                 },
-                "update_time": {
+                "odometer": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "service_type": {
+                    "type": "string",
+                    "enum": [
+                        "OIL_CHANGE",
+                        "PM",
+                        "TIRES",
+                        "BRAKES",
+                        "INSPECTION",
+                        "REPAIR",
+                        "OTHER"
+                    ]
+                },
+                "shop": {
                     "type": "string"
                 },
-                "updated_at": {
+                "started_at": {
+                    "type": "string"
+                },
+                "truck_id": {
                     "type": "string"
                 }
             }
         },
-        "models.Performance": {
+        "swag.CreateUpdateMaintenanceSchedule": {
             "type": "object",
+            "required": [
+                "service_type",
+                "truck_id"
+            ],
             "properties": {
-                "company_id": {
-                    "type": "string"
+                "interval_days": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "created_at": {
+                "interval_miles": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "last_date": {
                     "type": "string"
                 },
-                "employee_id": {
+                "last_odometer": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "service_type": {
+                    "type": "string",
+                    "enum": [
+                        "OIL_CHANGE",
+                        "PM",
+                        "TIRES",
+                        "BRAKES",
+                        "INSPECTION",
+                        "REPAIR",
+                        "OTHER"
+                    ]
+                },
+                "truck_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "swag.CreateUpdatePerformance": {
+            "type": "object",
+            "required": [
+                "company_id",
+                "employee_id",
+                "reason",
+                "section",
+                "status",
+                "whose_fault"
+            ],
+            "properties": {
+                "company_id": {
                     "type": "string"
                 },
-                "id": {
+                "employee_id": {
                     "type": "string"
                 },
                 "load_id": {
@@ -2470,61 +9676,148 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "status": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "success",
+                        "canceled"
+                    ]
                 },
                 "whose_fault": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "Dispatcher",
+                        "Driver",
+                        "Company"
+                    ]
                 }
             }
         },
-        "models.ResponseError": {
+        "swag.CreateUpdateProvider": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "error_code": {
+                "blacklisted": {
+                    "type": "boolean"
+                },
+                "contact_email": {
                     "type": "string"
                 },
-                "error_message": {
+                "contact_name": {
                     "type": "string"
-                }
-            }
-        },
-        "models.ResponseId": {
-            "type": "object",
-            "properties": {
-                "id": {
+                },
+                "contact_phone": {
+                    "type": "string"
+                },
+                "credit_notes": {
+                    "type": "string"
+                },
+                "dot": {
+                    "type": "string"
+                },
+                "mc": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
+                },
+                "payment_terms": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "models.ResponseSuccess": {
+        "swag.CreateUpdateTrailer": {
             "type": "object",
+            "required": [
+                "company_id",
+                "number",
+                "type",
+                "vin"
+            ],
             "properties": {
-                "message": {
+                "company_id": {
+                    "type": "string"
+                },
+                "length": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "make": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "number": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "ownership": {
+                    "type": "string",
+                    "enum": [
+                        "COMPANY",
+                        "OWNER_OPERATOR",
+                        "LEASED"
+                    ]
+                },
+                "plate": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "plate_state": {
+                    "type": "string"
+                },
+                "registration_expiry": {
                     "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ACTIVE",
+                        "INACTIVE"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "DRY_VAN",
+                        "REEFER",
+                        "FLATBED",
+                        "STEP_DECK",
+                        "TANKER",
+                        "OTHER"
+                    ]
+                },
+                "vin": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "models.Transaction": {
+        "swag.CreateUpdateTransaction": {
             "type": "object",
+            "required": [
+                "delivery_time",
+                "driver_id",
+                "employee_id",
+                "from",
+                "pu_time",
+                "to"
+            ],
             "properties": {
                 "cargo_id": {
                     "type": "string"
                 },
                 "cost": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "delivery_time": {
                     "type": "string"
                 },
-                "driver": {
-                    "$ref": "#/definitions/models.Driver"
-                },
                 "driver_id": {
                     "type": "string"
                 },
@@ -2534,20 +9827,22 @@ const docTemplate = `{
                 "from": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
                 "loaded_miles": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "provider": {
                     "type": "string"
                 },
+                "provider_id": {
+                    "type": "string"
+                },
                 "pu_time": {
                     "type": "string"
                 },
                 "rate": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
                 },
                 "success": {
                     "type": "boolean"
@@ -2556,256 +9851,355 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "total_miles": {
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "swag.CancelLogistic": {
+        "swag.CreateUpdateTruck": {
             "type": "object",
+            "required": [
+                "company_id",
+                "number",
+                "vin"
+            ],
             "properties": {
-                "cancel": {
-                    "type": "boolean"
-                },
                 "company_id": {
                     "type": "string"
                 },
-                "logistic_id": {
-                    "type": "string"
+                "make": {
+                    "type": "string",
+                    "maxLength": 30
                 },
-                "reason": {
+                "model": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "number": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "ownership": {
+                    "type": "string",
+                    "enum": [
+                        "COMPANY",
+                        "OWNER_OPERATOR",
+                        "LEASED"
+                    ]
+                },
+                "plate": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "plate_state": {
                     "type": "string"
                 },
-                "section": {
+                "registration_expiry": {
                     "type": "string"
                 },
                 "status": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "ACTIVE",
+                        "INACTIVE"
+                    ]
                 },
-                "whose_fault": {
+                "vin": {
                     "type": "string"
+                },
+                "year": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "swag.CreateUpdateCompany": {
+        "swag.CreateUpdateWebhookSubscription": {
             "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "dot": {
-                    "type": "integer"
+                "active": {
+                    "type": "boolean"
                 },
-                "mc": {
-                    "type": "integer"
+                "company_id": {
+                    "type": "string"
                 },
-                "name": {
+                "event_types": {
                     "type": "string"
                 },
-                "number": {
+                "name": {
                     "type": "string"
                 },
-                "scac": {
+                "secret": {
                     "type": "string"
                 },
-                "start_date": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "swag.CreateUpdateDriver": {
+        "swag.GenerateInvoice": {
             "type": "object",
+            "required": [
+                "company_id",
+                "provider_id",
+                "transaction_ids"
+            ],
             "properties": {
-                "birthday": {
-                    "type": "string"
+                "accessorials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swag.InvoiceAccessorial"
+                    }
                 },
                 "company_id": {
                     "type": "string"
                 },
-                "mail": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "phone_number": {
+                "notes": {
                     "type": "string"
                 },
-                "position": {
+                "provider_id": {
                     "type": "string"
                 },
-                "start_date": {
-                    "type": "string"
+                "transaction_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "swag.InvoiceAccessorial": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
                 },
-                "surname": {
+                "description": {
                     "type": "string"
                 },
-                "truck_number": {
+                "transaction_id": {
                     "type": "string"
                 },
                 "type": {
-                    "type": "string"
+                    "type": "string",
+                    "enum": [
+                        "DETENTION",
+                        "LAYOVER",
+                        "LUMPER",
+                        "TONU",
+                        "OTHER"
+                    ]
                 }
             }
         },
-        "swag.CreateUpdateEmployee": {
+        "swag.RunSettlements": {
             "type": "object",
+            "required": [
+                "week_start"
+            ],
             "properties": {
-                "access_level": {
-                    "type": "integer"
-                },
-                "birthday": {
-                    "type": "string"
-                },
-                "company": {
+                "week_start": {
                     "type": "string"
+                }
+            }
+        },
+        "swag.SaveDriverCompliance": {
+            "type": "object",
+            "properties": {
+                "cdl_class": {
+                    "type": "string",
+                    "enum": [
+                        "A",
+                        "B",
+                        "C",
+                        "a",
+                        "b",
+                        "c"
+                    ]
                 },
-                "email": {
+                "cdl_expiry": {
                     "type": "string"
                 },
-                "logo_id": {
+                "cdl_number": {
                     "type": "string"
                 },
-                "name": {
+                "cdl_state": {
                     "type": "string"
                 },
-                "password": {
+                "endorsements": {
                     "type": "string"
                 },
-                "phone_number": {
+                "last_drug_test": {
                     "type": "string"
                 },
-                "position": {
+                "medical_card_expiry": {
                     "type": "string"
                 },
-                "start_date": {
+                "mvr_next_review": {
                     "type": "string"
                 },
-                "surname": {
+                "mvr_reviewed_at": {
                     "type": "string"
                 },
-                "username": {
+                "next_drug_test": {
                     "type": "string"
                 }
             }
         },
-        "swag.CreateUpdateLogistic": {
+        "swag.SaveDriverHOS": {
             "type": "object",
             "properties": {
-                "cargo_id": {
-                    "type": "string"
+                "cycle_left": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "driver_id": {
-                    "type": "string"
-                },
-                "location": {
-                    "type": "string"
+                "drive_left": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "notion": {
+                "eld_driver_id": {
                     "type": "string"
                 },
-                "post": {
-                    "type": "boolean"
-                },
-                "st_time": {
-                    "type": "string"
+                "on_duty_left": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "status": {
+                "reported_at": {
                     "type": "string"
                 }
             }
         },
-        "swag.CreateUpdatePerformance": {
+        "swag.SavePayProfile": {
             "type": "object",
+            "required": [
+                "method"
+            ],
+            "properties": {
+                "empty_rate": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "loaded_rate": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "CPM",
+                        "PERCENT"
+                    ]
+                },
+                "percent": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "swag.ShortPayInvoice": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
             "properties": {
-                "company_id": {
-                    "type": "string"
-                },
-                "employee_id": {
-                    "type": "string"
-                },
-                "load_id": {
-                    "type": "string"
-                },
                 "reason": {
                     "type": "string"
-                },
-                "section": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "whose_fault": {
-                    "type": "string"
                 }
             }
         },
-        "swag.CreateUpdateTransaction": {
+        "swag.SnoozeAlert": {
             "type": "object",
             "properties": {
-                "cargo_id": {
-                    "type": "string"
-                },
-                "cost": {
+                "minutes": {
                     "type": "integer"
-                },
-                "delivery_time": {
-                    "type": "string"
-                },
-                "driver_id": {
-                    "type": "string"
-                },
-                "employee_id": {
+                }
+            }
+        },
+        "swag.TerminateLogistic": {
+            "type": "object",
+            "required": [
+                "logistic_id"
+            ],
+            "properties": {
+                "logistic_id": {
                     "type": "string"
                 },
-                "from": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "swag.UpdateInvoiceFactoring": {
+            "type": "object",
+            "properties": {
+                "advance_amount": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "loaded_miles": {
-                    "type": "integer"
+                "factored": {
+                    "type": "boolean"
                 },
-                "provider": {
+                "factored_at": {
                     "type": "string"
                 },
-                "pu_time": {
+                "factoring_company": {
                     "type": "string"
                 },
-                "rate": {
-                    "type": "number"
+                "factoring_fee": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "success": {
-                    "type": "boolean"
+                "reserve_amount": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "to": {
+                "reserve_released_at": {
                     "type": "string"
-                },
-                "total_miles": {
-                    "type": "integer"
                 }
             }
         },
-        "swag.TerminateLogistic": {
+        "swag.UpdateInvoiceStatus": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "logistic_id": {
-                    "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "SENT",
+                        "VOID"
+                    ]
                 }
             }
         },
         "swag.UpdateLogisticWithCargo": {
             "type": "object",
+            "required": [
+                "location",
+                "st_time",
+                "status"
+            ],
             "properties": {
                 "cargo_id": {
                     "type": "string"
                 },
+                "cargo_version": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "cost": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "create": {
                     "type": "boolean"
@@ -2817,7 +10211,8 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "free_miles": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "from": {
                     "type": "string"
@@ -2826,7 +10221,8 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "loaded_miles": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "location": {
                     "type": "string"
@@ -2835,7 +10231,14 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "notion": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "override_duplicate": {
+                    "type": "boolean"
+                },
+                "override_hos": {
+                    "type": "boolean"
                 },
                 "pick_up_time": {
                     "type": "string"
@@ -2846,8 +10249,12 @@ const docTemplate = `{
                 "provider": {
                     "type": "string"
                 },
+                "provider_id": {
+                    "type": "string"
+                },
                 "rate": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
                 },
                 "st_time": {
                     "type": "string"
@@ -2855,8 +10262,92 @@ const docTemplate = `{
                 "status": {
                     "type": "string"
                 },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swag.CargoStop"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "swag.UpdateStopStatus": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "ARRIVED",
+                        "COMPLETED",
+                        "pending",
+                        "arrived",
+                        "completed"
+                    ]
+                }
+            }
+        },
+        "swag.UpdateTransaction": {
+            "type": "object",
+            "required": [
+                "delivery_time",
+                "driver_id",
+                "employee_id",
+                "from",
+                "pu_time",
+                "to"
+            ],
+            "properties": {
+                "cargo_id": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "delivery_time": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "loaded_miles": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "pu_time": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "success": {
+                    "type": "boolean"
+                },
                 "to": {
                     "type": "string"
+                },
+                "total_miles": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         }
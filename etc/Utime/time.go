@@ -1,14 +1,14 @@
 package Utime
 
 import (
-	"log"
+	"log/slog"
 	"time"
 )
 
 func Now() time.Time {
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
-		log.Printf("Error loading location: %v, using UTC as fallback", err)
+		slog.Warn("Error loading location, using UTC as fallback", "error", err)
 	}
 	return time.Now().In(loc)
 }
@@ -16,7 +16,7 @@ func Now() time.Time {
 func Parse(t time.Time) time.Time {
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
-		log.Printf("Error loading location: %v, using UTC as fallback", err)
+		slog.Warn("Error loading location", "error", err)
 		return time.Time{}
 	}
 
@@ -0,0 +1,142 @@
+package alerting
+
+import (
+	"backend/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Channel delivers an alert of a rule somewhere outside the engine.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, rule *models.AlertRule, alert *models.Alert) error
+}
+
+// InboxChannel puts the alert into the in-app inbox. The alert is already
+// stored, so it is only marked to be listed there.
+type InboxChannel struct{}
+
+func NewInboxChannel() *InboxChannel { return &InboxChannel{} }
+
+func (c *InboxChannel) Name() string { return models.AlertChannelInbox }
+
+func (c *InboxChannel) Send(_ context.Context, _ *models.AlertRule, alert *models.Alert) error {
+	alert.Inbox = true
+	return nil
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPChannel mails the alert to the recipients of the rule. Without a username
+// no authentication is used, which is what local stand-ins like MailHog expect.
+type SMTPChannel struct {
+	config SMTPConfig
+}
+
+func NewSMTPChannel(config SMTPConfig) *SMTPChannel {
+	if config.Port == "" {
+		config.Port = "25"
+	}
+
+	return &SMTPChannel{config: config}
+}
+
+func (c *SMTPChannel) Name() string { return models.AlertChannelEmail }
+
+func (c *SMTPChannel) Send(_ context.Context, rule *models.AlertRule, alert *models.Alert) error {
+	recipients := rule.RecipientList()
+	if len(recipients) == 0 {
+		return errors.New("rule has no recipients")
+	}
+
+	var auth smtp.Auth
+	if c.config.Username != "" {
+		auth = smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", c.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&msg, "Subject: [%s] %s\r\n", alert.Severity, alert.Title)
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(alert.Message + "\r\n")
+
+	return smtp.SendMail(c.config.Host+":"+c.config.Port, auth, c.config.From, recipients, []byte(msg.String()))
+}
+
+// WebhookChannel posts the alert as JSON to the webhook URL of the rule.
+type WebhookChannel struct {
+	client *http.Client
+}
+
+func NewWebhookChannel() *WebhookChannel {
+	return &WebhookChannel{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (c *WebhookChannel) Name() string { return models.AlertChannelWebhook }
+
+type webhookPayload struct {
+	AlertId     string    `json:"alert_id"`
+	Rule        string    `json:"rule"`
+	Kind        string    `json:"kind"`
+	Severity    string    `json:"severity"`
+	Title       string    `json:"title"`
+	Message     string    `json:"message"`
+	DedupKey    string    `json:"dedup_key"`
+	Count       int       `json:"count"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, rule *models.AlertRule, alert *models.Alert) error {
+	if rule.WebhookURL == "" {
+		return errors.New("rule has no webhook URL")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		AlertId:     alert.Id.String(),
+		Rule:        rule.Name,
+		Kind:        rule.Kind,
+		Severity:    alert.Severity,
+		Title:       alert.Title,
+		Message:     alert.Message,
+		DedupKey:    alert.DedupKey,
+		Count:       alert.Count,
+		FirstSeenAt: alert.FirstSeenAt,
+		LastSeenAt:  alert.LastSeenAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rule.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
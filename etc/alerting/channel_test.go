@@ -0,0 +1,120 @@
+package alerting
+
+import (
+	"backend/models"
+	"context"
+	"net"
+	"net/textproto"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// smtpMail is what the SMTP stub received in one session.
+type smtpMail struct {
+	from       string
+	recipients []string
+	data       string
+}
+
+// startSMTPStub accepts a single SMTP session on a local port and hands the
+// received mail over once the client quits.
+func startSMTPStub(t *testing.T) (host, port string, mails <-chan smtpMail) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan smtpMail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var (
+			text = textproto.NewConn(conn)
+			mail smtpMail
+		)
+		text.PrintfLine("220 localhost ESMTP stub")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+
+			verb, arg, _ := strings.Cut(line, " ")
+			switch strings.ToUpper(verb) {
+			case "EHLO", "HELO":
+				text.PrintfLine("250 localhost")
+			case "MAIL":
+				mail.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+				text.PrintfLine("250 OK")
+			case "RCPT":
+				mail.recipients = append(mail.recipients, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+				text.PrintfLine("250 OK")
+			case "DATA":
+				text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				mail.data = string(data)
+				text.PrintfLine("250 OK")
+			case "QUIT":
+				text.PrintfLine("221 Bye")
+				received <- mail
+				return
+			default:
+				text.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	host, port, _ = net.SplitHostPort(listener.Addr().String())
+	return host, port, received
+}
+
+func TestSMTPChannelSend(t *testing.T) {
+	host, port, mails := startSMTPStub(t)
+
+	var (
+		channel = NewSMTPChannel(SMTPConfig{Host: host, Port: port, From: "alerts@sslsgroup.com"})
+		rule    = &models.AlertRule{Recipients: "dispatch@sslsgroup.com, safety@sslsgroup.com"}
+		alert   = &models.Alert{
+			Severity: models.AlertSeverityWarning,
+			Title:    "CDL of John Smith expires in 30 day(s)",
+			Message:  "CDL of John Smith expires in 30 day(s)\nExpires at: 2024-06-30",
+		}
+	)
+	if err := channel.Send(context.Background(), rule, alert); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	mail := <-mails
+	if mail.from != "alerts@sslsgroup.com" {
+		t.Errorf("got sender %q", mail.from)
+	}
+	if want := []string{"dispatch@sslsgroup.com", "safety@sslsgroup.com"}; !slices.Equal(mail.recipients, want) {
+		t.Errorf("got recipients %v, want %v", mail.recipients, want)
+	}
+
+	header, body, _ := strings.Cut(mail.data, "\n\n")
+	if !slices.Contains(strings.Split(header, "\n"), "Subject: [WARNING] CDL of John Smith expires in 30 day(s)") {
+		t.Errorf("subject missing from header:\n%s", header)
+	}
+	if want := alert.Message + "\n"; body != want {
+		t.Errorf("got body %q, want %q", body, want)
+	}
+}
+
+func TestSMTPChannelSendWithoutRecipients(t *testing.T) {
+	channel := NewSMTPChannel(SMTPConfig{Host: "127.0.0.1", Port: "1"})
+	if err := channel.Send(context.Background(), &models.AlertRule{}, &models.Alert{}); err == nil {
+		t.Error("mail without recipients was sent")
+	}
+}
@@ -0,0 +1,244 @@
+package alerting
+
+import (
+	"backend/etc/Utime"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Clock tells the engine what time it is, so that it can be driven by a fake
+// clock instead of the wall clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to Clock.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time { return f() }
+
+// SystemClock is the eastern time wall clock used by the rest of the backend.
+var SystemClock Clock = ClockFunc(Utime.Now)
+
+// Engine evaluates the enabled alert rules, keeps one alert per rule and
+// subject while the rule keeps firing, resolves alerts that stopped firing and
+// sends new ones to the channels of the rule.
+type Engine struct {
+	store    database.IStore
+	clock    Clock
+	channels map[string]Channel
+}
+
+func NewEngine(store database.IStore, clock Clock, channels ...Channel) *Engine {
+	engine := &Engine{
+		store:    store,
+		clock:    clock,
+		channels: make(map[string]Channel, len(channels)),
+	}
+	for _, channel := range channels {
+		engine.channels[channel.Name()] = channel
+	}
+
+	return engine
+}
+
+// firing is one subject a rule fires for.
+type firing struct {
+	key        string
+	title      string
+	message    string
+	logisticId *uuid.UUID
+	driverId   *uuid.UUID
+	employeeId *uuid.UUID
+}
+
+// Run evaluates every enabled rule once.
+func (e *Engine) Run(ctx context.Context) error {
+	rules, err := e.store.Alert().GetRules(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	for i := range rules {
+		if err = e.evaluate(ctx, &rules[i]); err != nil {
+			slog.ErrorContext(ctx, "Failed to evaluate alert rule", "rule", rules[i].Name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *Engine) evaluate(ctx context.Context, rule *models.AlertRule) error {
+	now := e.clock.Now()
+
+	firings, err := e.match(ctx, rule, now)
+	if err != nil {
+		return err
+	}
+
+	active, err := e.store.Alert().GetActiveAlerts(ctx, rule.Id)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*models.Alert, len(active))
+	for i := range active {
+		byKey[active[i].DedupKey] = &active[i]
+	}
+
+	for _, f := range firings {
+		alert, seen := byKey[f.key]
+		if seen {
+			delete(byKey, f.key)
+			alert.Count++
+			alert.LastSeenAt = now
+			alert.Title, alert.Message = f.title, f.message
+		} else {
+			alert = &models.Alert{
+				RuleId:      rule.Id,
+				DedupKey:    f.key,
+				Title:       f.title,
+				Message:     f.message,
+				Severity:    rule.Severity,
+				Status:      models.AlertStatusOpen,
+				LogisticId:  f.logisticId,
+				DriverId:    f.driverId,
+				EmployeeId:  f.employeeId,
+				Count:       1,
+				FirstSeenAt: now,
+				LastSeenAt:  now,
+			}
+			if err = e.store.Alert().CreateAlert(ctx, alert); err != nil {
+				return err
+			}
+		}
+
+		if alert.ShouldNotify(rule, now) {
+			alert.DeliveryError = e.notify(ctx, rule, alert)
+			alert.NotifiedAt = &now
+		}
+
+		if err = e.store.Alert().SaveAlert(ctx, alert); err != nil {
+			return err
+		}
+	}
+
+	resolved := make([]uuid.UUID, 0, len(byKey))
+	for _, alert := range byKey {
+		resolved = append(resolved, alert.Id)
+	}
+
+	return e.store.Alert().Resolve(ctx, resolved, now)
+}
+
+// notify sends the alert to every channel of the rule and returns what failed.
+func (e *Engine) notify(ctx context.Context, rule *models.AlertRule, alert *models.Alert) string {
+	var failures []string
+	for _, name := range rule.ChannelList() {
+		channel, ok := e.channels[name]
+		if !ok {
+			failures = append(failures, name+": channel is not configured")
+			continue
+		}
+
+		if err := channel.Send(ctx, rule, alert); err != nil {
+			failures = append(failures, name+": "+err.Error())
+		}
+	}
+
+	failure := strings.Join(failures, "; ")
+	if len(failure) > 255 {
+		failure = failure[:255]
+	}
+
+	return failure
+}
+
+func (e *Engine) match(ctx context.Context, rule *models.AlertRule, now time.Time) ([]firing, error) {
+	if rule.Kind == models.AlertKindDocumentExpiring {
+		return e.matchDocuments(ctx, rule, now)
+	}
+
+	logistics, err := e.store.Alert().MatchLogistics(ctx, rule, now)
+	if err != nil {
+		return nil, err
+	}
+
+	firings := make([]firing, 0, len(logistics))
+	for i := range logistics {
+		var (
+			logistic = &logistics[i]
+			driver   = logistic.Driver.FullName()
+			stTime   = Utime.Parse(*logistic.StTime)
+			f        = firing{key: "logistic:" + logistic.Id.String(), logisticId: &logistic.Id, driverId: &logistic.DriverId}
+		)
+
+		switch rule.Kind {
+		case models.AlertKindStatusDuration:
+			f.title = fmt.Sprintf("%s is %s for %s", driver, logistic.Status, formatDuration(now.Sub(stTime)))
+		case models.AlertKindETANearPickup:
+			f.title = fmt.Sprintf("%s arrives %s before pickup of load %s", driver,
+				formatDuration(Utime.Parse(logistic.Cargo.PickUpTime).Sub(stTime)), logistic.Cargo.CargoID)
+		case models.AlertKindETAPassed:
+			f.title = fmt.Sprintf("ETA of %s passed %s ago", driver, formatDuration(now.Sub(stTime)))
+		case models.AlertKindLateLoad:
+			f.title = fmt.Sprintf("Load %s of %s is late by %s", logistic.Cargo.CargoID, driver, formatDuration(now.Sub(stTime)))
+			f.employeeId = &logistic.Cargo.EmployeeId
+		}
+		f.message = fmt.Sprintf("%s\nTruck: %s\nStatus: %s\nLocation: %s, %s\nST time: %s\nNote: %s",
+			f.title, logistic.Driver.TruckNumber, logistic.Status, logistic.Location, logistic.State,
+			stTime.Format("2006-01-02 15:04"), logistic.Notion)
+
+		firings = append(firings, f)
+	}
+
+	return firings, nil
+}
+
+func (e *Engine) matchDocuments(ctx context.Context, rule *models.AlertRule, now time.Time) ([]firing, error) {
+	records, err := e.store.Compliance().GetExpiring(ctx, now.AddDate(0, 0, rule.Threshold))
+	if err != nil {
+		return nil, err
+	}
+
+	var firings []firing
+	for i := range records {
+		record := &records[i]
+		driver := record.Driver.FullName()
+		for _, document := range record.Expiring(now, rule.Threshold) {
+			f := firing{
+				key:      "driver:" + record.DriverId.String() + ":" + document.Document,
+				driverId: &record.DriverId,
+			}
+			if document.DaysLeft < 0 {
+				f.title = fmt.Sprintf("%s of %s expired %d day(s) ago", document.Document, driver, -document.DaysLeft)
+			} else {
+				f.title = fmt.Sprintf("%s of %s expires in %d day(s)", document.Document, driver, document.DaysLeft)
+			}
+			f.message = fmt.Sprintf("%s\nExpires at: %s", f.title, document.ExpiresAt.Format("2006-01-02"))
+
+			firings = append(firings, f)
+		}
+	}
+
+	return firings, nil
+}
+
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Minute)
+
+	hours := int(d.Hours())
+	if hours >= 24 {
+		return fmt.Sprintf("%dd %dh", hours/24, hours%24)
+	}
+
+	return fmt.Sprintf("%dh %dm", hours, int(d.Minutes())%60)
+}
@@ -0,0 +1,324 @@
+package alerting
+
+import (
+	"backend/models"
+	database "backend/st_database"
+	"backend/st_database/storage"
+	"context"
+	"github.com/google/uuid"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeStore serves the alert rules and alerts from memory and the compliance
+// records the document rules match. Everything else panics if used.
+type fakeStore struct {
+	database.IStore
+	alerts     *fakeAlerts
+	compliance *fakeCompliance
+}
+
+func (s *fakeStore) Alert() storage.Alert           { return s.alerts }
+func (s *fakeStore) Compliance() storage.Compliance { return s.compliance }
+
+type fakeAlerts struct {
+	storage.Alert
+	rules  []models.AlertRule
+	alerts map[uuid.UUID]models.Alert
+}
+
+func (f *fakeAlerts) GetRules(_ context.Context, onlyEnabled bool) ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	for _, rule := range f.rules {
+		if rule.Enabled || !onlyEnabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (f *fakeAlerts) CreateAlert(_ context.Context, alert *models.Alert) error {
+	alert.Id = uuid.New()
+	f.alerts[alert.Id] = *alert
+	return nil
+}
+
+func (f *fakeAlerts) SaveAlert(_ context.Context, alert *models.Alert) error {
+	f.alerts[alert.Id] = *alert
+	return nil
+}
+
+func (f *fakeAlerts) GetActiveAlerts(_ context.Context, ruleId uuid.UUID) ([]models.Alert, error) {
+	var alerts []models.Alert
+	for _, alert := range f.alerts {
+		if alert.RuleId == ruleId && alert.Status != models.AlertStatusResolved {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+func (f *fakeAlerts) Resolve(_ context.Context, ids []uuid.UUID, at time.Time) error {
+	for _, id := range ids {
+		alert := f.alerts[id]
+		alert.Status, alert.ResolvedAt = models.AlertStatusResolved, &at
+		f.alerts[id] = alert
+	}
+	return nil
+}
+
+func (f *fakeAlerts) Acknowledge(_ context.Context, id uuid.UUID, by uuid.UUID, at time.Time) error {
+	alert := f.alerts[id]
+	alert.Status, alert.AcknowledgedAt, alert.AcknowledgedBy = models.AlertStatusAcknowledged, &at, &by
+	f.alerts[id] = alert
+	return nil
+}
+
+func (f *fakeAlerts) Snooze(_ context.Context, id uuid.UUID, until time.Time) error {
+	alert := f.alerts[id]
+	alert.SnoozedUntil = &until
+	f.alerts[id] = alert
+	return nil
+}
+
+// only returns the single alert of the store.
+func (f *fakeAlerts) only(t *testing.T) models.Alert {
+	t.Helper()
+	if len(f.alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1", len(f.alerts))
+	}
+	for _, alert := range f.alerts {
+		return alert
+	}
+	return models.Alert{}
+}
+
+type fakeCompliance struct {
+	storage.Compliance
+	records []models.DriverCompliance
+}
+
+func (f *fakeCompliance) GetExpiring(_ context.Context, until time.Time) ([]models.DriverCompliance, error) {
+	var records []models.DriverCompliance
+	for _, record := range f.records {
+		if len(record.Expiring(until, 0)) > 0 {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// recordingChannel stands in for the email channel and keeps what was sent.
+type recordingChannel struct {
+	sent []string
+}
+
+func (c *recordingChannel) Name() string { return models.AlertChannelEmail }
+
+func (c *recordingChannel) Send(_ context.Context, _ *models.AlertRule, alert *models.Alert) error {
+	c.sent = append(c.sent, alert.Title)
+	return nil
+}
+
+// fakeClock is moved by the tests instead of waiting for the wall clock.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Clock() Clock            { return ClockFunc(func() time.Time { return c.now }) }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+func (c *fakeClock) AdvanceDays(days int)    { c.now = c.now.AddDate(0, 0, days) }
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 8, 0, 0, 0, time.UTC)
+}
+
+type engineTest struct {
+	engine     *Engine
+	clock      *fakeClock
+	alerts     *fakeAlerts
+	compliance *fakeCompliance
+	channel    *recordingChannel
+}
+
+// newEngineTest sets up a rule that fires 30 days before a document of the
+// driver expires, re-sends every cooldown minutes, and a CDL that expires on
+// June 30th.
+func newEngineTest(cooldown int) *engineTest {
+	var (
+		cdlExpiry = date(2024, time.June, 30)
+		test      = &engineTest{
+			clock: &fakeClock{now: date(2024, time.May, 1)},
+			alerts: &fakeAlerts{
+				rules: []models.AlertRule{{
+					Id:        uuid.New(),
+					Name:      "Documents",
+					Kind:      models.AlertKindDocumentExpiring,
+					Threshold: 30,
+					Severity:  models.AlertSeverityWarning,
+					Channels:  models.AlertChannelEmail,
+					Cooldown:  cooldown,
+					Enabled:   true,
+				}},
+				alerts: make(map[uuid.UUID]models.Alert),
+			},
+			compliance: &fakeCompliance{records: []models.DriverCompliance{{
+				DriverId:  uuid.New(),
+				Driver:    models.Driver{Name: "John", Surname: "Smith"},
+				CDLExpiry: &cdlExpiry,
+			}}},
+			channel: &recordingChannel{},
+		}
+	)
+	test.engine = NewEngine(&fakeStore{alerts: test.alerts, compliance: test.compliance}, test.clock.Clock(), test.channel)
+
+	return test
+}
+
+func (e *engineTest) run(t *testing.T) {
+	t.Helper()
+	if err := e.engine.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
+
+func TestEngineFiresOncePastThreshold(t *testing.T) {
+	test := newEngineTest(0)
+
+	test.run(t)
+	if len(test.alerts.alerts) != 0 || len(test.channel.sent) != 0 {
+		t.Fatalf("rule fired 60 days before expiry: %d alerts, %d sent", len(test.alerts.alerts), len(test.channel.sent))
+	}
+
+	test.clock.AdvanceDays(29)
+	test.run(t)
+	if len(test.alerts.alerts) != 0 {
+		t.Fatalf("rule fired 31 days before expiry")
+	}
+
+	test.clock.AdvanceDays(1)
+	test.run(t)
+	alert := test.alerts.only(t)
+	if alert.Status != models.AlertStatusOpen || alert.DedupKey != "driver:"+test.compliance.records[0].DriverId.String()+":CDL" {
+		t.Errorf("got alert %s with key %s, want an open CDL alert", alert.Status, alert.DedupKey)
+	}
+	if want := "CDL of John Smith expires in 30 day(s)"; alert.Title != want {
+		t.Errorf("got title %q, want %q", alert.Title, want)
+	}
+	if len(test.channel.sent) != 1 || alert.NotifiedAt == nil || !alert.NotifiedAt.Equal(test.clock.now) {
+		t.Errorf("got %d sent, notified at %v, want one send now", len(test.channel.sent), alert.NotifiedAt)
+	}
+}
+
+func TestEngineDedupsFiringSubject(t *testing.T) {
+	test := newEngineTest(0)
+	test.clock.AdvanceDays(35)
+
+	test.run(t)
+	first := test.alerts.only(t)
+
+	test.clock.Advance(time.Hour)
+	test.run(t)
+	test.clock.AdvanceDays(1)
+	test.run(t)
+
+	alert := test.alerts.only(t)
+	if alert.Id != first.Id {
+		t.Errorf("a second alert was opened for the same subject")
+	}
+	if alert.Count != 3 {
+		t.Errorf("got count %d, want 3", alert.Count)
+	}
+	if !alert.FirstSeenAt.Equal(first.FirstSeenAt) || !alert.LastSeenAt.Equal(test.clock.now) {
+		t.Errorf("got first seen %v and last seen %v", alert.FirstSeenAt, alert.LastSeenAt)
+	}
+	if !strings.Contains(alert.Title, "24 day(s)") {
+		t.Errorf("title was not refreshed: %q", alert.Title)
+	}
+	if len(test.channel.sent) != 1 {
+		t.Errorf("got %d sends, want the alert sent once", len(test.channel.sent))
+	}
+}
+
+func TestEngineSnoozeSuppressesNotification(t *testing.T) {
+	test := newEngineTest(60)
+	test.clock.AdvanceDays(35)
+	test.run(t)
+
+	alert := test.alerts.only(t)
+	if err := test.alerts.Snooze(context.Background(), alert.Id, test.clock.now.Add(3*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	test.clock.Advance(90 * time.Minute)
+	test.run(t)
+	if len(test.channel.sent) != 1 {
+		t.Fatalf("snoozed alert was sent again")
+	}
+
+	test.clock.Advance(2 * time.Hour)
+	test.run(t)
+	if len(test.channel.sent) != 2 {
+		t.Errorf("got %d sends, want the alert sent again after the snooze", len(test.channel.sent))
+	}
+	if count := test.alerts.only(t).Count; count != 3 {
+		t.Errorf("got count %d, want 3", count)
+	}
+}
+
+func TestEngineAckSuppressesNotification(t *testing.T) {
+	test := newEngineTest(60)
+	test.clock.AdvanceDays(35)
+	test.run(t)
+
+	alert := test.alerts.only(t)
+	if err := test.alerts.Acknowledge(context.Background(), alert.Id, uuid.New(), test.clock.now); err != nil {
+		t.Fatal(err)
+	}
+
+	test.clock.Advance(2 * time.Hour)
+	test.run(t)
+
+	alert = test.alerts.only(t)
+	if len(test.channel.sent) != 1 {
+		t.Errorf("acknowledged alert was sent again")
+	}
+	if alert.Status != models.AlertStatusAcknowledged || alert.Count != 2 {
+		t.Errorf("got %s alert with count %d, want it acknowledged and counted", alert.Status, alert.Count)
+	}
+}
+
+func TestEngineResolvesWhenSubjectStopsMatching(t *testing.T) {
+	test := newEngineTest(0)
+	test.clock.AdvanceDays(35)
+	test.run(t)
+	first := test.alerts.only(t)
+
+	renewed := date(2028, time.June, 30)
+	test.compliance.records[0].CDLExpiry = &renewed
+	test.clock.Advance(time.Hour)
+	test.run(t)
+
+	alert := test.alerts.only(t)
+	if alert.Status != models.AlertStatusResolved || alert.ResolvedAt == nil || !alert.ResolvedAt.Equal(test.clock.now) {
+		t.Fatalf("got %s alert resolved at %v, want it resolved now", alert.Status, alert.ResolvedAt)
+	}
+
+	expiring := date(2024, time.June, 30)
+	test.compliance.records[0].CDLExpiry = &expiring
+	test.clock.Advance(time.Hour)
+	test.run(t)
+
+	if len(test.alerts.alerts) != 2 {
+		t.Fatalf("got %d alerts, want a new one opened next to the resolved one", len(test.alerts.alerts))
+	}
+	for _, alert := range test.alerts.alerts {
+		if alert.Id != first.Id && (alert.Status != models.AlertStatusOpen || alert.Count != 1) {
+			t.Errorf("got reopened alert %s with count %d", alert.Status, alert.Count)
+		}
+	}
+	if len(test.channel.sent) != 2 {
+		t.Errorf("got %d sends, want the new alert sent", len(test.channel.sent))
+	}
+}
@@ -0,0 +1,199 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+)
+
+// Kind is the class of a failure. It decides the HTTP status, the Code says
+// what exactly went wrong.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+)
+
+// Codes of the kinds, used when nothing more specific is known.
+const (
+	CodeInternal     = "INTERNAL_ERROR"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeValidation   = "VALIDATION_FAILED"
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+	CodeBadRequest   = "BAD_REQUEST"
+)
+
+// Error is a failure the caller can act on. Err is the cause, if any, and is
+// kept for errors.Is and errors.As. Fields lists every invalid field of a
+// rejected request.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// FieldError is a field of a request that failed the check named by Rule.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(kind Kind, code, format string, args []any) *Error {
+	err := fmt.Errorf(format, args...)
+	return &Error{Kind: kind, Code: code, Message: err.Error(), Err: errors.Unwrap(err)}
+}
+
+// NotFound, Conflict, Validation, Unauthorized and Forbidden create an error
+// of their kind. The message is formatted like fmt.Errorf, including %w.
+func NotFound(code, format string, args ...any) *Error {
+	return newError(KindNotFound, code, format, args)
+}
+
+func Conflict(code, format string, args ...any) *Error {
+	return newError(KindConflict, code, format, args)
+}
+
+func Validation(code, format string, args ...any) *Error {
+	return newError(KindValidation, code, format, args)
+}
+
+// Invalid creates a validation error for a request with invalid fields.
+func Invalid(fields []FieldError) *Error {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Field)
+	}
+
+	return &Error{
+		Kind:    KindValidation,
+		Code:    CodeValidation,
+		Message: "invalid fields: " + strings.Join(names, ", "),
+		Fields:  fields,
+	}
+}
+
+func Unauthorized(code, format string, args ...any) *Error {
+	return newError(KindUnauthorized, code, format, args)
+}
+
+func Forbidden(code, format string, args ...any) *Error {
+	return newError(KindForbidden, code, format, args)
+}
+
+// constraintCodes names the unique constraints clients are expected to tell
+// apart. Other violations get CodeConflict.
+var constraintCodes = map[string]string{
+	"uni_employees_username":        "USERNAME_TAKEN",
+	"uni_employees_email":           "EMAIL_TAKEN",
+	"uni_logistics_driver_id":       "DRIVER_ALREADY_ON_BOARD",
+	"idx_providers_normalized_name": "PROVIDER_EXISTS",
+	"idx_invoices_company_number":   "INVOICE_NUMBER_TAKEN",
+	"idx_trucks_vin":                "VIN_TAKEN",
+	"idx_trailers_vin":              "VIN_TAKEN",
+}
+
+// FromDB turns an error of gorm or Postgres into an Error of the matching
+// kind. Errors it does not recognize are returned as they are.
+func FromDB(err error) error {
+	var appErr *Error
+	if err == nil || errors.As(err, &appErr) {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &Error{Kind: KindNotFound, Code: CodeNotFound, Message: "record not found", Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case "23505":
+		code, ok := constraintCodes[pgErr.ConstraintName]
+		if !ok {
+			code = CodeConflict
+		}
+		return &Error{Kind: KindConflict, Code: code, Message: describe(pgErr, "already exists"), Err: err}
+	case "23503":
+		if strings.Contains(pgErr.Detail, "still referenced") {
+			return &Error{Kind: KindConflict, Code: "STILL_REFERENCED", Message: describe(pgErr, "is still in use"), Err: err}
+		}
+		return &Error{Kind: KindValidation, Code: "UNKNOWN_REFERENCE", Message: describe(pgErr, "refers to a record that does not exist"), Err: err}
+	case "23502", "23514", "22001", "22P02", "22007", "22008":
+		return &Error{Kind: KindValidation, Code: CodeValidation, Message: describe(pgErr, pgErr.Message), Err: err}
+	}
+
+	return err
+}
+
+// describe prefers the detail of the server, which names the key involved.
+func describe(pgErr *pgconn.PgError, fallback string) string {
+	if pgErr.Detail != "" {
+		return pgErr.Detail
+	}
+	return fallback
+}
+
+// Typed is implemented by errors that carry more than a message, like the
+// duplicates of a load, and so can't be an Error themselves.
+type Typed interface {
+	error
+	AppError() *Error
+}
+
+// As returns err as an Error, translating database errors and classifying
+// everything else as internal.
+func As(err error) *Error {
+	var appErr *Error
+	if errors.As(FromDB(err), &appErr) {
+		return appErr
+	}
+
+	var typed Typed
+	if errors.As(err, &typed) {
+		return typed.AppError()
+	}
+
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: err.Error(), Err: err}
+}
+
+// Status returns the HTTP status of kind.
+func Status(kind Kind) int {
+	switch kind {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusUnprocessableEntity
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,43 @@
+package compliance
+
+import (
+	"backend/etc/Utime"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"log/slog"
+)
+
+// Check re-evaluates every driver's documents so that expiring and expired
+// drivers are flagged without anyone opening them.
+func Check(ctx context.Context, store database.IStore) error {
+	records, err := store.Compliance().GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		now     = Utime.Now()
+		flagged int
+	)
+	for i := range records {
+		record := &records[i]
+		status, issues := record.Status, record.Issues
+		record.Evaluate(now, models.ComplianceWarnDays)
+		if record.Status != models.ComplianceStatusOK {
+			flagged++
+		}
+		if record.Status == status && record.Issues == issues {
+			continue
+		}
+
+		record.CheckedAt = &now
+		if err = store.Compliance().UpdateStatus(ctx, record); err != nil {
+			slog.ErrorContext(ctx, "Failed to update compliance", "driver_id", record.DriverId, "error", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "Compliance check completed", "flagged", flagged, "checked", len(records))
+
+	return nil
+}
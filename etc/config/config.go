@@ -0,0 +1,380 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"log/slog"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFile is read when CONFIG_FILE is not set and the file exists.
+const DefaultFile = "config.yaml"
+
+// Config is every setting of the backend. Each value comes from the default
+// tag, then the YAML file, then the environment variable in the env tag, the
+// later one winning. Fields tagged secret are redacted by Print.
+type Config struct {
+	HTTP      HTTPConfig     `yaml:"http"`
+	Database  DatabaseConfig `yaml:"database"`
+	Auth      AuthConfig     `yaml:"auth"`
+	CORS      CORSConfig     `yaml:"cors"`
+	Log       LogConfig      `yaml:"log"`
+	Tracing   TracingConfig  `yaml:"tracing"`
+	Jobs      JobsConfig     `yaml:"jobs"`
+	SMTP      SMTPConfig     `yaml:"smtp"`
+	ELD       ELDConfig      `yaml:"eld"`
+	Metrics   MetricsConfig  `yaml:"metrics"`
+	Locations string         `yaml:"locations" env:"LOCATIONS_PATH" default:"/app/data/locations.json"`
+}
+
+// HTTPConfig is the listener. ShutdownTimeout is how long in-flight requests
+// and running jobs get to finish after SIGTERM.
+type HTTPConfig struct {
+	Addr            string        `yaml:"addr" env:"HTTP_ADDR" default:":8080"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" default:"30s"`
+}
+
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" env:"DB_HOST" default:"db"`
+	Port            int           `yaml:"port" env:"DB_PORT" default:"5432"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE" default:"disable"`
+	TimeZone        string        `yaml:"timezone" env:"DB_TIMEZONE" default:"US/Eastern"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" default:"25"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" default:"10"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" default:"1h"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" default:"30m"`
+}
+
+// DSN returns the connection string for the postgres driver.
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+		d.Host, d.User, d.Password, d.Name, d.Port, d.SSLMode, d.TimeZone,
+	)
+}
+
+type AuthConfig struct {
+	SecretKey string        `yaml:"secret_key" env:"SECRET_KEY" secret:"true"`
+	TokenTTL  time.Duration `yaml:"token_ttl" env:"JWT_TOKEN_TTL" default:"168h"`
+}
+
+// LogConfig sets the logger. Level is debug, info, warn or error and Format
+// json or text. Queries slower than SlowQuery are logged as warnings.
+type LogConfig struct {
+	Level     string        `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format    string        `yaml:"format" env:"LOG_FORMAT" default:"json"`
+	SlowQuery time.Duration `yaml:"slow_query" env:"LOG_SLOW_QUERY" default:"200ms"`
+}
+
+// TracingConfig sets where spans go. Exporter is none, stdout or otlp, which
+// sends them over HTTP to Endpoint, or to the OTEL_EXPORTER_OTLP_* variables
+// when Endpoint is empty. SampleRatio is the share of traces kept.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" default:"sslsgroup-backend"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1"`
+}
+
+// CORSConfig lists the origins browsers may call the API from. Browsers only
+// send cookies along with AllowCredentials, which needs the origins listed
+// instead of *.
+type CORSConfig struct {
+	AllowOrigins     []string `yaml:"allow_origins" env:"CORS_ALLOW_ORIGINS" default:"*"`
+	AllowCredentials bool     `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" default:"false"`
+}
+
+type JobsConfig struct {
+	BoardFlags time.Duration `yaml:"board_flags" env:"JOBS_BOARD_FLAGS_EVERY" default:"3m"`
+	Compliance time.Duration `yaml:"compliance" env:"JOBS_COMPLIANCE_EVERY" default:"1h"`
+	LateLoads  time.Duration `yaml:"late_loads" env:"JOBS_LATE_LOADS_EVERY" default:"1m"`
+	Alerts     time.Duration `yaml:"alerts" env:"JOBS_ALERTS_EVERY" default:"1m"`
+	Webhooks   time.Duration `yaml:"webhooks" env:"JOBS_WEBHOOKS_EVERY" default:"10s"`
+	ELDSync    time.Duration `yaml:"eld_sync" env:"JOBS_ELD_SYNC_EVERY" default:"15m"`
+}
+
+// SMTPConfig enables the email alert channel when Host is set.
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     string `yaml:"port" env:"SMTP_PORT"`
+	User     string `yaml:"user" env:"SMTP_USER"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	From     string `yaml:"from" env:"SMTP_FROM"`
+}
+
+// ELDConfig enables the ELD sync job when URL is set.
+type ELDConfig struct {
+	URL    string `yaml:"url" env:"ELD_URL"`
+	APIKey string `yaml:"api_key" env:"ELD_API_KEY" secret:"true"`
+}
+
+// MetricsConfig requires scrapers of /metrics to send Token as a bearer token.
+// Without a token the endpoint is open, for scrapers on a private network.
+type MetricsConfig struct {
+	Token string `yaml:"token" env:"METRICS_TOKEN" secret:"true"`
+}
+
+// Load reads the configuration and validates it.
+func Load() (*Config, error) {
+	cfg, err := Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Read reads the configuration from the defaults, the YAML file named by
+// CONFIG_FILE or DefaultFile, and the environment, without validating it.
+func Read() (*Config, error) {
+	cfg := &Config{}
+
+	err := walk(reflect.ValueOf(cfg).Elem(), "", func(field reflect.StructField, value reflect.Value, path string) error {
+		if def, ok := field.Tag.Lookup("default"); ok {
+			if err := set(value, def); err != nil {
+				return fmt.Errorf("default of %s: %w", path, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	path, explicit := os.LookupEnv("CONFIG_FILE")
+	if !explicit {
+		path = DefaultFile
+	}
+
+	content, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(content, cfg); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+	case explicit || !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	err = walk(reflect.ValueOf(cfg).Elem(), "", func(field reflect.StructField, value reflect.Value, path string) error {
+		name := field.Tag.Get("env")
+		if name == "" {
+			return nil
+		}
+
+		if env, ok := os.LookupEnv(name); ok {
+			if err := set(value, env); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate returns every problem of the configuration at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Auth.SecretKey == "" {
+		errs = append(errs, errors.New("auth.secret_key (SECRET_KEY) is required"))
+	}
+	if c.Auth.TokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.token_ttl must be positive"))
+	}
+	if c.HTTP.Addr == "" {
+		errs = append(errs, errors.New("http.addr is required"))
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("http.shutdown_timeout must be positive"))
+	}
+	if c.Database.Host == "" || c.Database.User == "" || c.Database.Name == "" {
+		errs = append(errs, errors.New("database.host, database.user and database.name are required"))
+	}
+	if c.Database.Port <= 0 || c.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database.port %d is out of range", c.Database.Port))
+	}
+	if c.Database.MaxOpenConns < 1 || c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, errors.New("database.max_idle_conns must be between 0 and database.max_open_conns, which must be positive"))
+	}
+	if _, err := time.LoadLocation(c.Database.TimeZone); err != nil {
+		errs = append(errs, fmt.Errorf("database.timezone: %w", err))
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		errs = append(errs, fmt.Errorf("log.format %q must be json or text", c.Log.Format))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter %q must be none, stdout or otlp", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio must be between 0 and 1"))
+	}
+	if len(c.CORS.AllowOrigins) == 0 {
+		errs = append(errs, errors.New("cors.allow_origins needs at least one origin"))
+	}
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowOrigins, "*") {
+		errs = append(errs, errors.New("cors.allow_credentials needs cors.allow_origins to list the origins instead of *"))
+	}
+	if c.Locations == "" {
+		errs = append(errs, errors.New("locations is required"))
+	}
+
+	err := walk(reflect.ValueOf(&c.Jobs).Elem(), "jobs", func(field reflect.StructField, value reflect.Value, path string) error {
+		if value.Interface().(time.Duration) < time.Second {
+			errs = append(errs, fmt.Errorf("%s must be at least 1s", path))
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Print returns the configuration as YAML with the secrets redacted.
+func (c *Config) Print() (string, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	nodes := map[string]*yaml.Node{"": root}
+
+	err := walk(reflect.ValueOf(c).Elem(), "", func(field reflect.StructField, value reflect.Value, path string) error {
+		parent := nodes[path[:max(strings.LastIndex(path, "."), 0)]]
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: field.Tag.Get("yaml")}
+
+		var node *yaml.Node
+		switch {
+		case field.Tag.Get("secret") == "true":
+			node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: redact(value.String())}
+		case value.Kind() == reflect.Slice:
+			node = &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+			for i := 0; i < value.Len(); i++ {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value.Index(i).String()})
+			}
+		case value.Kind() == reflect.String:
+			node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value.String()}
+		default:
+			node = &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(value.Interface())}
+		}
+
+		parent.Content = append(parent.Content, key, node)
+		return nil
+	}, func(field reflect.StructField, path string) {
+		parent := nodes[path[:max(strings.LastIndex(path, "."), 0)]]
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field.Tag.Get("yaml")}, node)
+		nodes[path] = node
+	})
+	if err != nil {
+		return "", err
+	}
+
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "********"
+}
+
+// walk calls fn for every leaf field of v with its dotted YAML path, and
+// enter, when given, for every nested struct before its fields.
+func walk(v reflect.Value, prefix string, fn func(reflect.StructField, reflect.Value, string) error, enter ...func(reflect.StructField, string)) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		path := field.Tag.Get("yaml")
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		value := v.Field(i)
+		if value.Kind() == reflect.Struct {
+			for _, e := range enter {
+				e(field, path)
+			}
+			if err := walk(value, path, fn, enter...); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(field, value, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// set parses raw into a leaf field. Lists are comma separated.
+func set(value reflect.Value, raw string) error {
+	switch value.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+	case string:
+		value.SetString(raw)
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	case float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case []string:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		value.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+
+	return nil
+}
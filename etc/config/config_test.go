@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readFile reads the configuration with content as the YAML file.
+func readFile(t *testing.T, content string) *Config {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+
+	cfg, err := Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	return cfg
+}
+
+const validFile = `
+auth:
+  secret_key: secret
+database:
+  user: ssls
+  name: ssls
+`
+
+func TestRead(t *testing.T) {
+	t.Setenv("DB_PORT", "6543")
+	t.Setenv("CORS_ALLOW_ORIGINS", "https://app.sslsgroup.com, https://admin.sslsgroup.com")
+
+	cfg := readFile(t, `
+http:
+  addr: ":9090"
+database:
+  port: 5433
+`)
+
+	if cfg.HTTP.Addr != ":9090" {
+		t.Errorf("got addr %q, want the file to win over the default", cfg.HTTP.Addr)
+	}
+	if cfg.Database.Port != 6543 {
+		t.Errorf("got port %d, want the environment to win over the file", cfg.Database.Port)
+	}
+	if cfg.Database.Host != "db" || cfg.Jobs.LateLoads != time.Minute {
+		t.Errorf("got host %q and late loads every %s, want the defaults", cfg.Database.Host, cfg.Jobs.LateLoads)
+	}
+	if origins := cfg.CORS.AllowOrigins; len(origins) != 2 || origins[1] != "https://admin.sslsgroup.com" {
+		t.Errorf("got origins %q", origins)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(c *Config)
+		wantErr []string
+	}{
+		{
+			name:   "defaults with the required settings",
+			change: func(c *Config) {},
+		},
+		{
+			name:    "no secret key",
+			change:  func(c *Config) { c.Auth.SecretKey = "" },
+			wantErr: []string{"auth.secret_key"},
+		},
+		{
+			name:    "credentials for any origin",
+			change:  func(c *Config) { c.CORS.AllowCredentials = true },
+			wantErr: []string{"cors.allow_credentials"},
+		},
+		{
+			name: "credentials for listed origins",
+			change: func(c *Config) {
+				c.CORS.AllowOrigins = []string{"https://app.sslsgroup.com"}
+				c.CORS.AllowCredentials = true
+			},
+		},
+		{
+			name:    "no origins",
+			change:  func(c *Config) { c.CORS.AllowOrigins = nil },
+			wantErr: []string{"cors.allow_origins"},
+		},
+		{
+			name: "more idle than open connections",
+			change: func(c *Config) {
+				c.Database.MaxOpenConns, c.Database.MaxIdleConns = 5, 10
+			},
+			wantErr: []string{"database.max_idle_conns"},
+		},
+		{
+			name: "unknown log level, format and exporter",
+			change: func(c *Config) {
+				c.Log.Level, c.Log.Format, c.Tracing.Exporter = "loud", "xml", "jaeger"
+			},
+			wantErr: []string{"log.level", "log.format", "tracing.exporter"},
+		},
+		{
+			name:    "job every millisecond",
+			change:  func(c *Config) { c.Jobs.Webhooks = time.Millisecond },
+			wantErr: []string{"jobs.webhooks"},
+		},
+		{
+			name:    "unknown time zone",
+			change:  func(c *Config) { c.Database.TimeZone = "Mars/Olympus" },
+			wantErr: []string{"database.timezone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := readFile(t, validFile)
+			tt.change(cfg)
+
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("got no error, want %v", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %s", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	cfg := readFile(t, validFile)
+	cfg.Database.Password = "hunter2"
+
+	out, err := cfg.Print()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "hunter2") || strings.Contains(out, "secret_key: secret") {
+		t.Errorf("secrets are printed:\n%s", out)
+	}
+	if !strings.Contains(out, "user: ssls") {
+		t.Errorf("settings are missing:\n%s", out)
+	}
+}
@@ -0,0 +1,118 @@
+package eld
+
+import (
+	"backend/etc/Utime"
+	database "backend/st_database"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Clock is what an ELD reports for one of its drivers. Hours are remaining hours.
+type Clock struct {
+	DriverId   string    `json:"driver_id"`
+	DriveLeft  float64   `json:"drive_left"`
+	OnDutyLeft float64   `json:"on_duty_left"`
+	CycleLeft  float64   `json:"cycle_left"`
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+// Adapter reads the current clocks of all drivers from an ELD provider.
+type Adapter interface {
+	Name() string
+	Clocks(ctx context.Context) ([]Clock, error)
+}
+
+// HTTPAdapter reads the clocks as a JSON array of Clock from a single endpoint,
+// usually a small bridge in front of the provider's own API.
+type HTTPAdapter struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func NewHTTPAdapter(url, apiKey string) *HTTPAdapter {
+	return &HTTPAdapter{
+		url:    url,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (a *HTTPAdapter) Name() string { return "http" }
+
+func (a *HTTPAdapter) Clocks(ctx context.Context) ([]Clock, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if a.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("eld responded with %s", resp.Status)
+	}
+
+	var clocks []Clock
+	if err = json.NewDecoder(resp.Body).Decode(&clocks); err != nil {
+		return nil, err
+	}
+
+	return clocks, nil
+}
+
+// Sync copies the clocks of the ELD into the hours of service of the drivers
+// linked to it.
+func Sync(ctx context.Context, store database.IStore, adapter Adapter) error {
+	clocks, err := adapter.Clocks(ctx)
+	if err != nil {
+		return fmt.Errorf("reading clocks from ELD %s: %w", adapter.Name(), err)
+	}
+
+	byDriver := make(map[string]Clock, len(clocks))
+	for _, clock := range clocks {
+		byDriver[clock.DriverId] = clock
+	}
+
+	records, err := store.HOS().GetLinked(ctx)
+	if err != nil {
+		return err
+	}
+
+	var updated int
+	for i := range records {
+		record := &records[i]
+		clock, ok := byDriver[record.ELDDriverId]
+		if !ok {
+			continue
+		}
+
+		record.DriveLeft = clock.DriveLeft
+		record.OnDutyLeft = clock.OnDutyLeft
+		record.CycleLeft = clock.CycleLeft
+		record.ReportedAt = clock.ReportedAt
+		if record.ReportedAt.IsZero() {
+			record.ReportedAt = Utime.Now()
+		}
+
+		if err = store.HOS().UpdateClocks(ctx, record); err != nil {
+			slog.ErrorContext(ctx, "Failed to update hours of service", "driver_id", record.DriverId, "error", err)
+			continue
+		}
+		updated++
+	}
+
+	slog.InfoContext(ctx, "ELD sync completed", "updated", updated, "linked", len(records))
+
+	return nil
+}
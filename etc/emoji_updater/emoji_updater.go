@@ -1,31 +1,18 @@
 package emoji
 
 import (
+	"backend/etc/Utime"
 	database "backend/st_database"
 	"context"
-	"log"
-	"time"
 )
 
-func StartEmojiUpdater(ctx context.Context, store *database.Store) {
-	go func() {
-		ticker := time.NewTicker(3 * time.Minute)
-		defer ticker.Stop()
+// Refresh evaluates the enabled flag rules against every logistic, the same
+// way the board does on the fly, and stores the result.
+func Refresh(ctx context.Context, store database.IStore) error {
+	rules, err := store.FlagRule().GetAll(ctx, true)
+	if err != nil {
+		return err
+	}
 
-		for {
-			select {
-			case <-ctx.Done():
-				log.Println("Stopping emoji updater")
-				return
-			case <-ticker.C:
-				log.Println("Starting emoji update...")
-				err := store.Logistic().Emoji(context.Background())
-				if err != nil {
-					log.Printf("Failed to update emojis: %v", err)
-				} else {
-					log.Println("Emoji update completed successfully")
-				}
-			}
-		}
-	}()
+	return store.Logistic().RefreshFlags(ctx, rules, Utime.Now())
 }
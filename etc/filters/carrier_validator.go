@@ -0,0 +1,21 @@
+package filters
+
+import (
+	"regexp"
+)
+
+var (
+	dotNumber = regexp.MustCompile(`^[1-9]\d{0,7}$`)
+	mcNumber  = regexp.MustCompile(`^(?i:MC-?)?[1-9]\d{0,7}$`)
+)
+
+// ValidateDOT reports whether dot is a USDOT number, up to 8 digits.
+func ValidateDOT(dot string) bool {
+	return dotNumber.MatchString(dot)
+}
+
+// ValidateMC reports whether mc is an MC docket number, with or without the
+// MC prefix.
+func ValidateMC(mc string) bool {
+	return mcNumber.MatchString(mc)
+}
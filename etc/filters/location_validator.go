@@ -0,0 +1,13 @@
+package filters
+
+import (
+	"regexp"
+)
+
+var location = regexp.MustCompile(`^[^,]*[^,\s][^,]*,\s*[A-Za-z]{2}$`)
+
+// ValidateLocation reports whether loc is a location like "Dallas, TX", the
+// form the board splits into the city and the state.
+func ValidateLocation(loc string) bool {
+	return location.MatchString(loc)
+}
@@ -14,7 +14,7 @@ func CountDown(resp *models.GetAllLogisticsResp) {
 			switch logistic.Status {
 			case "READY", "AT HOME", "READY AT HOME", "LET US KNOW":
 				logistic.Countdown = now.Sub(Utime.Parse(logistic.UpdateTime)).String()
-			case "COVERED", "ETA", "ETA WILL BE LATE":
+			case "COVERED", "ETA", "ETA WILL BE LATE", models.LogisticStatusLate:
 				logistic.Countdown = logistic.UpdateTime.Sub(now).String()
 			case "AT PU", "AT DEL", "TRUCK ISSUES":
 				logistic.Countdown = ""
@@ -0,0 +1,16 @@
+package helpers
+
+import "strings"
+
+// NormalizeProvider folds case and whitespace so that "tql", " TQL " and "Tql"
+// all resolve to the same provider. It must stay in sync with the SQL used by
+// the 0002_backfills migration.
+func NormalizeProvider(name string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(name), " "))
+}
+
+// NormalizeCargoID drops whitespace and folds case of a broker load number.
+// It must stay in sync with the SQL used by CargoRepo.FindDuplicates.
+func NormalizeCargoID(cargoID string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(cargoID), ""))
+}
@@ -0,0 +1,435 @@
+package jobs
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/logging"
+	"backend/etc/metrics"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"hash/fnv"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrUnknownJob = errors.New("unknown job")
+	ErrJobRunning = errors.New("job is already running")
+	ErrNotStarted = errors.New("job runner is not running")
+)
+
+const (
+	// leaderLock is held by the one instance that runs the schedule.
+	leaderLock = "jobs:leader"
+	// tick is how often the leader looks for due jobs and the others try to
+	// take over.
+	tick = 5 * time.Second
+	// staleGrace is added to two periods of a job before Health reports it
+	// as not running.
+	staleGrace = time.Minute
+	// runRetention is how long the runs of a job are kept.
+	runRetention = 30 * 24 * time.Hour
+)
+
+// Job is background work the leader runs every Every.
+type Job struct {
+	Name        string
+	Description string
+	Every       time.Duration
+	Run         func(ctx context.Context) error
+}
+
+// Runner runs the registered jobs on a single instance. Instances compete for
+// a Postgres advisory lock and only the one holding it runs the schedule, so
+// adding replicas does not multiply the work. Every job also takes a lock of
+// its own while it runs, which keeps a manual run on any instance from
+// overlapping a scheduled one. Each run is recorded with its status and
+// duration.
+type Runner struct {
+	store    database.IStore
+	jobs     []*Job
+	instance string
+	ctx      context.Context
+	started  time.Time
+	beat     atomic.Int64
+	wg       sync.WaitGroup
+}
+
+func NewRunner(store database.IStore) *Runner {
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "unknown"
+	}
+
+	return &Runner{
+		store:    store,
+		instance: instance,
+		ctx:      context.Background(),
+	}
+}
+
+// Register adds a job. Jobs must be registered before Start.
+func (r *Runner) Register(job Job) {
+	r.jobs = append(r.jobs, &job)
+}
+
+// Start competes for leadership and runs the due jobs until ctx is cancelled.
+func (r *Runner) Start(ctx context.Context) {
+	r.ctx = ctx
+	r.started = Utime.Now()
+	r.beat.Store(time.Now().UnixNano())
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		var (
+			leader *sql.Conn
+			next   map[string]time.Time
+		)
+		defer func() {
+			if leader != nil {
+				unlock(leader, leaderLock)
+			}
+		}()
+
+		for {
+			if leader == nil {
+				leader, next = r.elect(ctx)
+			} else if err := leader.PingContext(ctx); err != nil && ctx.Err() == nil {
+				slog.Warn("Lost job leadership", "error", err)
+				leader.Close()
+				leader = nil
+			}
+
+			if leader != nil {
+				r.schedule(ctx, next)
+			}
+			r.beat.Store(time.Now().UnixNano())
+
+			select {
+			case <-ctx.Done():
+				slog.Info("Stopping job runner")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// elect tries to become the leader. The new leader picks up the schedule
+// where the last runs left it, so a failover neither skips nor repeats a run.
+func (r *Runner) elect(ctx context.Context) (*sql.Conn, map[string]time.Time) {
+	conn, err := tryLock(ctx, r.store, leaderLock)
+	if err != nil {
+		slog.Error("Failed to take job leadership", "error", err)
+		return nil, nil
+	}
+	if conn == nil {
+		return nil, nil
+	}
+	slog.Info("Instance is now running the jobs", "instance", r.instance)
+
+	var (
+		now  = Utime.Now()
+		next = make(map[string]time.Time, len(r.jobs))
+	)
+	for _, job := range r.jobs {
+		next[job.Name] = now
+	}
+
+	runs, err := r.store.Job().GetLastRuns(ctx, r.names())
+	if err != nil {
+		slog.Error("Failed to load the last job runs", "error", err)
+		return conn, next
+	}
+	for _, run := range runs {
+		if job := r.find(run.Job); job != nil {
+			next[job.Name] = Utime.Parse(run.StartedAt).Add(job.Every)
+		}
+	}
+
+	return conn, next
+}
+
+// schedule starts every job whose time has come.
+func (r *Runner) schedule(ctx context.Context, next map[string]time.Time) {
+	now := Utime.Now()
+	for _, job := range r.jobs {
+		if now.Before(next[job.Name]) {
+			continue
+		}
+		next[job.Name] = now.Add(job.Every)
+
+		r.wg.Add(1)
+		go func(job *Job) {
+			defer r.wg.Done()
+
+			run, conn, err := r.begin(ctx, job, models.JobTriggerSchedule, nil)
+			if errors.Is(err, ErrJobRunning) {
+				return
+			} else if err != nil {
+				slog.Error("Failed to start job", "job", job.Name, "error", err)
+				return
+			}
+			r.execute(ctx, job, run, conn)
+		}(job)
+	}
+}
+
+// Trigger starts a job on this instance right away and returns its run
+// without waiting for it to finish.
+func (r *Runner) Trigger(ctx context.Context, name string, by *uuid.UUID) (*models.JobRun, error) {
+	job := r.find(name)
+	if job == nil {
+		return nil, ErrUnknownJob
+	}
+
+	run, conn, err := r.begin(ctx, job, models.JobTriggerManual, by)
+	if err != nil {
+		return nil, err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.execute(r.ctx, job, run, conn)
+	}()
+
+	return run, nil
+}
+
+// Wait blocks until the runner stopped after its context was cancelled and
+// every job it started returned, or until ctx is done.
+func (r *Runner) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Health returns an error when the runner of this instance stopped ticking
+// or a job has not started for two of its periods, whichever instance leads.
+func (r *Runner) Health(ctx context.Context) error {
+	if r.started.IsZero() {
+		return ErrNotStarted
+	}
+	if since := time.Since(time.Unix(0, r.beat.Load())); since > 3*tick {
+		return fmt.Errorf("job runner has not ticked for %s", since.Round(time.Second))
+	}
+
+	runs, err := r.store.Job().GetLastRuns(ctx, r.names())
+	if err != nil {
+		return err
+	}
+
+	last := make(map[string]time.Time, len(runs))
+	for _, run := range runs {
+		last[run.Job] = Utime.Parse(run.StartedAt)
+	}
+
+	var (
+		now   = Utime.Now()
+		stale []string
+	)
+	for _, job := range r.jobs {
+		since := r.started
+		if started, ok := last[job.Name]; ok && started.After(since) {
+			since = started
+		}
+		if now.Sub(since) > 2*job.Every+staleGrace {
+			stale = append(stale, job.Name)
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("jobs not running on schedule: %s", strings.Join(stale, ", "))
+	}
+
+	return nil
+}
+
+// Jobs returns the registered jobs with their latest run.
+func (r *Runner) Jobs(ctx context.Context) ([]models.JobInfo, error) {
+	runs, err := r.store.Job().GetLastRuns(ctx, r.names())
+	if err != nil {
+		return nil, err
+	}
+
+	last := make(map[string]models.JobRun, len(runs))
+	for _, run := range runs {
+		last[run.Job] = run
+	}
+
+	infos := make([]models.JobInfo, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		info := models.JobInfo{
+			Name:        job.Name,
+			Description: job.Description,
+			Every:       job.Every.String(),
+		}
+		if run, ok := last[job.Name]; ok {
+			info.LastRun = &run
+		}
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+func (r *Runner) names() []string {
+	names := make([]string, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		names = append(names, job.Name)
+	}
+
+	return names
+}
+
+func (r *Runner) find(name string) *Job {
+	for _, job := range r.jobs {
+		if job.Name == name {
+			return job
+		}
+	}
+
+	return nil
+}
+
+// begin takes the lock of the job and records the run as started.
+func (r *Runner) begin(ctx context.Context, job *Job, trigger string, by *uuid.UUID) (*models.JobRun, *sql.Conn, error) {
+	conn, err := tryLock(ctx, r.store, "job:"+job.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if conn == nil {
+		return nil, nil, ErrJobRunning
+	}
+
+	run := &models.JobRun{
+		Job:        job.Name,
+		Trigger:    trigger,
+		Status:     models.JobRunRunning,
+		Instance:   r.instance,
+		EmployeeId: by,
+		StartedAt:  Utime.Now(),
+	}
+	if err = r.store.Job().CreateRun(ctx, run); err != nil {
+		unlock(conn, "job:"+job.Name)
+		return nil, nil, err
+	}
+
+	return run, conn, nil
+}
+
+// execute runs the job, records how it went and releases its lock.
+func (r *Runner) execute(ctx context.Context, job *Job, run *models.JobRun, conn *sql.Conn) {
+	defer unlock(conn, "job:"+job.Name)
+
+	ctx = logging.WithJob(ctx, job.Name)
+	ctx, span := otel.Tracer("backend/etc/jobs").Start(ctx, "job "+job.Name,
+		trace.WithNewRoot(), trace.WithAttributes(attribute.String("job.trigger", run.Trigger)))
+	defer span.End()
+
+	started := time.Now()
+	err := safeRun(ctx, job)
+
+	finished := Utime.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = time.Since(started).Milliseconds()
+	run.Status = models.JobRunSucceeded
+	if err != nil {
+		run.Status = models.JobRunFailed
+		run.Error = err.Error()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		slog.ErrorContext(ctx, "Job failed", "run_id", run.Id, "duration_ms", run.DurationMs, "error", err)
+	}
+
+	metrics.ObserveJob(job.Name, run.Status, time.Since(started))
+
+	// The run is recorded even when ctx was cancelled during shutdown.
+	if err = r.store.Job().FinishRun(context.Background(), run); err != nil {
+		slog.ErrorContext(ctx, "Failed to record job run", "run_id", run.Id, "error", err)
+	}
+
+	if _, err = r.store.Job().DeleteRuns(context.Background(), job.Name, finished.Add(-runRetention)); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete old job runs", "error", err)
+	}
+}
+
+func safeRun(ctx context.Context, job *Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return job.Run(ctx)
+}
+
+// tryLock takes the session advisory lock of name on a connection of its own
+// and returns that connection, or nil when another session holds the lock.
+// The lock lives as long as the session, so it is released if the process
+// dies.
+func tryLock(ctx context.Context, store database.IStore, name string) (*sql.Conn, error) {
+	sqlDB, err := store.DB().DB()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockKey(name)).Scan(&locked)
+	if err != nil || !locked {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// unlock releases the lock and hands the connection back to the pool. If the
+// lock can't be released the connection is thrown away instead, which ends
+// the session and the lock with it.
+func unlock(conn *sql.Conn, name string) {
+	_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey(name))
+	if err != nil {
+		conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	conn.Close()
+}
+
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+
+	return int64(h.Sum64())
+}
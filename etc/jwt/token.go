@@ -4,11 +4,23 @@ import (
 	"backend/etc/Utime"
 	"errors"
 	"github.com/golang-jwt/jwt/v4"
-	"os"
 	"time"
 )
 
-var JwtSecret = []byte(os.Getenv("SECRET_KEY"))
+var (
+	JwtSecret []byte
+	TokenTTL  = 24 * 7 * time.Hour
+)
+
+// ErrNoSecret is returned while Setup was not called with a key, so that no
+// token is ever signed with an empty one.
+var ErrNoSecret = errors.New("jwt secret is not set")
+
+// Setup sets the key tokens are signed with and how long they are valid.
+func Setup(secret string, ttl time.Duration) {
+	JwtSecret = []byte(secret)
+	TokenTTL = ttl
+}
 
 type Claims struct {
 	UserID      string `json:"user_id"`
@@ -18,7 +30,11 @@ type Claims struct {
 }
 
 func GenerateToken(userID string, username string, accessLevel int) (string, error) {
-	expirationTime := Utime.Now().Add(24 * 7 * time.Hour)
+	if len(JwtSecret) == 0 {
+		return "", ErrNoSecret
+	}
+
+	expirationTime := Utime.Now().Add(TokenTTL)
 	claims := &Claims{
 		UserID:      userID,
 		AccessLevel: accessLevel,
@@ -37,6 +53,10 @@ func GenerateToken(userID string, username string, accessLevel int) (string, err
 }
 
 func ParseToken(tokenString string) (*Claims, error) {
+	if len(JwtSecret) == 0 {
+		return nil, ErrNoSecret
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		return JwtSecret, nil
 	})
@@ -0,0 +1,92 @@
+package late
+
+import (
+	"backend/etc/Utime"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// Escalate moves every ETA logistic whose st_time passed to the late status.
+// Each one gets a history entry by the system employee and, when a load is
+// attached, a draft performance incident for the dispatcher who booked it to
+// complete, or for the system employee when nobody is recorded as having
+// booked it. The dispatcher is notified by the LATE_LOAD alert rule.
+func Escalate(ctx context.Context, store database.IStore) error {
+	now := Utime.Now()
+	logistics, err := store.Logistic().GetOverdue(ctx, models.LogisticStatusETA, now)
+	if err != nil {
+		return err
+	}
+
+	var escalated int
+	for i := range logistics {
+		logistic := &logistics[i]
+
+		var moved bool
+		err = store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var errC error
+			moved, errC = store.Logistic().ChangeStatus(ctx, logistic.Id, models.LogisticStatusETA, models.LogisticStatusLate, tx)
+			if errC != nil || !moved {
+				return errC
+			}
+
+			from := models.JSONBLogistic{
+				Post:       logistic.Post,
+				Status:     logistic.Status,
+				UpdateTime: logistic.UpdateTime,
+				StTime:     logistic.StTime,
+				State:      logistic.State,
+				Location:   logistic.Location,
+				Notion:     logistic.Notion,
+			}
+			to := from
+			to.Status = models.LogisticStatusLate
+
+			_, errH := store.History().Create(ctx, &models.History{
+				DriverName:   logistic.Driver.FullName(),
+				LogisticId:   logistic.Id,
+				FromLogistic: from,
+				ToLogistic:   to,
+				EmployeeId:   models.SystemEmployeeId,
+			}, tx)
+			if errH != nil {
+				return errH
+			}
+
+			if logistic.CargoId == nil {
+				return nil
+			}
+
+			employeeId := logistic.Cargo.EmployeeId
+			if employeeId == uuid.Nil {
+				employeeId = models.SystemEmployeeId
+			}
+
+			_, errP := store.Performance().Create(ctx, &models.Performance{
+				Reason:     fmt.Sprintf("ETA %s passed", Utime.Parse(*logistic.StTime).Format("2006-01-02 15:04")),
+				Section:    "Late",
+				EmployeeId: employeeId,
+				CompanyId:  logistic.Driver.CompanyId,
+				LoadId:     logistic.Cargo.CargoID,
+				Draft:      true,
+			}, tx)
+			return errP
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to escalate late logistic", "logistic_id", logistic.Id, "error", err)
+			continue
+		}
+		if moved {
+			escalated++
+		}
+	}
+
+	slog.InfoContext(ctx, "Late watcher completed", "escalated", escalated, "overdue", len(logistics))
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package late
+
+import (
+	"backend/models"
+	database "backend/st_database"
+	"backend/st_database/storage"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"testing"
+	"time"
+)
+
+// fakeConn is a database connection that only opens and closes transactions,
+// so the watcher can run its transaction around the fake repositories.
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return fakeConn{}, nil }
+func (fakeConn) Commit() error             { return nil }
+func (fakeConn) Rollback() error           { return nil }
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return fakeConnector{} }
+func (fakeConnector) Open(string) (driver.Conn, error)             { return fakeConn{}, nil }
+
+// fakeStore serves the overdue logistics from memory and keeps the history
+// entries and performances the watcher writes. Everything else panics if used.
+type fakeStore struct {
+	database.IStore
+	db           *gorm.DB
+	logistics    *fakeLogistics
+	history      *fakeHistory
+	performances *fakePerformances
+}
+
+func (s *fakeStore) DB() *gorm.DB                     { return s.db }
+func (s *fakeStore) Logistic() storage.Logistic       { return s.logistics }
+func (s *fakeStore) History() storage.History         { return s.history }
+func (s *fakeStore) Performance() storage.Performance { return s.performances }
+
+type fakeLogistics struct {
+	storage.Logistic
+	overdue []models.Logistic
+	moved   []uuid.UUID
+}
+
+func (f *fakeLogistics) GetOverdue(context.Context, string, time.Time) ([]models.Logistic, error) {
+	return f.overdue, nil
+}
+
+func (f *fakeLogistics) ChangeStatus(_ context.Context, id uuid.UUID, _, _ string, _ ...*gorm.DB) (bool, error) {
+	f.moved = append(f.moved, id)
+	return true, nil
+}
+
+type fakeHistory struct {
+	storage.History
+	entries []models.History
+}
+
+func (f *fakeHistory) Create(_ context.Context, history *models.History, _ ...*gorm.DB) (string, error) {
+	f.entries = append(f.entries, *history)
+	return "", nil
+}
+
+type fakePerformances struct {
+	storage.Performance
+	created []models.Performance
+}
+
+func (f *fakePerformances) Create(_ context.Context, performance *models.Performance, _ ...*gorm.DB) (string, error) {
+	f.created = append(f.created, *performance)
+	return "", nil
+}
+
+func newFakeStore(t *testing.T, overdue ...models.Logistic) *fakeStore {
+	t.Helper()
+
+	sqlDB := sql.OpenDB(fakeConnector{})
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &fakeStore{
+		db:           db,
+		logistics:    &fakeLogistics{overdue: overdue},
+		history:      &fakeHistory{},
+		performances: &fakePerformances{},
+	}
+}
+
+func TestEscalate(t *testing.T) {
+	var (
+		stTime     = time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+		cargoId    = uuid.New()
+		employeeId = uuid.New()
+	)
+
+	tests := []struct {
+		name           string
+		cargo          *models.Cargo
+		wantEmployeeId *uuid.UUID
+	}{
+		{
+			name:           "incident for the dispatcher who booked the load",
+			cargo:          &models.Cargo{Id: cargoId, CargoID: "L-1", EmployeeId: employeeId},
+			wantEmployeeId: &employeeId,
+		},
+		{
+			name:           "incident for the system employee when nobody booked the load",
+			cargo:          &models.Cargo{Id: cargoId, CargoID: "L-1"},
+			wantEmployeeId: &models.SystemEmployeeId,
+		},
+		{
+			name: "no incident without a load",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logistic := models.Logistic{
+				Id:     uuid.New(),
+				Status: models.LogisticStatusETA,
+				StTime: &stTime,
+				Driver: models.Driver{Name: "John", Surname: "Smith", CompanyId: uuid.New()},
+			}
+			if tt.cargo != nil {
+				logistic.CargoId, logistic.Cargo = &tt.cargo.Id, *tt.cargo
+			}
+			store := newFakeStore(t, logistic)
+
+			if err := Escalate(context.Background(), store); err != nil {
+				t.Fatalf("Escalate: %v", err)
+			}
+
+			if len(store.logistics.moved) != 1 || store.logistics.moved[0] != logistic.Id {
+				t.Errorf("got moved %v, want the overdue logistic", store.logistics.moved)
+			}
+
+			if len(store.history.entries) != 1 {
+				t.Fatalf("got %d history entries, want 1", len(store.history.entries))
+			}
+			entry := store.history.entries[0]
+			if entry.DriverName != "John Smith" || entry.EmployeeId != models.SystemEmployeeId {
+				t.Errorf("got history by %s for %q", entry.EmployeeId, entry.DriverName)
+			}
+			if entry.FromLogistic.Status != models.LogisticStatusETA || entry.ToLogistic.Status != models.LogisticStatusLate {
+				t.Errorf("got history from %q to %q", entry.FromLogistic.Status, entry.ToLogistic.Status)
+			}
+
+			created := store.performances.created
+			if tt.wantEmployeeId == nil {
+				if len(created) != 0 {
+					t.Errorf("got %d incidents for a logistic without a load", len(created))
+				}
+				return
+			}
+			if len(created) != 1 {
+				t.Fatalf("got %d incidents, want 1", len(created))
+			}
+			if created[0].EmployeeId != *tt.wantEmployeeId || !created[0].Draft || created[0].LoadId != "L-1" {
+				t.Errorf("got incident %+v, want a draft for %s", created[0], *tt.wantEmployeeId)
+			}
+		})
+	}
+}
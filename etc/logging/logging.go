@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"backend/etc/config"
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"os"
+)
+
+type fieldsKey struct{}
+
+// fields are what every line logged with a context carries. The request
+// middleware attaches them and the auth middleware fills in the actor once the
+// token is parsed, so the access line logged after the handler has both.
+type fields struct {
+	requestID   string
+	userID      string
+	accessLevel int
+	job         string
+}
+
+// Setup makes slog's default logger, and through it the standard log
+// package, write cfg.Format lines at cfg.Level and above.
+func Setup(cfg config.LogConfig) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return fmt.Errorf("log level %q: %w", cfg.Level, err)
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case "json":
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	case "text":
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	default:
+		return fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	slog.SetDefault(slog.New(&contextHandler{Handler: handler}))
+
+	return nil
+}
+
+// WithRequest returns a context whose log lines carry requestID.
+func WithRequest(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, &fields{requestID: requestID})
+}
+
+// WithJob returns a context whose log lines carry the name of a background
+// job.
+func WithJob(ctx context.Context, job string) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, &fields{job: job})
+}
+
+// SetActor adds the authenticated employee to the lines logged with ctx and
+// every context derived from it. It does nothing outside of a request.
+func SetActor(ctx context.Context, userID string, accessLevel int) {
+	if f, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		f.userID = userID
+		f.accessLevel = accessLevel
+	}
+}
+
+// RequestID returns the id of the request ctx belongs to, if any.
+func RequestID(ctx context.Context) string {
+	if f, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		return f.requestID
+	}
+	return ""
+}
+
+// contextHandler adds the fields and the trace of the context to every record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if f, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		if f.requestID != "" {
+			record.AddAttrs(slog.String("request_id", f.requestID))
+		}
+		if f.userID != "" {
+			record.AddAttrs(slog.String("user_id", f.userID), slog.Int("access_level", f.accessLevel))
+		}
+		if f.job != "" {
+			record.AddAttrs(slog.String("job", f.job))
+		}
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
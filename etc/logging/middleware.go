@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader carries the request id in both directions. A client or proxy
+// may send one, otherwise it is generated.
+const RequestIDHeader = "X-Request-ID"
+
+// quietRoutes are polled by probes and scrapers and only logged at debug.
+var quietRoutes = map[string]bool{"/healthz": true, "/readyz": true, "/metrics": true}
+
+// Middleware gives every request an id, returns it in RequestIDHeader and logs
+// one line per request once it is served.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Set("request_id", requestID)
+
+		ctx := WithRequest(c.Request.Context(), requestID)
+		c.Request = c.Request.WithContext(ctx)
+
+		started := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case quietRoutes[c.FullPath()]:
+			level = slog.LevelDebug
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Int64("duration_ms", time.Since(started).Milliseconds()),
+			slog.Int("size", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		slog.LogAttrs(ctx, level, "Request served", attrs...)
+	}
+}
+
+// Recovery answers 500 to a request whose handler panicked and logs the panic
+// with the request id.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		slog.ErrorContext(c.Request.Context(), "Handler panicked", "error", fmt.Sprint(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"backend/models"
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"time"
+)
+
+// boardTimeout bounds the overview query a scrape runs.
+const boardTimeout = 5 * time.Second
+
+var (
+	boardDrivers = prometheus.NewDesc(
+		"board_drivers",
+		"Drivers on the board by company and state, as counted by the overview.",
+		[]string{"company_id", "company", "state"}, nil,
+	)
+	boardOverdue = prometheus.NewDesc(
+		"board_overdue_loads",
+		"Loads in an ETA status whose st_time passed, by company.",
+		[]string{"company_id", "company"}, nil,
+	)
+)
+
+// BoardCollector exports the counts of the logistics overview on every
+// scrape, so the gauges always agree with what the board shows.
+type BoardCollector struct {
+	overview func(ctx context.Context) (models.GetOverview, error)
+}
+
+func NewBoardCollector(overview func(ctx context.Context) (models.GetOverview, error)) *BoardCollector {
+	return &BoardCollector{overview: overview}
+}
+
+func (b *BoardCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- boardDrivers
+	ch <- boardOverdue
+}
+
+func (b *BoardCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), boardTimeout)
+	defer cancel()
+
+	overview, err := b.overview(ctx)
+	if err != nil {
+		slog.Error("Failed to collect board metrics", "error", err)
+		return
+	}
+
+	// Company names are not unique, so the series are told apart by the id and
+	// the name only labels them.
+	for _, company := range overview.Companies {
+		id := company.Id.String()
+
+		for state, count := range map[string]int64{
+			"free":         company.FreeDrivers,
+			"will_be_soon": company.WillBeSoonDrivers,
+			"occupied":     company.OccupiedDrivers,
+			"not_working":  company.NotWorking,
+		} {
+			ch <- prometheus.MustNewConstMetric(boardDrivers, prometheus.GaugeValue, float64(count), id, company.Name, state)
+		}
+		ch <- prometheus.MustNewConstMetric(boardOverdue, prometheus.GaugeValue, float64(company.Overdue), id, company.Name)
+	}
+}
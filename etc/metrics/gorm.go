@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+	"time"
+)
+
+const startedKey = "metrics:started"
+
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency by operation and table.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "table"})
+
+	queryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Failed database queries by operation and table. Missing records are not failures.",
+	}, []string{"operation", "table"})
+)
+
+// GormPlugin times every query gorm runs.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return "metrics" }
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	c := db.Callback()
+
+	return errors.Join(
+		c.Create().Before("gorm:create").Register("metrics:before_create", before),
+		c.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		c.Query().Before("gorm:query").Register("metrics:before_query", before),
+		c.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		c.Update().Before("gorm:update").Register("metrics:before_update", before),
+		c.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		c.Delete().Before("gorm:delete").Register("metrics:before_delete", before),
+		c.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		c.Row().Before("gorm:row").Register("metrics:before_row", before),
+		c.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		c.Raw().Before("gorm:raw").Register("metrics:before_raw", before),
+		c.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	)
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(startedKey, time.Now())
+}
+
+func after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startedKey)
+		if !ok {
+			return
+		}
+		started, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "none"
+		}
+
+		queryDuration.WithLabelValues(operation, table).Observe(time.Since(started).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			queryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package pdf writes simple text-only PDF documents (US Letter, Courier) without
+// any external dependency. It is enough for invoices and statements.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 612.0
+	pageHeight = 792.0
+	margin     = 50.0
+)
+
+type line struct {
+	x, y float64
+	size float64
+	bold bool
+	text string
+}
+
+type Document struct {
+	pages [][]line
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, nil)
+	d.y = pageHeight - margin
+}
+
+// Text writes a line at the left margin and moves the cursor below it, starting
+// a new page when the current one is full.
+func (d *Document) Text(size float64, bold bool, text string) {
+	d.TextAt(0, size, bold, text)
+	d.y -= size * 1.4
+}
+
+// TextAt writes text on the current line at offset x from the left margin
+// without moving the cursor, so several columns can share a line.
+func (d *Document) TextAt(x, size float64, bold bool, text string) {
+	if d.y-size < margin {
+		d.newPage()
+	}
+	last := len(d.pages) - 1
+	d.pages[last] = append(d.pages[last], line{x: margin + x, y: d.y - size, size: size, bold: bold, text: text})
+}
+
+// Break ends a line written with TextAt.
+func (d *Document) Break(size float64) {
+	d.y -= size * 1.4
+}
+
+// Gap moves the cursor down by h points.
+func (d *Document) Gap(h float64) {
+	d.y -= h
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() []byte {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4 are fixed; every page then takes a page and a content object.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		var content bytes.Buffer
+		for _, l := range page {
+			font := "F1"
+			if l.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, l.size, l.x, l.y, escape(l.text))
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// escape quotes PDF string delimiters and replaces characters the standard
+// fonts cannot show.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
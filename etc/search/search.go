@@ -43,6 +43,11 @@ func LoadLocations(filePath string) error {
 	return nil
 }
 
+// Loaded reports whether LoadLocations has succeeded.
+func Loaded() bool {
+	return model != nil
+}
+
 func GetLocations(query string) ([]Location, error) {
 	if query == "" {
 		return nil, errors.New("запрос не должен быть пустым")
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+// querySpan is the span of a running query and the context it was started
+// from. A query chain like Count then Find shares one statement, so the
+// context is restored once the query ends for the next one to start from the
+// same parent.
+type querySpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+// GormPlugin starts a client span for every query gorm runs, as a child of the
+// span in the context the query was given.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return "tracing" }
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	c := db.Callback()
+
+	return errors.Join(
+		c.Create().Before("gorm:create").Register("tracing:before_create", before("create")),
+		c.Create().After("gorm:create").Register("tracing:after_create", after),
+		c.Query().Before("gorm:query").Register("tracing:before_query", before("query")),
+		c.Query().After("gorm:query").Register("tracing:after_query", after),
+		c.Update().Before("gorm:update").Register("tracing:before_update", before("update")),
+		c.Update().After("gorm:update").Register("tracing:after_update", after),
+		c.Delete().Before("gorm:delete").Register("tracing:before_delete", before("delete")),
+		c.Delete().After("gorm:delete").Register("tracing:after_delete", after),
+		c.Row().Before("gorm:row").Register("tracing:before_row", before("row")),
+		c.Row().After("gorm:row").Register("tracing:after_row", after),
+		c.Raw().Before("gorm:raw").Register("tracing:before_raw", before("raw")),
+		c.Raw().After("gorm:raw").Register("tracing:after_raw", after),
+	)
+}
+
+func before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		spanName := "gorm." + operation
+		if db.Statement.Table != "" {
+			spanName += " " + db.Statement.Table
+		}
+
+		parent := db.Statement.Context
+		ctx, span := tracer().Start(parent, spanName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, querySpan{span: span, parent: parent})
+	}
+}
+
+func after(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	qs, ok := value.(querySpan)
+	if !ok {
+		return
+	}
+	db.Statement.Context = qs.parent
+
+	span := qs.span
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBCollectionName(db.Statement.Table),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"backend/etc/config"
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// name is the instrumentation scope of the spans started in this package.
+const name = "backend/etc/tracing"
+
+// Setup installs the global tracer provider for cfg and returns the function
+// that flushes the spans still buffered, to be called on shutdown. With the
+// none exporter spans are still created, so trace ids reach the logs, but
+// nothing is exported.
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case "stdout":
+		exporter, err = stdouttrace.New()
+	case "otlp":
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	return install(sdktrace.NewTracerProvider(opts...)), nil
+}
+
+// SetupWithExporter installs a tracer provider that hands every span to
+// exporter as soon as it ends, like the in-memory exporter of the sdk's
+// tracetest package, and samples all of them.
+func SetupWithExporter(exporter sdktrace.SpanExporter) func(context.Context) error {
+	return install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+}
+
+func install(provider *sdktrace.TracerProvider) func(context.Context) error {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(name)
+}
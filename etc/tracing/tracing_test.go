@@ -0,0 +1,216 @@
+package tracing_test
+
+import (
+	"backend/etc/tracing"
+	"backend/models"
+	"backend/service/services"
+	database "backend/st_database"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// exporter collects the spans of every test. The tracer of the services
+// package is bound to the first provider installed, so it is set up once.
+var exporter = tracetest.NewInMemoryExporter()
+
+func TestMain(m *testing.M) {
+	shutdown := tracing.SetupWithExporter(exporter)
+	code := m.Run()
+	_ = shutdown(context.Background())
+	os.Exit(code)
+}
+
+// fakeConn is a database connection that answers every query with no rows,
+// or with err when it is set.
+type fakeConn struct {
+	err error
+}
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c fakeConn) Close() error              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error) { return c, nil }
+func (c fakeConn) Commit() error             { return nil }
+func (c fakeConn) Rollback() error           { return nil }
+
+func (c fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return noRows{}, nil
+}
+
+func (c fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+type noRows struct{}
+
+func (noRows) Columns() []string         { return nil }
+func (noRows) Close() error              { return nil }
+func (noRows) Next([]driver.Value) error { return io.EOF }
+
+type fakeConnector struct {
+	conn fakeConn
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return c }
+func (c fakeConnector) Open(string) (driver.Conn, error)             { return c.conn, nil }
+
+// newRouter serves the update of a logistic with its cargo from a database
+// whose queries fail with queryErr, when it is not nil.
+func newRouter(t *testing.T, queryErr error) *gin.Engine {
+	t.Helper()
+
+	sqlDB := sql.OpenDB(fakeConnector{conn: fakeConn{err: queryErr}})
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Use(tracing.GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	service := services.NewLogisticService(database.New(db))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(tracing.Middleware())
+	r.PUT("/v1/logistics/:id", func(c *gin.Context) {
+		var (
+			logistic = models.Logistic{Id: uuid.MustParse(c.Param("id"))}
+			cargo    = models.Cargo{Id: uuid.New()}
+		)
+		_, err := service.UpdateWithCargo(c.Request.Context(), &logistic, &cargo, models.UpdateWithCargoOptions{}, models.RequestId{Id: uuid.New()})
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case err != nil:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		default:
+			c.Status(http.StatusOK)
+		}
+	})
+
+	return r
+}
+
+// updateLogistic sends the update through the router and returns the spans it
+// produced.
+func updateLogistic(t *testing.T, r *gin.Engine, wantStatus int) tracetest.SpanStubs {
+	t.Helper()
+	exporter.Reset()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/logistics/"+uuid.NewString(), nil))
+	if w.Code != wantStatus {
+		t.Fatalf("got status %d, want %d: %s", w.Code, wantStatus, w.Body)
+	}
+
+	return exporter.GetSpans()
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, prefix string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if strings.HasPrefix(span.Name, prefix) {
+			return span
+		}
+	}
+
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	t.Fatalf("no span %q among %v", prefix, names)
+	return tracetest.SpanStub{}
+}
+
+func attribute(span tracetest.SpanStub, key string) (string, bool) {
+	for _, kv := range span.Attributes {
+		if string(kv.Key) == key {
+			return kv.Value.Emit(), true
+		}
+	}
+	return "", false
+}
+
+func TestSpansFromRequestToQueries(t *testing.T) {
+	spans := updateLogistic(t, newRouter(t, nil), http.StatusNotFound)
+
+	var (
+		request = findSpan(t, spans, "PUT /v1/logistics/:id")
+		service = findSpan(t, spans, "LogisticService.UpdateWithCargo")
+		query   = findSpan(t, spans, "gorm.query logistics")
+	)
+	if request.SpanKind != trace.SpanKindServer || request.Parent.IsValid() {
+		t.Errorf("request span is not a root server span")
+	}
+	if service.Parent.SpanID() != request.SpanContext.SpanID() {
+		t.Errorf("service span is not a child of the request span")
+	}
+	if query.SpanKind != trace.SpanKindClient || query.Parent.SpanID() != service.SpanContext.SpanID() {
+		t.Errorf("query span is not a client child of the service span")
+	}
+	for _, span := range spans {
+		if span.SpanContext.TraceID() != request.SpanContext.TraceID() {
+			t.Errorf("span %q is in another trace", span.Name)
+		}
+	}
+
+	text, ok := attribute(query, string(semconv.DBQueryTextKey))
+	if !ok || !strings.Contains(text, `FROM "logistics"`) || !strings.Contains(text, "FOR UPDATE") {
+		t.Errorf("got query text %q, want the locking select of the logistic", text)
+	}
+	if query.Status.Code == codes.Error {
+		t.Errorf("a query that found nothing is marked failed")
+	}
+	if status, _ := attribute(request, string(semconv.HTTPResponseStatusCodeKey)); status != "404" {
+		t.Errorf("got response status %q on the request span", status)
+	}
+}
+
+func TestFailedQuerySpan(t *testing.T) {
+	queryErr := errors.New(`relation "logistics" does not exist`)
+	spans := updateLogistic(t, newRouter(t, queryErr), http.StatusInternalServerError)
+
+	query := findSpan(t, spans, "gorm.query logistics")
+	if query.Status.Code != codes.Error || query.Status.Description != queryErr.Error() {
+		t.Errorf("got query status %v %q, want the error", query.Status.Code, query.Status.Description)
+	}
+	if len(query.Events) == 0 || query.Events[0].Name != "exception" {
+		t.Errorf("error was not recorded on the query span")
+	}
+	if _, ok := attribute(query, string(semconv.DBQueryTextKey)); !ok {
+		t.Errorf("failed query has no query text")
+	}
+
+	if request := findSpan(t, spans, "PUT /v1/logistics/:id"); request.Status.Code != codes.Error {
+		t.Errorf("request span of a 500 is not marked failed")
+	}
+}
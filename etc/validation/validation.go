@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"backend/etc/apperr"
+	"backend/etc/filters"
+	"backend/models"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts of the dates and times in request bodies and queries.
+const (
+	DateLayout     = "2006-01-02"
+	DateTimeLayout = "2006-01-02T15:04:05"
+)
+
+// Setup registers the custom rules on the validator gin checks the binding
+// tags of request bodies with, and makes it name fields by their json keys.
+func Setup() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("gin does not validate with go-playground/validator")
+	}
+
+	v.RegisterTagNameFunc(jsonName)
+
+	return errors.Join(
+		v.RegisterValidation("phone", str(filters.ValidatePhoneNumber)),
+		v.RegisterValidation("dot", number(filters.ValidateDOT)),
+		v.RegisterValidation("mc", number(filters.ValidateMC)),
+		v.RegisterValidation("location", str(filters.ValidateLocation)),
+		v.RegisterValidation("logistic_status", str(func(s string) bool { return slices.Contains(models.LogisticStatuses, s) })),
+		v.RegisterValidation("date", layout(DateLayout)),
+		v.RegisterValidation("datetime", layout(DateTimeLayout)),
+	)
+}
+
+// Fields returns every failed check of err, or nil when err is not a
+// validation failure.
+func Fields(err error) []apperr.FieldError {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+
+	fields := make([]apperr.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		// The namespace starts with the name of the request type.
+		field := fe.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+
+		fields = append(fields, apperr.FieldError{Field: field, Rule: fe.Tag(), Message: message(fe)})
+	}
+
+	return fields
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "uuid":
+		return "must be a UUID"
+	case "email":
+		return "must be an email address"
+	case "url":
+		return "must be a URL"
+	case "phone":
+		return "must be a phone number like +15551234567"
+	case "dot":
+		return "must be a USDOT number"
+	case "mc":
+		return "must be an MC number"
+	case "location":
+		return `must be a location like "Dallas, TX"`
+	case "logistic_status":
+		// Statuses can hold commas, so they are quoted.
+		return fmt.Sprintf("must be one of %q", models.LogisticStatuses)
+	case "date":
+		return "must be a date like " + DateLayout
+	case "datetime":
+		if fe.Param() != "" {
+			return "must be a date and time like " + fe.Param()
+		}
+		return "must be a date and time like " + DateTimeLayout
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte", "min":
+		return "must be at least " + fe.Param()
+	case "lte", "max":
+		return "must be at most " + fe.Param()
+	case "len":
+		return "must be " + fe.Param() + " long"
+	case "alpha":
+		return "must be letters only"
+	case "unique":
+		return "must not contain duplicates"
+	case "dive":
+		return "is invalid"
+	default:
+		return fmt.Sprintf("failed the %s check", fe.Tag())
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func str(valid func(string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return fl.Field().Kind() == reflect.String && valid(fl.Field().String())
+	}
+}
+
+// number checks numbers kept as strings as well as integers.
+func number(valid func(string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		switch field.Kind() {
+		case reflect.String:
+			return valid(field.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return valid(strconv.FormatInt(field.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return valid(strconv.FormatUint(field.Uint(), 10))
+		default:
+			return false
+		}
+	}
+}
+
+// layout checks a date or time against the layout given as the parameter of
+// the tag, or against def.
+func layout(def string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		if fl.Field().Kind() != reflect.String {
+			return false
+		}
+
+		l := fl.Param()
+		if l == "" {
+			l = def
+		}
+		_, err := time.Parse(l, fl.Field().String())
+		return err == nil
+	}
+}
@@ -0,0 +1,212 @@
+package webhooks
+
+import (
+	"backend/etc/Utime"
+	"backend/models"
+	database "backend/st_database"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxAttempts is how many times a delivery is tried before it fails for good.
+	maxAttempts = 8
+	// baseBackoff is the wait after the first failed attempt, doubled after
+	// every further one up to maxBackoff.
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+	// lease is how long a claimed delivery is hidden from other dispatchers.
+	lease = 2 * time.Minute
+	batch = 50
+)
+
+// Sign returns the HMAC-SHA256 signature of a request body sent at timestamp,
+// hex encoded. Receivers compute it over "<timestamp>.<body>" with their
+// secret and compare it with the X-Webhook-Signature header.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher fans the events of the outbox out to the subscriptions and sends
+// the pending deliveries, retrying failed ones with exponential backoff.
+type Dispatcher struct {
+	store  database.IStore
+	client *http.Client
+}
+
+func NewDispatcher(store database.IStore) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Run fans out the new events and sends the deliveries that are due once.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if err := d.fanOut(ctx); err != nil {
+		return err
+	}
+
+	deliveries, err := d.store.Webhook().ClaimDeliveries(ctx, Utime.Now(), lease, batch)
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		d.deliver(ctx, &deliveries[i])
+		if err = d.store.Webhook().SaveDelivery(ctx, &deliveries[i]); err != nil {
+			slog.ErrorContext(ctx, "Failed to save webhook delivery", "delivery_id", deliveries[i].Id, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) fanOut(ctx context.Context) error {
+	return d.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		events, err := d.store.Webhook().GetUndispatchedEvents(ctx, batch, tx)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		subscriptions, err := d.store.Webhook().GetSubscriptions(ctx, true, tx)
+		if err != nil {
+			return err
+		}
+
+		var (
+			now        = Utime.Now()
+			ids        = make([]uuid.UUID, 0, len(events))
+			deliveries []models.WebhookDelivery
+		)
+		for i := range events {
+			ids = append(ids, events[i].Id)
+			for j := range subscriptions {
+				if !subscriptions[j].Wants(&events[i]) {
+					continue
+				}
+				deliveries = append(deliveries, models.WebhookDelivery{
+					EventId:        events[i].Id,
+					SubscriptionId: subscriptions[j].Id,
+					Status:         models.DeliveryStatusPending,
+					NextAttemptAt:  now,
+				})
+			}
+		}
+
+		if err = d.store.Webhook().CreateDeliveries(ctx, deliveries, tx); err != nil {
+			return err
+		}
+
+		return d.store.Webhook().MarkDispatched(ctx, ids, now, tx)
+	})
+}
+
+type body struct {
+	Id         uuid.UUID           `json:"id"`
+	Type       string              `json:"type"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	CompanyId  uuid.UUID           `json:"company_id"`
+	Data       models.EventPayload `json:"data"`
+}
+
+// deliver makes one attempt and records its outcome on the delivery.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	now := Utime.Now()
+	delivery.Attempts++
+
+	subscription := delivery.Subscription
+	if subscription.DeletedAt.Valid || !subscription.Active {
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.LastError = "subscription is inactive"
+		return
+	}
+
+	statusCode, err := d.send(ctx, delivery)
+	delivery.LastStatusCode = statusCode
+	if err == nil {
+		delivery.Status = models.DeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if len(delivery.LastError) > 255 {
+		delivery.LastError = delivery.LastError[:255]
+	}
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = models.DeliveryStatusFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	event := delivery.Event
+	payload, err := json.Marshal(body{
+		Id:         event.Id,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		CompanyId:  event.CompanyId,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", delivery.Id.String())
+	req.Header.Set("X-Webhook-Event", event.Type)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", Sign(delivery.Subscription.Secret, timestamp, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff is the wait before the next attempt after the given number of
+// failed ones.
+func backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	return wait
+}
@@ -0,0 +1,131 @@
+package webhooks
+
+import (
+	"backend/etc/Utime"
+	"backend/models"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// computed with another HMAC implementation over `1714550400.{"id":1}`
+	const want = "sha256=1c954429dbb7193ba40a9f7dff71901239b6dc8983002c0cbc5e3671a49aad1c"
+
+	if got := Sign("whsec_test", 1714550400, []byte(`{"id":1}`)); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if Sign("whsec_test", 1714550401, []byte(`{"id":1}`)) == want {
+		t.Errorf("signature does not cover the timestamp")
+	}
+	if Sign("whsec_other", 1714550400, []byte(`{"id":1}`)) == want {
+		t.Errorf("signature does not depend on the secret")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 10, want: 256 * time.Minute},
+		{attempts: 11, want: maxBackoff},
+		{attempts: 50, want: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	var (
+		statusCode int
+		requests   int
+		header     http.Header
+		received   []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		header = r.Header
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		statusCode   int
+		attempts     int
+		inactive     bool
+		wantStatus   string
+		wantRequests int
+		wantRetry    time.Duration
+	}{
+		{name: "accepted", statusCode: http.StatusNoContent, wantStatus: models.DeliveryStatusSucceeded, wantRequests: 1},
+		{name: "first failure", statusCode: http.StatusInternalServerError, wantStatus: models.DeliveryStatusPending, wantRequests: 1, wantRetry: 30 * time.Second},
+		{name: "third failure", statusCode: http.StatusBadGateway, attempts: 2, wantStatus: models.DeliveryStatusPending, wantRequests: 1, wantRetry: 2 * time.Minute},
+		{name: "last attempt", statusCode: http.StatusInternalServerError, attempts: maxAttempts - 1, wantStatus: models.DeliveryStatusFailed, wantRequests: 1},
+		{name: "inactive subscription", inactive: true, wantStatus: models.DeliveryStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusCode, requests, header, received = tt.statusCode, 0, nil, nil
+
+			var (
+				dispatcher = &Dispatcher{client: server.Client()}
+				before     = Utime.Now()
+				delivery   = models.WebhookDelivery{
+					Status:        models.DeliveryStatusPending,
+					Attempts:      tt.attempts,
+					NextAttemptAt: before,
+					Event:         models.WebhookEvent{Type: models.EventLoadDelivered, Payload: models.EventPayload{LoadId: "L-1"}},
+					Subscription:  models.WebhookSubscription{URL: server.URL, Secret: "whsec_test", Active: !tt.inactive},
+				}
+			)
+			dispatcher.deliver(context.Background(), &delivery)
+
+			if delivery.Status != tt.wantStatus || delivery.Attempts != tt.attempts+1 || requests != tt.wantRequests {
+				t.Fatalf("got %s after %d attempts and %d requests, want %s after %d and %d", delivery.Status, delivery.Attempts, requests, tt.wantStatus, tt.attempts+1, tt.wantRequests)
+			}
+			if tt.wantRetry > 0 {
+				if wait := delivery.NextAttemptAt.Sub(before); wait < tt.wantRetry || wait > tt.wantRetry+time.Minute {
+					t.Errorf("got next attempt in %s, want %s", wait, tt.wantRetry)
+				}
+			}
+			if requests == 0 {
+				return
+			}
+
+			if delivery.LastStatusCode != tt.statusCode {
+				t.Errorf("got status code %d recorded, want %d", delivery.LastStatusCode, tt.statusCode)
+			}
+			if (delivery.LastError == "") != (tt.wantStatus == models.DeliveryStatusSucceeded) {
+				t.Errorf("got last error %q", delivery.LastError)
+			}
+			if header.Get("X-Webhook-Event") != models.EventLoadDelivered || !strings.Contains(string(received), `"load_id":"L-1"`) {
+				t.Errorf("got event %q with body %s", header.Get("X-Webhook-Event"), received)
+			}
+
+			timestamp, err := strconv.ParseInt(header.Get("X-Webhook-Timestamp"), 10, 64)
+			if err != nil {
+				t.Fatalf("got timestamp %q", header.Get("X-Webhook-Timestamp"))
+			}
+			if header.Get("X-Webhook-Signature") != Sign("whsec_test", timestamp, received) {
+				t.Errorf("signature does not match the body received")
+			}
+		})
+	}
+}
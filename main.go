@@ -3,51 +3,145 @@ package main
 import (
 	"backend/api"
 	"backend/api/controllers"
+	"backend/etc/alerting"
+	compliance "backend/etc/compliance_checker"
+	"backend/etc/config"
+	"backend/etc/eld"
 	emoji "backend/etc/emoji_updater"
+	"backend/etc/jobs"
+	"backend/etc/jwt"
+	late "backend/etc/late_watcher"
+	"backend/etc/logging"
+	"backend/etc/metrics"
 	"backend/etc/search"
-	"backend/models"
+	"backend/etc/tracing"
+	"backend/etc/validation"
+	"backend/etc/webhooks"
 	"backend/service"
 	database "backend/st_database"
+	"backend/st_database/migrations"
+	"backend/st_database/storage"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 )
 
-func setupDatabase() (*gorm.DB, error) {
-	dsn := fmt.Sprintf(
-		"host=db user=%s password=%s dbname=%s port=5432 sslmode=disable TimeZone=US/Eastern",
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func setupDatabase(cfg config.DatabaseConfig, slowQuery time.Duration) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{Logger: logging.NewGormLogger(slowQuery)})
 	if err != nil {
-		log.Printf("Connecting to DB with DSN: %s", dsn)
+		return nil, fmt.Errorf("connecting to %s:%d: %w", cfg.Host, cfg.Port, err)
+	}
+
+	if err = db.Use(metrics.GormPlugin{}); err != nil {
+		return nil, err
+	}
+	if err = db.Use(storage.ErrorTranslator{}); err != nil {
+		return nil, err
+	}
+	if err = db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, err
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatalf("Failed to get database %v", err)
+		return nil, err
 	}
+	metrics.RegisterDB(sqlDB, cfg.Name)
+
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	slog.Info("Database connection established", "host", cfg.Host, "name", cfg.Name)
 
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetConnMaxLifetime(time.Hour)
-	sqlDB.SetConnMaxIdleTime(30 * time.Minute)
+	return db, nil
+}
+
+// migrate runs the migrate subcommand: "migrate up", "migrate down [steps]"
+// or "migrate status".
+func migrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down [steps]|status")
+	}
 
-	log.Println("Database connection established")
+	switch args[0] {
+	case "up":
+		applied, err := migrations.Up(db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+			steps = n
+		}
 
-	if err := models.AutoMigrate(db); err != nil {
-		log.Fatalf("Failed to auto migrate %v", err)
+		rolledBack, err := migrations.Down(db, steps)
+		if err != nil {
+			return err
+		}
+		for _, migration := range rolledBack {
+			fmt.Printf("rolled back %04d_%s\n", migration.Version, migration.Name)
+		}
+	case "status":
+		statuses, err := migrations.GetStatus(db)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Unknown {
+				state += " (unknown to this binary)"
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Version, status.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q, use up, down or status", args[0])
 	}
 
-	return db, nil
+	return nil
+}
+
+// printConfig runs "config print": the effective configuration with the
+// secrets redacted, followed by what is wrong with it.
+func printConfig() error {
+	cfg, err := config.Read()
+	if err != nil {
+		return err
+	}
+
+	out, err := cfg.Print()
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+
+	return cfg.Validate()
 }
 
 // @securityDefinitions.apikey ApiKeyAuth
@@ -55,27 +149,154 @@ func setupDatabase() (*gorm.DB, error) {
 // @name Authorization
 func main() {
 	godotenv.Load(".env")
-	db, err := setupDatabase()
+
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "print" {
+		if err := printConfig(); err != nil {
+			log.Fatalf("Invalid config: %v", err)
+		}
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
+	if err = logging.Setup(cfg.Log); err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
+	jwt.Setup(cfg.Auth.SecretKey, cfg.Auth.TokenTTL)
+	if err = validation.Setup(); err != nil {
+		log.Fatalf("Failed to setup validation: %v", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to setup tracing: %v", err)
+	}
+
+	db, err := setupDatabase(cfg.Database, cfg.Log.SlowQuery)
 	if err != nil {
 		log.Fatalf("Failed to setup database %v", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate(db, os.Args[2:]); err != nil {
+			log.Fatalf("Failed to migrate %v", err)
+		}
+		return
+	}
+
+	applied, err := migrations.Up(db)
+	if err != nil {
+		log.Fatalf("Failed to migrate %v", err)
+	}
+	for _, migration := range applied {
+		slog.Info("Applied migration", "version", migration.Version, "name", migration.Name)
+	}
+
 	store := database.New(db)
-	serviceS := service.New(store)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	emoji.StartEmojiUpdater(ctx, store)
+	// SIGTERM cancels ctx, which stops the job runner and the server below.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	channels := []alerting.Channel{alerting.NewInboxChannel(), alerting.NewWebhookChannel()}
+	if cfg.SMTP.Host != "" {
+		channels = append(channels, alerting.NewSMTPChannel(alerting.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.User,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		}))
+	}
+	engine := alerting.NewEngine(store, alerting.SystemClock, channels...)
+	dispatcher := webhooks.NewDispatcher(store)
 
+	runner := jobs.NewRunner(store)
+	runner.Register(jobs.Job{
+		Name:        "board_flags",
+		Description: "Evaluates the flag rules against every logistic",
+		Every:       cfg.Jobs.BoardFlags,
+		Run:         func(ctx context.Context) error { return emoji.Refresh(ctx, store) },
+	})
+	runner.Register(jobs.Job{
+		Name:        "compliance",
+		Description: "Flags drivers with expiring or expired documents",
+		Every:       cfg.Jobs.Compliance,
+		Run:         func(ctx context.Context) error { return compliance.Check(ctx, store) },
+	})
+	runner.Register(jobs.Job{
+		Name:        "late_loads",
+		Description: "Moves ETA loads whose st_time passed to the late status",
+		Every:       cfg.Jobs.LateLoads,
+		Run:         func(ctx context.Context) error { return late.Escalate(ctx, store) },
+	})
+	runner.Register(jobs.Job{
+		Name:        "alerts",
+		Description: "Evaluates the alert rules and notifies their channels",
+		Every:       cfg.Jobs.Alerts,
+		Run:         engine.Run,
+	})
+	runner.Register(jobs.Job{
+		Name:        "webhooks",
+		Description: "Sends the pending webhook deliveries",
+		Every:       cfg.Jobs.Webhooks,
+		Run:         dispatcher.Run,
+	})
+	if cfg.ELD.URL != "" {
+		adapter := eld.NewHTTPAdapter(cfg.ELD.URL, cfg.ELD.APIKey)
+		runner.Register(jobs.Job{
+			Name:        "eld_sync",
+			Description: "Copies the hours of service clocks from the ELD",
+			Every:       cfg.Jobs.ELDSync,
+			Run:         func(ctx context.Context) error { return eld.Sync(ctx, store, adapter) },
+		})
+	}
+	runner.Start(ctx)
+
+	serviceS := service.New(store, runner)
 	cont := controllers.NewController(serviceS)
+	metrics.Register(metrics.NewBoardCollector(serviceS.Logistic().GetOverview))
 
-	errLoc := search.LoadLocations("/app/data/locations.json")
+	errLoc := search.LoadLocations(cfg.Locations)
 	if errLoc != nil {
 		log.Fatalf("Ошибка загрузки данных: %v", errLoc)
 	}
 
-	router := api.Construct(*cont)
-	if err := router.Run(":8080"); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	router := api.Construct(*cont, cfg.CORS, cfg.Metrics)
+	server := &http.Server{
+		Addr:    cfg.HTTP.Addr,
+		Handler: router,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+	slog.Info("Listening", "addr", cfg.HTTP.Addr)
+
+	<-ctx.Done()
+	stop()
+	slog.Info("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to drain requests", "error", err)
+	}
+	if err := runner.Wait(shutdownCtx); err != nil {
+		slog.Error("Failed to wait for jobs", "error", err)
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Failed to flush spans", "error", err)
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
 	}
+	slog.Info("Stopped")
 }
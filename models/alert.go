@@ -0,0 +1,143 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const (
+	// AlertKindStatusDuration fires when a logistic stays in one of the rule's
+	// statuses for longer than Threshold minutes after its st_time.
+	AlertKindStatusDuration = "STATUS_DURATION"
+	// AlertKindETANearPickup fires when the ETA of a logistic is less than
+	// Threshold minutes before the pickup time of its cargo.
+	AlertKindETANearPickup = "ETA_NEAR_PICKUP"
+	// AlertKindETAPassed fires when st_time passed more than Threshold minutes
+	// ago while the logistic is still in one of the rule's statuses.
+	AlertKindETAPassed = "ETA_PASSED"
+	// AlertKindLateLoad fires while a load is in the late status. The alert is
+	// addressed to the dispatcher who booked it.
+	AlertKindLateLoad = "LATE_LOAD"
+	// AlertKindDocumentExpiring fires when a compliance document of a driver
+	// expires within Threshold days.
+	AlertKindDocumentExpiring = "DOCUMENT_EXPIRING"
+
+	AlertSeverityInfo     = "INFO"
+	AlertSeverityWarning  = "WARNING"
+	AlertSeverityCritical = "CRITICAL"
+
+	AlertChannelInbox   = "INBOX"
+	AlertChannelEmail   = "EMAIL"
+	AlertChannelWebhook = "WEBHOOK"
+
+	AlertStatusOpen         = "OPEN"
+	AlertStatusAcknowledged = "ACKNOWLEDGED"
+	AlertStatusResolved     = "RESOLVED"
+)
+
+// AlertRule is evaluated by the alert engine. Statuses, Channels and Recipients
+// are comma separated lists. Cooldown is how many minutes pass before a still
+// firing alert is sent again, zero sends it only once.
+type AlertRule struct {
+	Id         uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	Name       string         `gorm:"type:varchar(90);not null" json:"name"`
+	Kind       string         `gorm:"type:varchar(30);not null" json:"kind"`
+	Statuses   string         `gorm:"type:varchar(255);not null;default:''" json:"statuses"`
+	Threshold  int            `gorm:"not null;default:0" json:"threshold"`
+	Severity   string         `gorm:"type:varchar(10);not null;default:'WARNING'" json:"severity"`
+	Channels   string         `gorm:"type:varchar(50);not null;default:'INBOX'" json:"channels"`
+	Recipients string         `gorm:"type:varchar(255);not null;default:''" json:"recipients"`
+	WebhookURL string         `gorm:"column:webhook_url;type:varchar(255);not null;default:''" json:"webhook_url"`
+	Cooldown   int            `gorm:"not null;default:0" json:"cooldown"`
+	Enabled    bool           `gorm:"not null" json:"enabled"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// StatusList returns the logistic statuses the rule applies to.
+func (r *AlertRule) StatusList() []string {
+	return splitList(r.Statuses)
+}
+
+// ChannelList returns the channels the rule delivers to.
+func (r *AlertRule) ChannelList() []string {
+	return splitList(r.Channels)
+}
+
+// RecipientList returns the email addresses of the rule.
+func (r *AlertRule) RecipientList() []string {
+	return splitList(r.Recipients)
+}
+
+// Alert is one firing of a rule for one subject. While it is not resolved the
+// same rule and key update it instead of opening another one.
+type Alert struct {
+	Id             uuid.UUID  `gorm:"primary_key;type:uuid;" json:"id"`
+	RuleId         uuid.UUID  `gorm:"type:uuid;not null;index" json:"rule_id"`
+	Rule           AlertRule  `gorm:"foreignKey:RuleId" swaggerignore:"true" json:"rule"`
+	DedupKey       string     `gorm:"type:varchar(100);not null;index" json:"dedup_key"`
+	Title          string     `gorm:"type:varchar(255);not null" json:"title"`
+	Message        string     `gorm:"type:text;not null" json:"message"`
+	Severity       string     `gorm:"type:varchar(10);not null" json:"severity"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'OPEN';index" json:"status"`
+	LogisticId     *uuid.UUID `gorm:"type:uuid;" json:"logistic_id"`
+	DriverId       *uuid.UUID `gorm:"type:uuid;" json:"driver_id"`
+	EmployeeId     *uuid.UUID `gorm:"type:uuid;index" json:"employee_id"`
+	Count          int        `gorm:"not null;default:1" json:"count"`
+	FirstSeenAt    time.Time  `gorm:"type:timestamp;not null" json:"first_seen_at"`
+	LastSeenAt     time.Time  `gorm:"type:timestamp;not null" json:"last_seen_at"`
+	NotifiedAt     *time.Time `gorm:"type:timestamp;" json:"notified_at"`
+	DeliveryError  string     `gorm:"type:varchar(255);not null;default:''" json:"delivery_error"`
+	Inbox          bool       `gorm:"not null;default:false" json:"inbox"`
+	SnoozedUntil   *time.Time `gorm:"type:timestamp;" json:"snoozed_until"`
+	AcknowledgedAt *time.Time `gorm:"type:timestamp;" json:"acknowledged_at"`
+	AcknowledgedBy *uuid.UUID `gorm:"type:uuid;" json:"acknowledged_by"`
+	ResolvedAt     *time.Time `gorm:"type:timestamp;" json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ShouldNotify reports whether the alert has to be sent to the channels of
+// its rule at now.
+func (a *Alert) ShouldNotify(rule *AlertRule, now time.Time) bool {
+	if a.Status != AlertStatusOpen {
+		return false
+	}
+	if a.SnoozedUntil != nil && now.Before(*a.SnoozedUntil) {
+		return false
+	}
+	if a.NotifiedAt == nil {
+		return true
+	}
+
+	return rule.Cooldown > 0 && now.Sub(*a.NotifiedAt) >= time.Duration(rule.Cooldown)*time.Minute
+}
+
+type GetAllAlertsReq struct {
+	Page       uint64    `json:"page"`
+	Limit      uint64    `json:"limit"`
+	RuleId     uuid.UUID `json:"rule_id"`
+	Status     string    `json:"status"`
+	Severity   string    `json:"severity"`
+	Inbox      bool      `json:"inbox"`
+	EmployeeId uuid.UUID `json:"employee_id"`
+}
+
+type GetAllAlertsResp struct {
+	Alerts []Alert `json:"alerts"`
+	Count  int64   `json:"count"`
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
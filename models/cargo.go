@@ -1,25 +1,133 @@
 package models
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"time"
 )
 
+const (
+	CargoStatusActive    = "ACTIVE"
+	CargoStatusDelivered = "DELIVERED"
+	CargoStatusCancelled = "CANCELLED"
+)
+
 type Cargo struct {
-	Id           uuid.UUID      `gorm:"primary_key;type:uuid;not_null" json:"id"`
-	CargoID      string         `gorm:"type:varchar(90); not null"     json:"cargo_id"`
-	Provider     string         `gorm:"type:varchar(90); not null"  json:"provider"`
-	LoadedMiles  int64          `gorm:"not null" json:"loaded_miles"`
-	FreeMiles    int64          `gorm:"not null" json:"free_miles"`
-	From         string         `gorm:"type:varchar(90); not null" json:"from"`
-	To           string         `gorm:"type:varchar(90); not null" json:"to"`
-	Cost         int64          `gorm:"not null" json:"cost"`
-	Rate         float64        `gorm:"type:decimal(10,2);not null" json:"rate"`
-	PickUpTime   time.Time      `gorm:"type:timestamp;not null" json:"pick_up_time"`
-	DeliveryTime time.Time      `gorm:"type:timestamp;not null" json:"delivery_time"`
-	EmployeeId   uuid.UUID      `gorm:"type:uuid;" json:"employee_id"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+	Id            uuid.UUID      `gorm:"primary_key;type:uuid;not_null" json:"id"`
+	CargoID       string         `gorm:"type:varchar(90); not null"     json:"cargo_id"`
+	Provider      string         `gorm:"type:varchar(90); not null"  json:"provider"`
+	ProviderId    *uuid.UUID     `gorm:"type:uuid;index" json:"provider_id"`
+	LoadedMiles   int64          `gorm:"not null" json:"loaded_miles"`
+	FreeMiles     int64          `gorm:"not null" json:"free_miles"`
+	From          string         `gorm:"type:varchar(90); not null" json:"from"`
+	To            string         `gorm:"type:varchar(90); not null" json:"to"`
+	Cost          int64          `gorm:"not null" json:"cost"`
+	Rate          float64        `gorm:"type:decimal(10,2);not null" json:"rate"`
+	PickUpTime    time.Time      `gorm:"type:timestamp;not null" json:"pick_up_time"`
+	DeliveryTime  time.Time      `gorm:"type:timestamp;not null" json:"delivery_time"`
+	EmployeeId    uuid.UUID      `gorm:"type:uuid;" json:"employee_id"`
+	DriverId      *uuid.UUID     `gorm:"type:uuid;index" json:"driver_id"`
+	Status        string         `gorm:"type:varchar(20);not null;default:'ACTIVE'" json:"status"`
+	TransactionId *uuid.UUID     `gorm:"type:uuid;" json:"transaction_id"`
+	TerminatedAt  *time.Time     `gorm:"type:timestamp;" json:"terminated_at"`
+	Stops         []Stop         `gorm:"foreignKey:CargoId" json:"stops"`
+	Version       int64          `gorm:"not null;default:1" json:"version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+type CargoResponse struct {
+	Id             uuid.UUID  `json:"id"`
+	CargoID        string     `json:"cargo_id"`
+	Provider       string     `json:"provider"`
+	ProviderId     *uuid.UUID `json:"provider_id"`
+	LoadedMiles    int64      `json:"loaded_miles"`
+	FreeMiles      int64      `json:"free_miles"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	Cost           int64      `json:"cost"`
+	Rate           float64    `json:"rate"`
+	PickUpTime     time.Time  `json:"pick_up_time"`
+	DeliveryTime   time.Time  `json:"delivery_time"`
+	Status         string     `json:"status"`
+	EmployeeId     uuid.UUID  `json:"employee_id"`
+	DispatcherName string     `json:"dispatcher_name"`
+	DriverId       *uuid.UUID `json:"driver_id"`
+	DriverName     string     `json:"driver_name"`
+	DriverSurname  string     `json:"driver_surname"`
+	LogisticId     *uuid.UUID `json:"logistic_id"`
+	TransactionId  *uuid.UUID `json:"transaction_id"`
+	TerminatedAt   *time.Time `json:"terminated_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Stops          []Stop     `gorm:"-" json:"stops,omitempty"`
+}
+
+type GetAllCargosReq struct {
+	Page           uint64     `json:"page"`
+	Limit          uint64     `json:"limit"`
+	Search         string     `json:"search"`
+	CargoID        string     `json:"cargo_id"`
+	Provider       string     `json:"provider"`
+	ProviderId     uuid.UUID  `json:"provider_id"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	PickUpFrom     *time.Time `json:"pick_up_from"`
+	PickUpTo       *time.Time `json:"pick_up_to"`
+	EmployeeId     uuid.UUID  `json:"employee_id"`
+	DispatcherName string     `json:"dispatcher_name"`
+	DriverId       uuid.UUID  `json:"driver_id"`
+	Status         string     `json:"status"`
+}
+
+type GetAllCargosResp struct {
+	Cargos []CargoResponse `json:"cargos"`
+	Count  int64           `json:"count"`
+}
+
+type DuplicateLoad struct {
+	CargoId         *uuid.UUID `json:"cargo_id"`
+	LoadId          string     `json:"load_id"`
+	Provider        string     `json:"provider"`
+	From            string     `json:"from"`
+	To              string     `json:"to"`
+	PickUpTime      time.Time  `json:"pick_up_time"`
+	Status          string     `json:"status"`
+	LogisticId      *uuid.UUID `json:"logistic_id"`
+	TransactionId   *uuid.UUID `json:"transaction_id"`
+	Exact           bool       `json:"exact"`
+	CargoLink       string     `json:"cargo_link,omitempty"`
+	LogisticLink    string     `json:"logistic_link,omitempty"`
+	TransactionLink string     `json:"transaction_link,omitempty"`
+}
+
+func (d DuplicateLoad) String() string {
+	if d.Exact {
+		return fmt.Sprintf("load %s was already booked with %s (%s)", d.LoadId, d.Provider, d.Status)
+	}
+	return fmt.Sprintf("load %s of %s runs the same lane %s - %s at %s",
+		d.LoadId, d.Provider, d.From, d.To, d.PickUpTime.Format("2006-01-02 15:04"))
+}
+
+type UpdateWithCargoOptions struct {
+	Create            bool
+	OverrideDuplicate bool
+	OverrideHOS       bool
+}
+
+// UpdateWithCargoResp is the cargo that was created or updated. CargoVersion
+// is its version now, to send as cargo_version with the next update.
+type UpdateWithCargoResp struct {
+	Id           string          `json:"id"`
+	CargoVersion int64           `json:"cargo_version"`
+	Message      string          `json:"message"`
+	Warnings     []string        `json:"warnings"`
+	Duplicates   []DuplicateLoad `json:"duplicates"`
+}
+
+type DuplicateLoadResp struct {
+	ErrorMessage string          `json:"error_message"`
+	ErrorCode    string          `json:"error_code"`
+	Duplicates   []DuplicateLoad `json:"duplicates"`
 }
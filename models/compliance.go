@@ -0,0 +1,128 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const (
+	ComplianceStatusOK       = "OK"
+	ComplianceStatusExpiring = "EXPIRING"
+	ComplianceStatusExpired  = "EXPIRED"
+
+	DocumentCDL         = "CDL"
+	DocumentMedicalCard = "MEDICAL_CARD"
+	DocumentDrugTest    = "DRUG_TEST"
+	DocumentMVR         = "MVR"
+
+	// ComplianceWarnDays is how long before expiry a document is flagged.
+	ComplianceWarnDays = 30
+)
+
+// DriverCompliance holds the documents a driver must keep current to be
+// dispatched. Status and Issues are refreshed by the compliance checker.
+type DriverCompliance struct {
+	Id                uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	DriverId          uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"driver_id"`
+	Driver            Driver         `gorm:"foreignKey:DriverId" swaggerignore:"true" json:"driver"`
+	CDLNumber         string         `gorm:"type:varchar(30);not null;default:''" json:"cdl_number"`
+	CDLState          string         `gorm:"type:varchar(2);not null;default:''" json:"cdl_state"`
+	CDLClass          string         `gorm:"type:varchar(1);not null;default:''" json:"cdl_class"`
+	CDLExpiry         *time.Time     `gorm:"type:date;" json:"cdl_expiry"`
+	Endorsements      string         `gorm:"type:varchar(20);not null;default:''" json:"endorsements"`
+	MedicalCardExpiry *time.Time     `gorm:"type:date;" json:"medical_card_expiry"`
+	LastDrugTest      *time.Time     `gorm:"type:date;" json:"last_drug_test"`
+	NextDrugTest      *time.Time     `gorm:"type:date;" json:"next_drug_test"`
+	MVRReviewedAt     *time.Time     `gorm:"type:date;" json:"mvr_reviewed_at"`
+	MVRNextReview     *time.Time     `gorm:"type:date;" json:"mvr_next_review"`
+	Status            string         `gorm:"type:varchar(20);not null;default:'OK'" json:"status"`
+	Issues            string         `gorm:"type:varchar(255);not null;default:''" json:"issues"`
+	CheckedAt         *time.Time     `json:"checked_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+type ExpiringDocument struct {
+	Document  string    `json:"document"`
+	ExpiresAt time.Time `json:"expires_at"`
+	DaysLeft  int       `json:"days_left"`
+}
+
+// Expiring returns the documents that expire within days of now. Documents that
+// already expired are included with a negative DaysLeft.
+func (c *DriverCompliance) Expiring(now time.Time, days int) []ExpiringDocument {
+	var (
+		today     = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		documents []ExpiringDocument
+	)
+	for _, d := range []struct {
+		name string
+		date *time.Time
+	}{
+		{DocumentCDL, c.CDLExpiry},
+		{DocumentMedicalCard, c.MedicalCardExpiry},
+		{DocumentDrugTest, c.NextDrugTest},
+		{DocumentMVR, c.MVRNextReview},
+	} {
+		if d.date == nil {
+			continue
+		}
+		expires := time.Date(d.date.Year(), d.date.Month(), d.date.Day(), 0, 0, 0, 0, time.UTC)
+		left := int(expires.Sub(today).Hours() / 24)
+		if left <= days {
+			documents = append(documents, ExpiringDocument{Document: d.name, ExpiresAt: expires, DaysLeft: left})
+		}
+	}
+
+	return documents
+}
+
+// Expired returns the names of the documents that are no longer valid.
+func (c *DriverCompliance) Expired(now time.Time) []string {
+	var expired []string
+	for _, d := range c.Expiring(now, -1) {
+		expired = append(expired, d.Document)
+	}
+
+	return expired
+}
+
+// Evaluate sets Status and Issues from the document dates.
+func (c *DriverCompliance) Evaluate(now time.Time, warnDays int) {
+	var (
+		expired  []string
+		expiring []string
+	)
+	for _, d := range c.Expiring(now, warnDays) {
+		if d.DaysLeft < 0 {
+			expired = append(expired, d.Document+" expired")
+		} else {
+			expiring = append(expiring, d.Document+" expiring")
+		}
+	}
+
+	switch {
+	case len(expired) > 0:
+		c.Status = ComplianceStatusExpired
+	case len(expiring) > 0:
+		c.Status = ComplianceStatusExpiring
+	default:
+		c.Status = ComplianceStatusOK
+	}
+	c.Issues = strings.Join(append(expired, expiring...), ", ")
+}
+
+type ExpiringCompliance struct {
+	DriverId   uuid.UUID          `json:"driver_id"`
+	DriverName string             `json:"driver_name"`
+	Compliance DriverCompliance   `json:"compliance"`
+	Documents  []ExpiringDocument `json:"documents"`
+}
+
+type GetExpiringComplianceResp struct {
+	Drivers []ExpiringCompliance `json:"drivers"`
+	Count   int64                `json:"count"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDriverComplianceEvaluate(t *testing.T) {
+	var (
+		eastern, _ = time.LoadLocation("America/New_York")
+		// late in the evening, when the date in UTC is already the next day
+		now = time.Date(2024, time.March, 10, 23, 30, 0, 0, eastern)
+		day = func(days int) *time.Time {
+			date := time.Date(2024, time.March, 10+days, 0, 0, 0, 0, time.UTC)
+			return &date
+		}
+	)
+
+	tests := []struct {
+		name        string
+		compliance  DriverCompliance
+		wantStatus  string
+		wantIssues  string
+		wantExpired []string
+	}{
+		{
+			name:       "no documents",
+			wantStatus: ComplianceStatusOK,
+		},
+		{
+			name:       "all current",
+			compliance: DriverCompliance{CDLExpiry: day(400), MedicalCardExpiry: day(31), NextDrugTest: day(90)},
+			wantStatus: ComplianceStatusOK,
+		},
+		{
+			name:       "expires in the warning window",
+			compliance: DriverCompliance{CDLExpiry: day(400), MedicalCardExpiry: day(30)},
+			wantStatus: ComplianceStatusExpiring,
+			wantIssues: "MEDICAL_CARD expiring",
+		},
+		{
+			name:       "expires today",
+			compliance: DriverCompliance{MVRNextReview: day(0)},
+			wantStatus: ComplianceStatusExpiring,
+			wantIssues: "MVR expiring",
+		},
+		{
+			name:        "expired yesterday",
+			compliance:  DriverCompliance{CDLExpiry: day(5), NextDrugTest: day(-1)},
+			wantStatus:  ComplianceStatusExpired,
+			wantIssues:  "DRUG_TEST expired, CDL expiring",
+			wantExpired: []string{DocumentDrugTest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compliance := tt.compliance
+			compliance.Evaluate(now, ComplianceWarnDays)
+
+			if compliance.Status != tt.wantStatus || compliance.Issues != tt.wantIssues {
+				t.Errorf("got %s with %q, want %s with %q", compliance.Status, compliance.Issues, tt.wantStatus, tt.wantIssues)
+			}
+
+			expired := compliance.Expired(now)
+			if len(expired) != len(tt.wantExpired) {
+				t.Fatalf("got expired %q, want %q", expired, tt.wantExpired)
+			}
+			for i := range expired {
+				if expired[i] != tt.wantExpired[i] {
+					t.Errorf("got expired %q, want %q", expired, tt.wantExpired)
+				}
+			}
+		})
+	}
+}
+
+func TestDriverComplianceExpiringDaysLeft(t *testing.T) {
+	var (
+		now        = time.Date(2024, time.November, 3, 12, 0, 0, 0, time.UTC)
+		cdl        = time.Date(2024, time.November, 13, 0, 0, 0, 0, time.UTC)
+		medical    = time.Date(2024, time.October, 30, 0, 0, 0, 0, time.UTC)
+		compliance = DriverCompliance{CDLExpiry: &cdl, MedicalCardExpiry: &medical}
+	)
+
+	got := compliance.Expiring(now, 10)
+	if len(got) != 2 || got[0].Document != DocumentCDL || got[0].DaysLeft != 10 || got[1].Document != DocumentMedicalCard || got[1].DaysLeft != -4 {
+		t.Errorf("got %+v, want the CDL with 10 days left and the medical card 4 days past", got)
+	}
+	if got := compliance.Expiring(now, 9); len(got) != 1 || got[0].Document != DocumentMedicalCard {
+		t.Errorf("got %+v, want only the medical card within 9 days", got)
+	}
+}
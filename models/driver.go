@@ -13,6 +13,8 @@ type Driver struct {
 	Type        string         `gorm:"type:varchar(50);not null;" json:"type"`
 	Position    string         `gorm:"type:varchar(50);not null;" json:"position"`
 	TruckNumber string         `gorm:"type:varchar; not null;" json:"truck_number"`
+	TruckId     *uuid.UUID     `gorm:"type:uuid;" json:"truck_id"`
+	TrailerId   *uuid.UUID     `gorm:"type:uuid;" json:"trailer_id"`
 	PhoneNumber string         `gorm:"type:varchar(20);not null;" json:"phone_number"`
 	Mail        string         `gorm:"type:varchar(50);not null;" json:"mail"`
 	Birthday    time.Time      `gorm:"type:date;not null;" json:"birthday"`
@@ -24,6 +26,11 @@ type Driver struct {
 	DeletedAt   gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
 }
 
+// FullName is the name of the driver as shown on the board and in history.
+func (d *Driver) FullName() string {
+	return d.Name + " " + d.Surname
+}
+
 type GetAllDriversResp struct {
 	Drivers []Driver `json:"drivers"`
 	Count   int64    `json:"count"`
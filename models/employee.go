@@ -6,6 +6,10 @@ import (
 	"time"
 )
 
+// SystemEmployeeId is the employee that changes made by the backend itself,
+// like the late watcher, are attributed to. It can't log in.
+var SystemEmployeeId = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
 type Employee struct {
 	Id          uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
 	Name        string         `gorm:"type:varchar(30); not null" json:"name"`
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	OwnershipCompany       = "COMPANY"
+	OwnershipOwnerOperator = "OWNER_OPERATOR"
+	OwnershipLeased        = "LEASED"
+
+	EquipmentStatusActive   = "ACTIVE"
+	EquipmentStatusInactive = "INACTIVE"
+
+	TrailerTypeDryVan   = "DRY_VAN"
+	TrailerTypeReefer   = "REEFER"
+	TrailerTypeFlatbed  = "FLATBED"
+	TrailerTypeStepDeck = "STEP_DECK"
+	TrailerTypeTanker   = "TANKER"
+	TrailerTypeOther    = "OTHER"
+)
+
+type Truck struct {
+	Id                 uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	CompanyId          uuid.UUID      `gorm:"type:uuid;not null;index" json:"company_id"`
+	Company            Company        `gorm:"foreignKey:CompanyId" swaggerignore:"true" json:"company"`
+	Number             string         `gorm:"type:varchar(20);not null" json:"number"`
+	VIN                string         `gorm:"type:varchar(17);not null;uniqueIndex:idx_trucks_vin,where:deleted_at IS NULL" json:"vin"`
+	Make               string         `gorm:"type:varchar(30);not null;default:''" json:"make"`
+	Model              string         `gorm:"type:varchar(30);not null;default:''" json:"model"`
+	Year               int            `gorm:"type:int;not null;default:0" json:"year"`
+	Odometer           int64          `gorm:"not null;default:0" json:"odometer"`
+	Plate              string         `gorm:"type:varchar(20);not null;default:''" json:"plate"`
+	PlateState         string         `gorm:"type:varchar(2);not null;default:''" json:"plate_state"`
+	RegistrationExpiry *time.Time     `gorm:"type:date;" json:"registration_expiry"`
+	Ownership          string         `gorm:"type:varchar(20);not null;default:'COMPANY'" json:"ownership"`
+	Status             string         `gorm:"type:varchar(20);not null;default:'ACTIVE'" json:"status"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+type Trailer struct {
+	Id                 uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	CompanyId          uuid.UUID      `gorm:"type:uuid;not null;index" json:"company_id"`
+	Company            Company        `gorm:"foreignKey:CompanyId" swaggerignore:"true" json:"company"`
+	Number             string         `gorm:"type:varchar(20);not null" json:"number"`
+	VIN                string         `gorm:"type:varchar(17);not null;uniqueIndex:idx_trailers_vin,where:deleted_at IS NULL" json:"vin"`
+	Type               string         `gorm:"type:varchar(20);not null" json:"type"`
+	Length             int            `gorm:"type:int;not null;default:53" json:"length"`
+	Make               string         `gorm:"type:varchar(30);not null;default:''" json:"make"`
+	Year               int            `gorm:"type:int;not null;default:0" json:"year"`
+	Plate              string         `gorm:"type:varchar(20);not null;default:''" json:"plate"`
+	PlateState         string         `gorm:"type:varchar(2);not null;default:''" json:"plate_state"`
+	RegistrationExpiry *time.Time     `gorm:"type:date;" json:"registration_expiry"`
+	Ownership          string         `gorm:"type:varchar(20);not null;default:'COMPANY'" json:"ownership"`
+	Status             string         `gorm:"type:varchar(20);not null;default:'ACTIVE'" json:"status"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// EquipmentAssignment is one period during which a driver ran a truck and
+// trailer. The open assignment of a driver has no EndedAt.
+type EquipmentAssignment struct {
+	Id         uuid.UUID  `gorm:"primary_key;type:uuid;" json:"id"`
+	DriverId   uuid.UUID  `gorm:"type:uuid;not null;index" json:"driver_id"`
+	Driver     Driver     `gorm:"foreignKey:DriverId" swaggerignore:"true" json:"driver"`
+	TruckId    *uuid.UUID `gorm:"type:uuid;index" json:"truck_id"`
+	Truck      *Truck     `gorm:"foreignKey:TruckId" json:"truck,omitempty"`
+	TrailerId  *uuid.UUID `gorm:"type:uuid;index" json:"trailer_id"`
+	Trailer    *Trailer   `gorm:"foreignKey:TrailerId" json:"trailer,omitempty"`
+	StartedAt  time.Time  `gorm:"type:timestamp;not null" json:"started_at"`
+	EndedAt    *time.Time `gorm:"type:timestamp;" json:"ended_at"`
+	Notes      string     `gorm:"type:varchar(255);not null;default:''" json:"notes"`
+	EmployeeId uuid.UUID  `gorm:"type:uuid;not null" json:"employee_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type GetAllEquipmentReq struct {
+	Page      uint64    `json:"page"`
+	Limit     uint64    `json:"limit"`
+	CompanyId uuid.UUID `json:"company_id"`
+	Search    string    `json:"search"`
+	Status    string    `json:"status"`
+	Type      string    `json:"type"`
+}
+
+type GetAllTrucksResp struct {
+	Trucks []Truck `json:"trucks"`
+	Count  int64   `json:"count"`
+}
+
+type GetAllTrailersResp struct {
+	Trailers []Trailer `json:"trailers"`
+	Count    int64     `json:"count"`
+}
+
+type GetAllAssignmentsReq struct {
+	Page      uint64    `json:"page"`
+	Limit     uint64    `json:"limit"`
+	DriverId  uuid.UUID `json:"driver_id"`
+	TruckId   uuid.UUID `json:"truck_id"`
+	TrailerId uuid.UUID `json:"trailer_id"`
+}
+
+type GetAllAssignmentsResp struct {
+	Assignments []EquipmentAssignment `json:"assignments"`
+	Count       int64                 `json:"count"`
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	// FlagConditionAfterStTime flags a logistic whose st_time passed more than
+	// Threshold minutes ago.
+	FlagConditionAfterStTime = "AFTER_ST_TIME"
+	// FlagConditionBeforeStTime flags a logistic whose st_time is still ahead
+	// but less than Threshold minutes away.
+	FlagConditionBeforeStTime = "BEFORE_ST_TIME"
+)
+
+// FlagRule puts Icon on the board rows of the listed statuses, or of all of
+// them when Statuses is empty, while its condition holds. Statuses are kept as
+// a list because some of them contain commas. Rules with a lower Priority come
+// first. A nil CompanyId applies the rule to every company.
+type FlagRule struct {
+	Id        uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	Name      string         `gorm:"type:varchar(90);not null" json:"name"`
+	Condition string         `gorm:"type:varchar(30);not null" json:"condition"`
+	Statuses  StringList     `gorm:"type:jsonb;not null;default:'[]'" json:"statuses"`
+	Threshold int            `gorm:"not null;default:0" json:"threshold"`
+	Icon      string         `gorm:"type:varchar(30);not null" json:"icon"`
+	Priority  int            `gorm:"not null;default:0" json:"priority"`
+	CompanyId *uuid.UUID     `gorm:"type:uuid;" json:"company_id"`
+	Enabled   bool           `gorm:"not null" json:"enabled"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// Matches reports whether the rule flags a logistic of the company in status
+// with st_time stTime at now.
+func (r *FlagRule) Matches(companyId uuid.UUID, status string, stTime time.Time, now time.Time) bool {
+	if r.CompanyId != nil && *r.CompanyId != companyId {
+		return false
+	}
+
+	if len(r.Statuses) > 0 {
+		found := false
+		for _, s := range r.Statuses {
+			if s == status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	threshold := time.Duration(r.Threshold) * time.Minute
+	switch r.Condition {
+	case FlagConditionAfterStTime:
+		return now.Sub(stTime) > threshold
+	case FlagConditionBeforeStTime:
+		return !stTime.Before(now) && stTime.Sub(now) < threshold
+	}
+
+	return false
+}
+
+type StringList []string
+
+func (l *StringList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	}
+	return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+}
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l)
+}
+
+// Flag is a rule that matched a logistic.
+type Flag struct {
+	RuleId uuid.UUID `json:"rule_id"`
+	Name   string    `json:"name"`
+	Icon   string    `json:"icon"`
+}
+
+type Flags []Flag
+
+// EvaluateFlags returns the flags of the rules, in their order, that match a
+// logistic. st_time is stored without a zone, so its wall clock is read in
+// the location of now. Logistics without st_time get no flags.
+func EvaluateFlags(rules []FlagRule, companyId uuid.UUID, status string, stTime *time.Time, now time.Time) Flags {
+	flags := Flags{}
+	if stTime == nil {
+		return flags
+	}
+
+	st := time.Date(stTime.Year(), stTime.Month(), stTime.Day(),
+		stTime.Hour(), stTime.Minute(), stTime.Second(), stTime.Nanosecond(), now.Location())
+
+	for i := range rules {
+		if rules[i].Matches(companyId, status, st, now) {
+			flags = append(flags, Flag{RuleId: rules[i].Id, Name: rules[i].Name, Icon: rules[i].Icon})
+		}
+	}
+
+	return flags
+}
+
+// Icon returns the icon of the first flag, kept in the emoji column for
+// clients that show a single one.
+func (f Flags) Icon() string {
+	if len(f) == 0 {
+		return ""
+	}
+
+	return f[0].Icon
+}
+
+// Equal reports whether both lists hold the same rules in the same order.
+func (f Flags) Equal(other Flags) bool {
+	if len(f) != len(other) {
+		return false
+	}
+	for i := range f {
+		if f[i] != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *Flags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	}
+	return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+}
+
+func (f Flags) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f)
+}
@@ -60,18 +60,39 @@ func (j *JSONBLogistic) Value() (driver.Value, error) {
 }
 
 type JSONBCargo struct {
-	Id           uuid.UUID `json:"id"`
-	CargoID      string    `json:"cargo_id"`
-	Provider     string    `json:"provider"`
-	LoadedMiles  int64     `json:"loaded_miles"`
-	FreeMiles    int64     `json:"free_miles"`
-	From         string    `json:"from"`
-	To           string    `json:"to"`
-	Cost         int64     `json:"cost"`
-	Rate         float64   `json:"rate"`
-	PickUpTime   time.Time `json:"pick_up_time"`
-	DeliveryTime time.Time `json:"delivery_time"`
-	EmployeeId   uuid.UUID `json:"employee_id"`
+	Id           uuid.UUID  `json:"id"`
+	CargoID      string     `json:"cargo_id"`
+	Provider     string     `json:"provider"`
+	ProviderId   *uuid.UUID `json:"provider_id"`
+	LoadedMiles  int64      `json:"loaded_miles"`
+	FreeMiles    int64      `json:"free_miles"`
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	Cost         int64      `json:"cost"`
+	Rate         float64    `json:"rate"`
+	PickUpTime   time.Time  `json:"pick_up_time"`
+	DeliveryTime time.Time  `json:"delivery_time"`
+	EmployeeId   uuid.UUID  `json:"employee_id"`
+	Stops        JSONBStops `json:"stops"`
+}
+
+func NewJSONBCargo(cargo *Cargo) *JSONBCargo {
+	return &JSONBCargo{
+		Id:           cargo.Id,
+		CargoID:      cargo.CargoID,
+		Provider:     cargo.Provider,
+		ProviderId:   cargo.ProviderId,
+		LoadedMiles:  cargo.LoadedMiles,
+		FreeMiles:    cargo.FreeMiles,
+		From:         cargo.From,
+		To:           cargo.To,
+		Cost:         cargo.Cost,
+		Rate:         cargo.Rate,
+		PickUpTime:   cargo.PickUpTime,
+		DeliveryTime: cargo.DeliveryTime,
+		EmployeeId:   cargo.EmployeeId,
+		Stops:        NewJSONBStops(cargo.Stops),
+	}
 }
 
 func (j *JSONBCargo) Scan(value interface{}) error {
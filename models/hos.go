@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"math"
+	"time"
+)
+
+const (
+	HOSSourceManual = "MANUAL"
+	HOSSourceELD    = "ELD"
+
+	// HOSDriveLimit is the driving a driver gets after a full reset.
+	HOSDriveLimit = 11.0
+	// HOSResetHours is the off-duty break that restores HOSDriveLimit.
+	HOSResetHours = 10.0
+	// HOSAssumedSpeed is the average speed in mph used to turn miles into hours.
+	HOSAssumedSpeed = 50.0
+	// HOSStaleAfter is how old the clocks may be before a warning is shown.
+	HOSStaleAfter = 12 * time.Hour
+)
+
+// DriverHOS is a simplified hours-of-service state of a driver: how many
+// driving, on-duty and cycle hours were left at ReportedAt.
+type DriverHOS struct {
+	Id          uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	DriverId    uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"driver_id"`
+	Driver      Driver         `gorm:"foreignKey:DriverId" swaggerignore:"true" json:"driver"`
+	DriveLeft   float64        `gorm:"type:decimal(5,2);not null;default:0" json:"drive_left"`
+	OnDutyLeft  float64        `gorm:"type:decimal(5,2);not null;default:0" json:"on_duty_left"`
+	CycleLeft   float64        `gorm:"type:decimal(5,2);not null;default:0" json:"cycle_left"`
+	Source      string         `gorm:"type:varchar(10);not null;default:'MANUAL'" json:"source"`
+	ELDDriverId string         `gorm:"column:eld_driver_id;type:varchar(50);not null;default:''" json:"eld_driver_id"`
+	ReportedAt  time.Time      `gorm:"type:timestamp;not null" json:"reported_at"`
+	EmployeeId  *uuid.UUID     `gorm:"type:uuid;" json:"employee_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// Feasibility checks whether a trip of freeMiles to the pickup and loadedMiles
+// to the delivery fits the driver's remaining hours at HOSAssumedSpeed. Once the
+// current shift is used up, every HOSDriveLimit hours of driving costs a
+// HOSResetHours break. The returned problems are empty when the trip fits.
+func (h *DriverHOS) Feasibility(now, pickUp, delivery time.Time, loadedMiles, freeMiles int64) []string {
+	var (
+		problems   []string
+		deadhead   = float64(freeMiles) / HOSAssumedSpeed
+		loaded     = float64(loadedMiles) / HOSAssumedSpeed
+		drive      = deadhead + loaded
+		toPickUp   = pickUp.Sub(now).Hours()
+		toDelivery = delivery.Sub(now).Hours()
+	)
+
+	if drive > h.CycleLeft {
+		problems = append(problems, fmt.Sprintf("trip needs %.1f driving hours but only %.1f are left in the cycle", drive, h.CycleLeft))
+	}
+
+	if need := h.elapsed(deadhead); toPickUp > 0 && need > toPickUp {
+		problems = append(problems, fmt.Sprintf("driver needs about %.1f hours to reach the pickup but it is in %.1f hours", need, toPickUp))
+	}
+
+	if window := delivery.Sub(pickUp).Hours(); loaded > window {
+		problems = append(problems, fmt.Sprintf("loaded miles need %.1f driving hours but pickup and delivery are %.1f hours apart", loaded, window))
+	}
+
+	if need := h.elapsed(drive); need > toDelivery {
+		problems = append(problems, fmt.Sprintf("trip needs about %.1f hours including breaks but delivery is in %.1f hours", need, math.Max(toDelivery, 0)))
+	}
+
+	return problems
+}
+
+// elapsed is how long it takes to drive the given hours including resets.
+func (h *DriverHOS) elapsed(drive float64) float64 {
+	shift := math.Max(math.Min(h.DriveLeft, h.OnDutyLeft), 0)
+	if drive <= shift {
+		return drive
+	}
+
+	resets := math.Ceil((drive - shift) / HOSDriveLimit)
+	return drive + resets*HOSResetHours
+}
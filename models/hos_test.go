@@ -0,0 +1,99 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDriverHOSFeasibility(t *testing.T) {
+	var (
+		now  = time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+		in   = func(hours float64) time.Time { return now.Add(time.Duration(hours * float64(time.Hour))) }
+		full = DriverHOS{DriveLeft: 11, OnDutyLeft: 14, CycleLeft: 70}
+	)
+
+	tests := []struct {
+		name         string
+		hos          DriverHOS
+		pickUp       time.Time
+		delivery     time.Time
+		loadedMiles  int64
+		freeMiles    int64
+		wantProblems []string
+	}{
+		{
+			name:        "fits the shift",
+			hos:         full,
+			pickUp:      in(3),
+			delivery:    in(13),
+			loadedMiles: 400,
+			freeMiles:   50,
+		},
+		{
+			name:         "not enough cycle",
+			hos:          DriverHOS{DriveLeft: 11, OnDutyLeft: 14, CycleLeft: 5},
+			pickUp:       in(3),
+			delivery:     in(13),
+			loadedMiles:  400,
+			freeMiles:    50,
+			wantProblems: []string{"trip needs 9.0 driving hours but only 5.0 are left in the cycle"},
+		},
+		{
+			name:         "pickup out of reach",
+			hos:          full,
+			pickUp:       in(3),
+			delivery:     in(23),
+			loadedMiles:  400,
+			freeMiles:    250,
+			wantProblems: []string{"driver needs about 5.0 hours to reach the pickup but it is in 3.0 hours"},
+		},
+		{
+			name:        "pickup time already passed",
+			hos:         full,
+			pickUp:      in(-1),
+			delivery:    in(30),
+			loadedMiles: 400,
+			freeMiles:   250,
+		},
+		{
+			name:         "delivery window too short",
+			hos:          full,
+			pickUp:       in(3),
+			delivery:     in(9),
+			loadedMiles:  400,
+			freeMiles:    50,
+			wantProblems: []string{"loaded miles need 8.0 driving hours but pickup and delivery are 6.0 hours apart"},
+		},
+		{
+			name:         "shift used up needs a reset",
+			hos:          DriverHOS{DriveLeft: 2, OnDutyLeft: 1, CycleLeft: 70},
+			pickUp:       in(3),
+			delivery:     in(13),
+			loadedMiles:  400,
+			freeMiles:    50,
+			wantProblems: []string{"trip needs about 19.0 hours including breaks but delivery is in 13.0 hours"},
+		},
+		{
+			name:         "delivery already passed",
+			hos:          full,
+			pickUp:       in(-5),
+			delivery:     in(-1),
+			wantProblems: []string{"trip needs about 0.0 hours including breaks but delivery is in 0.0 hours"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := tt.hos.Feasibility(now, tt.pickUp, tt.delivery, tt.loadedMiles, tt.freeMiles)
+
+			if len(problems) != len(tt.wantProblems) {
+				t.Fatalf("got problems %q, want %q", problems, tt.wantProblems)
+			}
+			for i := range tt.wantProblems {
+				if problems[i] != tt.wantProblems[i] {
+					t.Errorf("got problem %q, want %q", problems[i], tt.wantProblems[i])
+				}
+			}
+		})
+	}
+}
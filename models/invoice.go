@@ -0,0 +1,163 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	InvoiceStatusDraft = "DRAFT"
+	InvoiceStatusSent  = "SENT"
+	InvoiceStatusPaid  = "PAID"
+	InvoiceStatusVoid  = "VOID"
+
+	InvoiceLineLinehaul  = "LINEHAUL"
+	InvoiceLineDetention = "DETENTION"
+	InvoiceLineLayover   = "LAYOVER"
+	InvoiceLineLumper    = "LUMPER"
+	InvoiceLineTONU      = "TONU"
+	InvoiceLineOther     = "OTHER"
+)
+
+type Invoice struct {
+	Id                uuid.UUID        `gorm:"primary_key;type:uuid;" json:"id"`
+	Number            string           `gorm:"type:varchar(40);not null;uniqueIndex:idx_invoices_company_number,priority:2" json:"number"`
+	Sequence          int64            `gorm:"not null" json:"sequence"`
+	CompanyId         uuid.UUID        `gorm:"type:uuid;not null;index;uniqueIndex:idx_invoices_company_number,priority:1" json:"company_id"`
+	Company           Company          `gorm:"foreignKey:CompanyId" swaggerignore:"true" json:"company"`
+	ProviderId        uuid.UUID        `gorm:"type:uuid;not null;index" json:"provider_id"`
+	Provider          Provider         `gorm:"foreignKey:ProviderId" swaggerignore:"true" json:"provider"`
+	Status            string           `gorm:"type:varchar(20);not null;default:'DRAFT'" json:"status"`
+	IssueDate         *time.Time       `gorm:"type:date;" json:"issue_date"`
+	DueDate           *time.Time       `gorm:"type:date;" json:"due_date"`
+	Total             float64          `gorm:"type:decimal(12,2);not null" json:"total"`
+	AmountPaid        float64          `gorm:"type:decimal(12,2);not null;default:0" json:"amount_paid"`
+	ShortPaid         float64          `gorm:"type:decimal(12,2);not null;default:0" json:"short_paid"`
+	ShortPayReason    string           `gorm:"type:varchar(255);not null;default:''" json:"short_pay_reason"`
+	ShortPaidAt       *time.Time       `gorm:"type:date;" json:"short_paid_at"`
+	Factored          bool             `gorm:"not null;default:false" json:"factored"`
+	FactoringCompany  string           `gorm:"type:varchar(90);not null;default:''" json:"factoring_company"`
+	FactoredAt        *time.Time       `gorm:"type:date;" json:"factored_at"`
+	AdvanceAmount     float64          `gorm:"type:decimal(12,2);not null;default:0" json:"advance_amount"`
+	ReserveAmount     float64          `gorm:"type:decimal(12,2);not null;default:0" json:"reserve_amount"`
+	FactoringFee      float64          `gorm:"type:decimal(12,2);not null;default:0" json:"factoring_fee"`
+	ReserveReleasedAt *time.Time       `gorm:"type:date;" json:"reserve_released_at"`
+	Notes             string           `gorm:"type:text;not null;default:''" json:"notes"`
+	EmployeeId        uuid.UUID        `gorm:"type:uuid;not null" json:"employee_id"`
+	Lines             []InvoiceLine    `gorm:"foreignKey:InvoiceId" json:"lines"`
+	Payments          []InvoicePayment `gorm:"foreignKey:InvoiceId" json:"payments"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt   `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// Balance is what the provider still owes on the invoice. ShortPaid is the part
+// of the total the provider refused to pay and that was written off.
+func (i *Invoice) Balance() float64 {
+	return i.Total - i.AmountPaid - i.ShortPaid
+}
+
+type InvoiceLine struct {
+	Id            uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	InvoiceId     uuid.UUID      `gorm:"type:uuid;not null;index" json:"invoice_id"`
+	TransactionId *uuid.UUID     `gorm:"type:uuid;" json:"transaction_id"`
+	Type          string         `gorm:"type:varchar(20);not null" json:"type"`
+	Description   string         `gorm:"type:varchar(255);not null" json:"description"`
+	Amount        float64        `gorm:"type:decimal(12,2);not null" json:"amount"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+type InvoicePayment struct {
+	Id         uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	InvoiceId  uuid.UUID      `gorm:"type:uuid;not null;index" json:"invoice_id"`
+	Amount     float64        `gorm:"type:decimal(12,2);not null" json:"amount"`
+	PaidAt     time.Time      `gorm:"type:date;not null" json:"paid_at"`
+	Method     string         `gorm:"type:varchar(30);not null;default:''" json:"method"`
+	Reference  string         `gorm:"type:varchar(90);not null;default:''" json:"reference"`
+	EmployeeId uuid.UUID      `gorm:"type:uuid;not null" json:"employee_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// InvoiceCounter holds the last invoice sequence issued by a company.
+type InvoiceCounter struct {
+	CompanyId    uuid.UUID `gorm:"primary_key;type:uuid;" json:"company_id"`
+	LastSequence int64     `gorm:"not null" json:"last_sequence"`
+}
+
+type GenerateInvoiceReq struct {
+	CompanyId      uuid.UUID     `json:"company_id"`
+	ProviderId     uuid.UUID     `json:"provider_id"`
+	TransactionIds []uuid.UUID   `json:"transaction_ids"`
+	Accessorials   []InvoiceLine `json:"accessorials"`
+	Notes          string        `json:"notes"`
+}
+
+type GetAllInvoicesReq struct {
+	Page       uint64    `json:"page"`
+	Limit      uint64    `json:"limit"`
+	CompanyId  uuid.UUID `json:"company_id"`
+	ProviderId uuid.UUID `json:"provider_id"`
+	Status     string    `json:"status"`
+	Number     string    `json:"number"`
+}
+
+type GetAllInvoicesResp struct {
+	Invoices []Invoice `json:"invoices"`
+	Count    int64     `json:"count"`
+}
+
+const (
+	LedgerEntryInvoice  = "INVOICE"
+	LedgerEntryPayment  = "PAYMENT"
+	LedgerEntryShortPay = "SHORT_PAY"
+)
+
+// ARAging splits the open balance of a provider by the age of its invoices in
+// days since they were issued.
+type ARAging struct {
+	ProviderId   uuid.UUID `json:"provider_id"`
+	ProviderName string    `json:"provider_name"`
+	Days0To30    float64   `gorm:"column:days_0_30" json:"days_0_30"`
+	Days31To60   float64   `gorm:"column:days_31_60" json:"days_31_60"`
+	Days61To90   float64   `gorm:"column:days_61_90" json:"days_61_90"`
+	Days90Plus   float64   `gorm:"column:days_90_plus" json:"days_90_plus"`
+	Total        float64   `json:"total"`
+	Invoices     int64     `json:"invoices"`
+}
+
+type GetARAgingReq struct {
+	CompanyId  uuid.UUID `json:"company_id"`
+	ProviderId uuid.UUID `json:"provider_id"`
+}
+
+type GetARAgingResp struct {
+	Providers []ARAging `json:"providers"`
+	Totals    ARAging   `json:"totals"`
+}
+
+type LedgerEntry struct {
+	Date          time.Time `json:"date"`
+	Type          string    `json:"type"`
+	InvoiceId     uuid.UUID `json:"invoice_id"`
+	InvoiceNumber string    `json:"invoice_number"`
+	Reference     string    `json:"reference"`
+	Debit         float64   `json:"debit"`
+	Credit        float64   `json:"credit"`
+	Balance       float64   `json:"balance"`
+}
+
+type ProviderLedger struct {
+	ProviderId uuid.UUID     `json:"provider_id"`
+	Entries    []LedgerEntry `json:"entries"`
+	Invoiced   float64       `json:"invoiced"`
+	Paid       float64       `json:"paid"`
+	ShortPaid  float64       `json:"short_paid"`
+	Balance    float64       `json:"balance"`
+	Factored   float64       `json:"factored"`
+	Reserve    float64       `json:"reserve"`
+}
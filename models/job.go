@@ -0,0 +1,52 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	JobRunRunning   = "RUNNING"
+	JobRunSucceeded = "SUCCEEDED"
+	JobRunFailed    = "FAILED"
+
+	JobTriggerSchedule = "SCHEDULE"
+	JobTriggerManual   = "MANUAL"
+)
+
+// JobRun is one execution of a background job. Instance is the host that ran
+// it and EmployeeId who triggered it by hand.
+type JobRun struct {
+	Id         uuid.UUID  `gorm:"primary_key;type:uuid;" json:"id"`
+	Job        string     `gorm:"type:varchar(60);not null;index:idx_job_runs_job_started_at,priority:1" json:"job"`
+	Trigger    string     `gorm:"type:varchar(20);not null" json:"trigger"`
+	Status     string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Instance   string     `gorm:"type:varchar(90);not null;default:''" json:"instance"`
+	EmployeeId *uuid.UUID `gorm:"type:uuid;" json:"employee_id"`
+	StartedAt  time.Time  `gorm:"type:timestamp;not null;index;index:idx_job_runs_job_started_at,priority:2,sort:desc" json:"started_at"`
+	FinishedAt *time.Time `gorm:"type:timestamp;" json:"finished_at"`
+	DurationMs int64      `gorm:"not null;default:0" json:"duration_ms"`
+	Error      string     `gorm:"type:text;not null;default:''" json:"error"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// JobInfo describes a registered job and its latest run.
+type JobInfo struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Every       string  `json:"every"`
+	Running     bool    `json:"running"`
+	LastRun     *JobRun `json:"last_run"`
+}
+
+type GetAllJobRunsReq struct {
+	Page   uint64 `json:"page"`
+	Limit  uint64 `json:"limit"`
+	Job    string `json:"job"`
+	Status string `json:"status"`
+}
+
+type GetAllJobRunsResp struct {
+	Runs  []JobRun `json:"runs"`
+	Count int64    `json:"count"`
+}
@@ -6,6 +6,19 @@ import (
 	"time"
 )
 
+const (
+	LogisticStatusETA = "ETA"
+	// LogisticStatusLate is where the late watcher moves loads whose ETA passed.
+	LogisticStatusLate = "ETA, WILL BE LATE"
+)
+
+// LogisticStatuses are the statuses a dispatcher can put a logistic in, in
+// the order of the board.
+var LogisticStatuses = []string{
+	"READY", "WILL BE READY", "READY AT HOME", "COVERED", "AT PU", LogisticStatusETA, "AT DEL",
+	"ETA WILL BE LATE", LogisticStatusLate, LogisticStatusTruckIssues, "CANCELLED", "AT HOME", "LET US KNOW",
+}
+
 type Logistic struct {
 	Id         uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
 	Post       bool           `gorm:"default:false;" json:"post"`
@@ -17,14 +30,24 @@ type Logistic struct {
 	State      string         `gorm:"type:varchar(90);not null;" json:"state"`
 	Location   string         `gorm:"type:varchar(90);not null;" json:"location"`
 	Emoji      string         `gorm:"type:varchar(30);not null; default: ''" json:"emoji"`
+	Flags      Flags          `gorm:"type:jsonb;not null;default:'[]'" json:"flags"`
 	Notion     string         `gorm:"type:varchar(255);not null; default: ''" json:"notion"`
 	CargoId    *uuid.UUID     `gorm:"type:uuid;" json:"cargo_id"`
 	Cargo      Cargo          `gorm:"foreignKey:CargoId;references:Id" swaggerignore:"true" json:"cargo"`
+	Version    int64          `gorm:"not null;default:1" json:"version"`
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
 }
 
+// LogisticConflictResp is the 409 of an update sent with an outdated version.
+// Current is the logistic as it is now, to merge the change into.
+type LogisticConflictResp struct {
+	ErrorMessage string    `json:"error_message"`
+	ErrorCode    string    `json:"error_code"`
+	Current      *Logistic `json:"current"`
+}
+
 type LogisticResponse struct {
 	Id             uuid.UUID  `json:"id"`
 	Post           bool       `json:"post"`
@@ -35,6 +58,7 @@ type LogisticResponse struct {
 	State          string     `json:"state"`
 	Location       string     `json:"location"`
 	Emoji          string     `json:"emoji"`
+	Flags          Flags      `json:"flags"`
 	Notion         string     `json:"notion"`
 	CargoId        *uuid.UUID `json:"cargo_id"`
 	DriverName     string     `json:"driver_name"`
@@ -44,20 +68,26 @@ type LogisticResponse struct {
 	Countdown      string     `json:"countdown"`
 	CompanyId      uuid.UUID  `json:"company_id"`
 	CompanyName    string     `json:"company_name"`
+	TruckNumber    string     `json:"truck_number"`
+	TrailerNumber  string     `json:"trailer_number"`
+	TrailerType    string     `json:"trailer_type"`
+	CurrentStop    *Stop      `gorm:"-" json:"current_stop"`
 	UpdatedAt      time.Time  `json:"updated_at"`
+	Version        int64      `json:"version"`
 }
 
 type GetAllLogisticsReq struct {
-	Page       uint64     `json:"page"`
-	Limit      uint64     `json:"limit"`
-	Post       string     `json:"post"`
-	Type       string     `json:"type"`
-	Position   string     `json:"position"`
-	State      string     `json:"state"`
-	Location   string     `json:"location"`
-	Name       string     `json:"name"`
-	Status     string     `json:"status"`
-	CompanyIds uuid.UUIDs `json:"company_ids"`
+	Page        uint64     `json:"page"`
+	Limit       uint64     `json:"limit"`
+	Post        string     `json:"post"`
+	Type        string     `json:"type"`
+	Position    string     `json:"position"`
+	State       string     `json:"state"`
+	Location    string     `json:"location"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	TrailerType string     `json:"trailer_type"`
+	CompanyIds  uuid.UUIDs `json:"company_ids"`
 }
 
 type ByCompany struct {
@@ -79,5 +109,6 @@ type GetOverview struct {
 		WillBeSoonDrivers int64
 		OccupiedDrivers   int64
 		NotWorking        int64
+		Overdue           int64
 	} `json:"companies"`
 }
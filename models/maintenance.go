@@ -0,0 +1,138 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	ServiceOilChange  = "OIL_CHANGE"
+	ServicePM         = "PM"
+	ServiceTires      = "TIRES"
+	ServiceBrakes     = "BRAKES"
+	ServiceInspection = "INSPECTION"
+	ServiceRepair     = "REPAIR"
+	ServiceOther      = "OTHER"
+
+	// LogisticStatusTruckIssues opens a downtime record, LogisticStatusReady closes it.
+	LogisticStatusTruckIssues = "TRUCK ISSUES"
+	LogisticStatusReady       = "READY"
+)
+
+type MaintenanceRecord struct {
+	Id          uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	TruckId     uuid.UUID      `gorm:"type:uuid;not null;index" json:"truck_id"`
+	Truck       Truck          `gorm:"foreignKey:TruckId" swaggerignore:"true" json:"truck"`
+	ServiceType string         `gorm:"type:varchar(20);not null" json:"service_type"`
+	Description string         `gorm:"type:varchar(255);not null;default:''" json:"description"`
+	Odometer    int64          `gorm:"not null;default:0" json:"odometer"`
+	Cost        float64        `gorm:"type:decimal(10,2);not null;default:0" json:"cost"`
+	Shop        string         `gorm:"type:varchar(90);not null;default:''" json:"shop"`
+	StartedAt   time.Time      `gorm:"type:date;not null" json:"started_at"`
+	CompletedAt *time.Time     `gorm:"type:date;" json:"completed_at"`
+	EmployeeId  uuid.UUID      `gorm:"type:uuid;not null" json:"employee_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// MaintenanceSchedule repeats a service every IntervalMiles and/or IntervalDays
+// counted from the last time it was done.
+type MaintenanceSchedule struct {
+	Id            uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	TruckId       uuid.UUID      `gorm:"type:uuid;not null;index" json:"truck_id"`
+	Truck         Truck          `gorm:"foreignKey:TruckId" swaggerignore:"true" json:"truck"`
+	ServiceType   string         `gorm:"type:varchar(20);not null" json:"service_type"`
+	IntervalMiles int64          `gorm:"not null;default:0" json:"interval_miles"`
+	IntervalDays  int            `gorm:"not null;default:0" json:"interval_days"`
+	LastOdometer  int64          `gorm:"not null;default:0" json:"last_odometer"`
+	LastDate      *time.Time     `gorm:"type:date;" json:"last_date"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// Downtime is a period during which a driver's truck was out of service,
+// opened when the board goes to TRUCK ISSUES and closed when it is READY again.
+type Downtime struct {
+	Id          uuid.UUID  `gorm:"primary_key;type:uuid;" json:"id"`
+	TruckId     *uuid.UUID `gorm:"type:uuid;index" json:"truck_id"`
+	TruckNumber string     `gorm:"type:varchar(20);not null" json:"truck_number"`
+	DriverId    uuid.UUID  `gorm:"type:uuid;not null;index" json:"driver_id"`
+	CompanyId   uuid.UUID  `gorm:"type:uuid;not null;index" json:"company_id"`
+	LogisticId  uuid.UUID  `gorm:"type:uuid;not null" json:"logistic_id"`
+	Reason      string     `gorm:"type:varchar(255);not null;default:''" json:"reason"`
+	StartedAt   time.Time  `gorm:"type:timestamp;not null" json:"started_at"`
+	EndedAt     *time.Time `gorm:"type:timestamp;" json:"ended_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type MaintenanceDue struct {
+	Schedule    MaintenanceSchedule `json:"schedule"`
+	TruckNumber string              `json:"truck_number"`
+	Odometer    int64               `json:"odometer"`
+	DueDate     *time.Time          `json:"due_date"`
+	DueOdometer *int64              `json:"due_odometer"`
+	DaysLeft    *int                `json:"days_left"`
+	MilesLeft   *int64              `json:"miles_left"`
+	Overdue     bool                `json:"overdue"`
+}
+
+type GetAllMaintenanceReq struct {
+	Page        uint64    `json:"page"`
+	Limit       uint64    `json:"limit"`
+	TruckId     uuid.UUID `json:"truck_id"`
+	ServiceType string    `json:"service_type"`
+}
+
+type GetAllMaintenanceRecordsResp struct {
+	Records []MaintenanceRecord `json:"records"`
+	Count   int64               `json:"count"`
+}
+
+type GetAllMaintenanceSchedulesResp struct {
+	Schedules []MaintenanceSchedule `json:"schedules"`
+	Count     int64                 `json:"count"`
+}
+
+type GetAllDowntimesReq struct {
+	Page      uint64    `json:"page"`
+	Limit     uint64    `json:"limit"`
+	TruckId   uuid.UUID `json:"truck_id"`
+	CompanyId uuid.UUID `json:"company_id"`
+	Open      bool      `json:"open"`
+}
+
+type GetAllDowntimesResp struct {
+	Downtimes []Downtime `json:"downtimes"`
+	Count     int64      `json:"count"`
+}
+
+type DowntimeReportReq struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	CompanyId uuid.UUID `json:"company_id"`
+}
+
+type TruckDowntime struct {
+	TruckNumber string    `json:"truck_number"`
+	CompanyId   uuid.UUID `json:"company_id"`
+	Events      int64     `json:"events"`
+	Hours       float64   `json:"hours"`
+}
+
+type CompanyDowntime struct {
+	CompanyId   uuid.UUID `json:"company_id"`
+	CompanyName string    `json:"company_name"`
+	Events      int64     `json:"events"`
+	Hours       float64   `json:"hours"`
+}
+
+type DowntimeReport struct {
+	From      time.Time         `json:"from"`
+	To        time.Time         `json:"to"`
+	Trucks    []TruckDowntime   `json:"trucks"`
+	Companies []CompanyDowntime `json:"companies"`
+}
@@ -1,16 +0,0 @@
-package models
-
-import "gorm.io/gorm"
-
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&Company{},
-		&Driver{},
-		&Logistic{},
-		&Employee{},
-		&Cargo{},
-		&Transaction{},
-		&Performance{},
-		&History{},
-	)
-}
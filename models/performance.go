@@ -6,6 +6,8 @@ import (
 	"time"
 )
 
+// Performance is an incident on a load. Draft ones were opened by the backend
+// and wait for the dispatcher to fill in what happened.
 type Performance struct {
 	Id         uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
 	Reason     string         `gorm:"type:varchar(255);" json:"reason"`
@@ -17,6 +19,7 @@ type Performance struct {
 	CompanyId  uuid.UUID      `gorm:"type:uuid;not null;" json:"company_id"`
 	Company    Company        `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" swaggerignore:"true" json:"company"`
 	LoadId     string         `gorm:"type:varchar(255); not null;" json:"load_id"`
+	Draft      bool           `gorm:"not null;default:false" json:"draft"`
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
@@ -30,6 +33,7 @@ type GetAllPerformancesReq struct {
 	Status     string    `json:"status"`
 	Section    string    `json:"section"`
 	EmployeeId uuid.UUID `json:"employee_id"`
+	Draft      string    `json:"draft"`
 }
 
 type GetAllPerformancesResp struct {
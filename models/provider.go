@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Provider struct {
+	Id             uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	Name           string         `gorm:"type:varchar(90);not null;" json:"name"`
+	NormalizedName string         `gorm:"type:varchar(90);not null;uniqueIndex:idx_providers_normalized_name,where:deleted_at IS NULL" json:"normalized_name"`
+	MC             string         `gorm:"type:varchar(20);not null;default:''" json:"mc"`
+	DOT            string         `gorm:"type:varchar(20);not null;default:''" json:"dot"`
+	ContactName    string         `gorm:"type:varchar(90);not null;default:''" json:"contact_name"`
+	ContactPhone   string         `gorm:"type:varchar(20);not null;default:''" json:"contact_phone"`
+	ContactEmail   string         `gorm:"type:varchar(90);not null;default:''" json:"contact_email"`
+	PaymentTerms   int            `gorm:"type:int;not null;default:30" json:"payment_terms"`
+	CreditNotes    string         `gorm:"type:text;not null;default:''" json:"credit_notes"`
+	Blacklisted    bool           `gorm:"not null;default:false" json:"blacklisted"`
+	Aging          *ARAging       `gorm:"-" json:"aging,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+type GetAllProvidersReq struct {
+	Page        uint64 `json:"page"`
+	Limit       uint64 `json:"limit"`
+	Search      string `json:"search"`
+	Blacklisted string `json:"blacklisted"`
+}
+
+type GetAllProvidersResp struct {
+	Providers []Provider `json:"providers"`
+	Count     int64      `json:"count"`
+}
+
+// ProviderStats sums up the transactions of a provider. AverageRPM is the
+// gross of its delivered loads per loaded mile, so long loads weigh more.
+type ProviderStats struct {
+	ProviderId       uuid.UUID `json:"provider_id"`
+	Loads            int64     `json:"loads"`
+	CancelledLoads   int64     `json:"cancelled_loads"`
+	Gross            int64     `json:"gross"`
+	AverageRPM       float64   `json:"average_rpm"`
+	CancellationRate float64   `json:"cancellation_rate"`
+}
@@ -7,6 +7,6 @@ type RequestId struct {
 }
 
 type AuthReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
 }
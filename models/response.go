@@ -1,12 +1,15 @@
 package models
 
+import "backend/etc/apperr"
+
 type ResponseId struct {
 	Id string `json:"id"`
 }
 
 type ResponseError struct {
-	ErrorMessage string `json:"error_message"`
-	ErrorCode    string `json:"error_code"`
+	ErrorMessage string              `json:"error_message"`
+	ErrorCode    string              `json:"error_code"`
+	Fields       []apperr.FieldError `json:"fields,omitempty"`
 }
 
 type ResponseSuccess struct {
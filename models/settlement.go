@@ -0,0 +1,122 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	PayMethodCPM     = "CPM"
+	PayMethodPercent = "PERCENT"
+
+	SettlementStatusDraft  = "DRAFT"
+	SettlementStatusLocked = "LOCKED"
+
+	SettlementLineLoad          = "LOAD"
+	SettlementLineDeduction     = "DEDUCTION"
+	SettlementLineAdvance       = "ADVANCE"
+	SettlementLineReimbursement = "REIMBURSEMENT"
+)
+
+// PayProfile describes how a driver is paid: a rate per loaded and per empty
+// mile (CPM) or a percentage of the gross of each load (PERCENT).
+type PayProfile struct {
+	Id         uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	DriverId   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"driver_id"`
+	Driver     Driver         `gorm:"foreignKey:DriverId" swaggerignore:"true" json:"driver"`
+	Method     string         `gorm:"type:varchar(20);not null" json:"method"`
+	LoadedRate float64        `gorm:"type:decimal(10,4);not null;default:0" json:"loaded_rate"`
+	EmptyRate  float64        `gorm:"type:decimal(10,4);not null;default:0" json:"empty_rate"`
+	Percent    float64        `gorm:"type:decimal(5,2);not null;default:0" json:"percent"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// SettlementAdjustment is a deduction, advance or reimbursement of a driver.
+// One-time adjustments go on the first unlocked settlement on or after Date;
+// recurring ones are applied every week from Date until EndDate.
+type SettlementAdjustment struct {
+	Id           uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	DriverId     uuid.UUID      `gorm:"type:uuid;not null;index" json:"driver_id"`
+	Type         string         `gorm:"type:varchar(20);not null" json:"type"`
+	Description  string         `gorm:"type:varchar(255);not null" json:"description"`
+	Amount       float64        `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Date         time.Time      `gorm:"type:date;not null" json:"date"`
+	Recurring    bool           `gorm:"not null;default:false" json:"recurring"`
+	EndDate      *time.Time     `gorm:"type:date;" json:"end_date"`
+	SettlementId *uuid.UUID     `gorm:"type:uuid;index" json:"settlement_id"`
+	EmployeeId   uuid.UUID      `gorm:"type:uuid;not null" json:"employee_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+type Settlement struct {
+	Id             uuid.UUID        `gorm:"primary_key;type:uuid;" json:"id"`
+	DriverId       uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_settlements_driver_week" json:"driver_id"`
+	Driver         Driver           `gorm:"foreignKey:DriverId" json:"driver"`
+	WeekStart      time.Time        `gorm:"type:date;not null;uniqueIndex:idx_settlements_driver_week" json:"week_start"`
+	WeekEnd        time.Time        `gorm:"type:date;not null" json:"week_end"`
+	Status         string           `gorm:"type:varchar(20);not null;default:'DRAFT'" json:"status"`
+	Method         string           `gorm:"type:varchar(20);not null" json:"method"`
+	Loads          int64            `gorm:"not null" json:"loads"`
+	LoadedMiles    int64            `gorm:"not null" json:"loaded_miles"`
+	EmptyMiles     int64            `gorm:"not null" json:"empty_miles"`
+	Gross          float64          `gorm:"type:decimal(12,2);not null" json:"gross"`
+	Pay            float64          `gorm:"type:decimal(12,2);not null" json:"pay"`
+	Deductions     float64          `gorm:"type:decimal(12,2);not null" json:"deductions"`
+	Advances       float64          `gorm:"type:decimal(12,2);not null" json:"advances"`
+	Reimbursements float64          `gorm:"type:decimal(12,2);not null" json:"reimbursements"`
+	NetPay         float64          `gorm:"type:decimal(12,2);not null" json:"net_pay"`
+	LockedAt       *time.Time       `json:"locked_at"`
+	LockedBy       *uuid.UUID       `gorm:"type:uuid;" json:"locked_by"`
+	Lines          []SettlementLine `gorm:"foreignKey:SettlementId" json:"lines,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt   `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+type SettlementLine struct {
+	Id            uuid.UUID  `gorm:"primary_key;type:uuid;" json:"id"`
+	SettlementId  uuid.UUID  `gorm:"type:uuid;not null;index" json:"settlement_id"`
+	TransactionId *uuid.UUID `gorm:"type:uuid;index" json:"transaction_id"`
+	AdjustmentId  *uuid.UUID `gorm:"type:uuid;" json:"adjustment_id"`
+	Type          string     `gorm:"type:varchar(20);not null" json:"type"`
+	Description   string     `gorm:"type:varchar(255);not null" json:"description"`
+	Miles         int64      `gorm:"not null;default:0" json:"miles"`
+	Amount        float64    `gorm:"type:decimal(12,2);not null" json:"amount"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type SettlementRunResp struct {
+	WeekStart   time.Time    `json:"week_start"`
+	Settlements []Settlement `json:"settlements"`
+	Skipped     []string     `json:"skipped"`
+}
+
+type GetAllSettlementsReq struct {
+	Page      uint64     `json:"page"`
+	Limit     uint64     `json:"limit"`
+	DriverId  uuid.UUID  `json:"driver_id"`
+	WeekStart *time.Time `json:"week_start"`
+	Status    string     `json:"status"`
+}
+
+type GetAllSettlementsResp struct {
+	Settlements []Settlement `json:"settlements"`
+	Count       int64        `json:"count"`
+}
+
+type GetAllAdjustmentsReq struct {
+	Page     uint64    `json:"page"`
+	Limit    uint64    `json:"limit"`
+	DriverId uuid.UUID `json:"driver_id"`
+	Pending  bool      `json:"pending"`
+}
+
+type GetAllAdjustmentsResp struct {
+	Adjustments []SettlementAdjustment `json:"adjustments"`
+	Count       int64                  `json:"count"`
+}
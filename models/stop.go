@@ -0,0 +1,80 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	StopTypePickup   = "PICKUP"
+	StopTypeDelivery = "DELIVERY"
+
+	StopStatusPending   = "PENDING"
+	StopStatusArrived   = "ARRIVED"
+	StopStatusCompleted = "COMPLETED"
+)
+
+var StopStatuses = []string{StopStatusPending, StopStatusArrived, StopStatusCompleted}
+
+type Stop struct {
+	Id              uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	CargoId         uuid.UUID      `gorm:"type:uuid;not null;index" json:"cargo_id"`
+	Sequence        int            `gorm:"type:int;not null" json:"sequence"`
+	Type            string         `gorm:"type:varchar(20);not null" json:"type"`
+	Location        string         `gorm:"type:varchar(90);not null" json:"location"`
+	AppointmentTime time.Time      `gorm:"type:timestamp;not null" json:"appointment_time"`
+	Status          string         `gorm:"type:varchar(20);not null;default:'PENDING'" json:"status"`
+	ArrivedAt       *time.Time     `gorm:"type:timestamp;" json:"arrived_at"`
+	CompletedAt     *time.Time     `gorm:"type:timestamp;" json:"completed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+func (Stop) TableName() string {
+	return "cargo_stops"
+}
+
+type JSONBStop struct {
+	Sequence        int       `json:"sequence"`
+	Type            string    `json:"type"`
+	Location        string    `json:"location"`
+	AppointmentTime time.Time `json:"appointment_time"`
+	Status          string    `json:"status"`
+}
+
+type JSONBStops []JSONBStop
+
+func NewJSONBStops(stops []Stop) JSONBStops {
+	resp := make(JSONBStops, 0, len(stops))
+	for _, stop := range stops {
+		resp = append(resp, JSONBStop{
+			Sequence:        stop.Sequence,
+			Type:            stop.Type,
+			Location:        stop.Location,
+			AppointmentTime: stop.AppointmentTime,
+			Status:          stop.Status,
+		})
+	}
+
+	return resp
+}
+
+func (j *JSONBStops) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+	}
+	return json.Unmarshal(bytes, j)
+}
+
+func (j JSONBStops) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return json.Marshal(j)
+}
@@ -0,0 +1,18 @@
+package swag
+
+type CreateUpdateAlertRule struct {
+	Name       string `json:"name" binding:"required"`
+	Kind       string `json:"kind" binding:"required"`
+	Statuses   string `json:"statuses"`
+	Threshold  int    `json:"threshold" binding:"gte=0"`
+	Severity   string `json:"severity"`
+	Channels   string `json:"channels"`
+	Recipients string `json:"recipients"`
+	WebhookURL string `json:"webhook_url" binding:"omitempty,url"`
+	Cooldown   int    `json:"cooldown" binding:"gte=0"`
+	Enabled    bool   `json:"enabled"`
+}
+
+type SnoozeAlert struct {
+	Minutes int `json:"minutes" binding:"gt=0"`
+}
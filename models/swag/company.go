@@ -1,11 +1,11 @@
 package swag
 
 type CreateUpdateCompany struct {
-	Name      string `json:"name"`
-	Address   string `json:"address"`
-	Number    string `json:"number"`
-	SCAC      string `json:"scac"`
-	DOT       int    `json:"dot"`
-	MC        int    `json:"mc"`
-	StartDate string `json:"start_date"`
+	Name      string `json:"name" binding:"required"`
+	Address   string `json:"address" binding:"required"`
+	Number    string `json:"number" binding:"required,phone"`
+	SCAC      string `json:"scac" binding:"required"`
+	DOT       int    `json:"dot" binding:"required,dot"`
+	MC        int    `json:"mc" binding:"required,mc"`
+	StartDate string `json:"start_date" binding:"omitempty,date"`
 }
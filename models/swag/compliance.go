@@ -0,0 +1,14 @@
+package swag
+
+type SaveDriverCompliance struct {
+	CDLNumber         string `json:"cdl_number"`
+	CDLState          string `json:"cdl_state" binding:"omitempty,len=2,alpha"`
+	CDLClass          string `json:"cdl_class" binding:"omitempty,oneof=A B C a b c"`
+	CDLExpiry         string `json:"cdl_expiry" binding:"omitempty,date"`
+	Endorsements      string `json:"endorsements"`
+	MedicalCardExpiry string `json:"medical_card_expiry" binding:"omitempty,date"`
+	LastDrugTest      string `json:"last_drug_test" binding:"omitempty,date"`
+	NextDrugTest      string `json:"next_drug_test" binding:"omitempty,date"`
+	MVRReviewedAt     string `json:"mvr_reviewed_at" binding:"omitempty,date"`
+	MVRNextReview     string `json:"mvr_next_review" binding:"omitempty,date"`
+}
@@ -1,14 +1,14 @@
 package swag
 
 type CreateUpdateDriver struct {
-	Name        string `json:"name"`
-	Surname     string `json:"surname"`
-	TruckNumber string `json:"truck_number"`
-	PhoneNumber string `json:"phone_number"`
-	Mail        string `json:"mail"`
-	Birthday    string `json:"birthday"`
-	CompanyId   string `json:"company_id"`
-	StartDate   string `json:"start_date"`
-	Type        string `json:"type"`
-	Position    string `json:"position"`
+	Name        string `json:"name" binding:"required"`
+	Surname     string `json:"surname" binding:"required"`
+	TruckNumber string `json:"truck_number" binding:"required"`
+	PhoneNumber string `json:"phone_number" binding:"omitempty,phone"`
+	Mail        string `json:"mail" binding:"required,email"`
+	Birthday    string `json:"birthday" binding:"required,date"`
+	CompanyId   string `json:"company_id" binding:"required,uuid"`
+	StartDate   string `json:"start_date" binding:"omitempty,date"`
+	Type        string `json:"type" binding:"required,oneof=SOLO TEAM"`
+	Position    string `json:"position" binding:"required,oneof=CO OW"`
 }
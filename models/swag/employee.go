@@ -1,16 +1,16 @@
 package swag
 
 type CreateUpdateEmployee struct {
-	Name        string `json:"name"`
-	Surname     string `json:"surname"`
-	Username    string `json:"username"`
+	Name        string `json:"name" binding:"required"`
+	Surname     string `json:"surname" binding:"required"`
+	Username    string `json:"username" binding:"required"`
 	Password    string `json:"password"`
 	LogoId      string `json:"logo_id"`
 	Position    string `json:"position"`
-	AccessLevel int64  `json:"access_level"`
-	Email       string `json:"email"`
-	PhoneNumber string `json:"phone_number"`
+	AccessLevel int64  `json:"access_level" binding:"gte=0"`
+	Email       string `json:"email" binding:"omitempty,email"`
+	PhoneNumber string `json:"phone_number" binding:"omitempty,phone"`
 	Company     string `json:"company"`
-	Birthday    string `json:"birthday"`
-	StartDate   string `json:"start_date"`
+	Birthday    string `json:"birthday" binding:"omitempty,date"`
+	StartDate   string `json:"start_date" binding:"omitempty,date"`
 }
@@ -0,0 +1,36 @@
+package swag
+
+type CreateUpdateTruck struct {
+	CompanyId          string `json:"company_id" binding:"required,uuid"`
+	Number             string `json:"number" binding:"required,max=20"`
+	VIN                string `json:"vin" binding:"required,len=17"`
+	Make               string `json:"make" binding:"max=30"`
+	Model              string `json:"model" binding:"max=30"`
+	Year               int    `json:"year" binding:"gte=0"`
+	Plate              string `json:"plate" binding:"max=20"`
+	PlateState         string `json:"plate_state" binding:"omitempty,len=2,alpha"`
+	RegistrationExpiry string `json:"registration_expiry" binding:"omitempty,date"`
+	Ownership          string `json:"ownership" binding:"omitempty,oneof=COMPANY OWNER_OPERATOR LEASED"`
+	Status             string `json:"status" binding:"omitempty,oneof=ACTIVE INACTIVE"`
+}
+
+type CreateUpdateTrailer struct {
+	CompanyId          string `json:"company_id" binding:"required,uuid"`
+	Number             string `json:"number" binding:"required,max=20"`
+	VIN                string `json:"vin" binding:"required,len=17"`
+	Type               string `json:"type" binding:"required,oneof=DRY_VAN REEFER FLATBED STEP_DECK TANKER OTHER"`
+	Length             int    `json:"length" binding:"gte=0"`
+	Make               string `json:"make" binding:"max=30"`
+	Year               int    `json:"year" binding:"gte=0"`
+	Plate              string `json:"plate" binding:"max=20"`
+	PlateState         string `json:"plate_state" binding:"omitempty,len=2,alpha"`
+	RegistrationExpiry string `json:"registration_expiry" binding:"omitempty,date"`
+	Ownership          string `json:"ownership" binding:"omitempty,oneof=COMPANY OWNER_OPERATOR LEASED"`
+	Status             string `json:"status" binding:"omitempty,oneof=ACTIVE INACTIVE"`
+}
+
+type AssignEquipment struct {
+	TruckId   string `json:"truck_id" binding:"omitempty,uuid"`
+	TrailerId string `json:"trailer_id" binding:"omitempty,uuid"`
+	Notes     string `json:"notes"`
+}
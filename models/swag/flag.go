@@ -0,0 +1,12 @@
+package swag
+
+type CreateUpdateFlagRule struct {
+	Name      string   `json:"name" binding:"required"`
+	Condition string   `json:"condition" binding:"required"`
+	Statuses  []string `json:"statuses"`
+	Threshold int      `json:"threshold" binding:"gte=0"`
+	Icon      string   `json:"icon" binding:"required"`
+	Priority  int      `json:"priority"`
+	CompanyId string   `json:"company_id" binding:"omitempty,uuid"`
+	Enabled   bool     `json:"enabled"`
+}
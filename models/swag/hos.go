@@ -0,0 +1,9 @@
+package swag
+
+type SaveDriverHOS struct {
+	DriveLeft   float64 `json:"drive_left" binding:"gte=0"`
+	OnDutyLeft  float64 `json:"on_duty_left" binding:"gte=0"`
+	CycleLeft   float64 `json:"cycle_left" binding:"gte=0"`
+	ELDDriverId string  `json:"eld_driver_id"`
+	ReportedAt  string  `json:"reported_at" binding:"omitempty,datetime"`
+}
@@ -0,0 +1,41 @@
+package swag
+
+type GenerateInvoice struct {
+	CompanyId      string               `json:"company_id" binding:"required,uuid"`
+	ProviderId     string               `json:"provider_id" binding:"required,uuid"`
+	TransactionIds []string             `json:"transaction_ids" binding:"required,min=1,unique,dive,uuid"`
+	Accessorials   []InvoiceAccessorial `json:"accessorials" binding:"dive"`
+	Notes          string               `json:"notes"`
+}
+
+type InvoiceAccessorial struct {
+	TransactionId string  `json:"transaction_id" binding:"omitempty,uuid"`
+	Type          string  `json:"type" binding:"required,oneof=DETENTION LAYOVER LUMPER TONU OTHER"`
+	Description   string  `json:"description"`
+	Amount        float64 `json:"amount" binding:"gt=0"`
+}
+
+type UpdateInvoiceStatus struct {
+	Status string `json:"status" binding:"required,oneof=SENT VOID"`
+}
+
+type CreateInvoicePayment struct {
+	Amount    float64 `json:"amount" binding:"gt=0"`
+	PaidAt    string  `json:"paid_at" binding:"required,date"`
+	Method    string  `json:"method"`
+	Reference string  `json:"reference"`
+}
+
+type ShortPayInvoice struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type UpdateInvoiceFactoring struct {
+	Factored          bool    `json:"factored"`
+	FactoringCompany  string  `json:"factoring_company"`
+	FactoredAt        string  `json:"factored_at" binding:"omitempty,date"`
+	AdvanceAmount     float64 `json:"advance_amount" binding:"gte=0"`
+	ReserveAmount     float64 `json:"reserve_amount" binding:"gte=0"`
+	FactoringFee      float64 `json:"factoring_fee" binding:"gte=0"`
+	ReserveReleasedAt string  `json:"reserve_released_at" binding:"omitempty,date"`
+}
@@ -1,48 +1,67 @@
 package swag
 
 type CreateUpdateLogistic struct {
-	DriverId string `json:"driver_id"`
-	CargoId  string `json:"cargo_id"`
-	Status   string `json:"status"`
-	StTime   string `json:"st_time"`
-	Location string `json:"location"`
-	Notion   string `json:"notion"`
+	DriverId string `json:"driver_id" binding:"omitempty,uuid"`
+	CargoId  string `json:"cargo_id" binding:"required,uuid"`
+	Status   string `json:"status" binding:"required,logistic_status"`
+	StTime   string `json:"st_time" binding:"required,datetime"`
+	Location string `json:"location" binding:"required,location"`
+	Notion   string `json:"notion" binding:"max=255"`
 	Post     bool   `json:"post"`
 }
 
+// UpdateLogisticWithCargo makes the update of the cargo conditional on
+// CargoVersion, as If-Match does for the logistic. 0 skips the check.
 type UpdateLogisticWithCargo struct {
-	LogisticId   string  `json:"logistic_id"`
-	Status       string  `json:"status"`
-	CargoId      string  `json:"cargo_id"`
-	Notion       string  `json:"notion"`
-	StTime       string  `json:"st_time"`
-	Location     string  `json:"location"`
-	Post         bool    `json:"post"`
-	LoadId       string  `json:"load_id"`
-	Provider     string  `json:"provider"`
-	LoadedMiles  int64   `json:"loaded_miles"`
-	FreeMiles    int64   `json:"free_miles"`
-	From         string  `json:"from"`
-	To           string  `json:"to"`
-	Cost         int64   `json:"cost"`
-	Rate         float64 `json:"rate"`
-	PickUpTime   string  `json:"pick_up_time"`
-	DeliveryTime string  `json:"delivery_time"`
-	EmployeeId   string  `json:"employee_id"`
-	Create       bool    `json:"create"`
+	LogisticId        string      `json:"logistic_id" binding:"omitempty,uuid"`
+	Status            string      `json:"status" binding:"required,logistic_status"`
+	CargoId           string      `json:"cargo_id" binding:"omitempty,uuid"`
+	CargoVersion      int64       `json:"cargo_version" binding:"gte=0"`
+	Notion            string      `json:"notion" binding:"max=255"`
+	StTime            string      `json:"st_time" binding:"required,datetime"`
+	Location          string      `json:"location" binding:"required,location"`
+	Post              bool        `json:"post"`
+	LoadId            string      `json:"load_id"`
+	Provider          string      `json:"provider"`
+	ProviderId        string      `json:"provider_id" binding:"omitempty,uuid"`
+	LoadedMiles       int64       `json:"loaded_miles" binding:"gte=0"`
+	FreeMiles         int64       `json:"free_miles" binding:"gte=0"`
+	From              string      `json:"from"`
+	To                string      `json:"to"`
+	Cost              int64       `json:"cost" binding:"gte=0"`
+	Rate              float64     `json:"rate" binding:"gte=0"`
+	PickUpTime        string      `json:"pick_up_time" binding:"omitempty,datetime"`
+	DeliveryTime      string      `json:"delivery_time" binding:"omitempty,datetime"`
+	EmployeeId        string      `json:"employee_id" binding:"omitempty,uuid"`
+	Create            bool        `json:"create"`
+	OverrideDuplicate bool        `json:"override_duplicate"`
+	OverrideHOS       bool        `json:"override_hos"`
+	Stops             []CargoStop `json:"stops" binding:"dive"`
+}
+
+type CargoStop struct {
+	Id              string `json:"id" binding:"omitempty,uuid"`
+	Type            string `json:"type" binding:"required"`
+	Location        string `json:"location" binding:"required"`
+	AppointmentTime string `json:"appointment_time" binding:"required,datetime"`
+	Status          string `json:"status" binding:"omitempty,oneof=PENDING ARRIVED COMPLETED pending arrived completed"`
+}
+
+type UpdateStopStatus struct {
+	Status string `json:"status" binding:"required,oneof=PENDING ARRIVED COMPLETED pending arrived completed"`
 }
 
 type TerminateLogistic struct {
-	LogisticId string `json:"logistic_id"`
+	LogisticId string `json:"logistic_id" binding:"required,uuid"`
 	Success    bool   `json:"success"`
 }
 
 type CancelLogistic struct {
-	LogisticId string `json:"logistic_id"`
+	LogisticId string `json:"logistic_id" binding:"required,uuid"`
 	Cancel     bool   `json:"cancel"`
-	WhoseFault string `json:"whose_fault"`
-	Status     string `json:"status"`
-	Section    string `json:"section"`
-	Reason     string `json:"reason"`
-	CompanyId  string `json:"company_id"`
+	WhoseFault string `json:"whose_fault" binding:"required,oneof=Dispatcher Driver Company"`
+	Status     string `json:"status" binding:"required,oneof=success canceled"`
+	Section    string `json:"section" binding:"required"`
+	Reason     string `json:"reason" binding:"required"`
+	CompanyId  string `json:"company_id" binding:"required,uuid"`
 }
@@ -0,0 +1,21 @@
+package swag
+
+type CreateUpdateMaintenanceRecord struct {
+	TruckId     string  `json:"truck_id" binding:"required,uuid"`
+	ServiceType string  `json:"service_type" binding:"required,oneof=OIL_CHANGE PM TIRES BRAKES INSPECTION REPAIR OTHER"`
+	Description string  `json:"description"`
+	Odometer    int64   `json:"odometer" binding:"gte=0"`
+	Cost        float64 `json:"cost" binding:"gte=0"`
+	Shop        string  `json:"shop"`
+	StartedAt   string  `json:"started_at" binding:"required,date"`
+	CompletedAt string  `json:"completed_at" binding:"omitempty,date"`
+}
+
+type CreateUpdateMaintenanceSchedule struct {
+	TruckId       string `json:"truck_id" binding:"required,uuid"`
+	ServiceType   string `json:"service_type" binding:"required,oneof=OIL_CHANGE PM TIRES BRAKES INSPECTION REPAIR OTHER"`
+	IntervalMiles int64  `json:"interval_miles" binding:"gte=0"`
+	IntervalDays  int    `json:"interval_days" binding:"gte=0"`
+	LastOdometer  int64  `json:"last_odometer" binding:"gte=0"`
+	LastDate      string `json:"last_date" binding:"omitempty,date"`
+}
@@ -1,11 +1,11 @@
 package swag
 
 type CreateUpdatePerformance struct {
-	Reason     string `json:"reason"`
-	WhoseFault string `json:"whose_fault"`
-	Status     string `json:"status"`
-	Section    string `json:"section"`
-	EmployeeId string `json:"employee_id"`
-	CompanyId  string `json:"company_id"`
+	Reason     string `json:"reason" binding:"required"`
+	WhoseFault string `json:"whose_fault" binding:"required,oneof=Dispatcher Driver Company"`
+	Status     string `json:"status" binding:"required,oneof=success canceled"`
+	Section    string `json:"section" binding:"required"`
+	EmployeeId string `json:"employee_id" binding:"required,uuid"`
+	CompanyId  string `json:"company_id" binding:"required,uuid"`
 	LoadId     string `json:"load_id"`
 }
@@ -0,0 +1,13 @@
+package swag
+
+type CreateUpdateProvider struct {
+	Name         string `json:"name" binding:"required"`
+	MC           string `json:"mc" binding:"omitempty,mc"`
+	DOT          string `json:"dot" binding:"omitempty,dot"`
+	ContactName  string `json:"contact_name"`
+	ContactPhone string `json:"contact_phone" binding:"omitempty,phone"`
+	ContactEmail string `json:"contact_email" binding:"omitempty,email"`
+	PaymentTerms int    `json:"payment_terms" binding:"gte=0"`
+	CreditNotes  string `json:"credit_notes"`
+	Blacklisted  bool   `json:"blacklisted"`
+}
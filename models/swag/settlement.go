@@ -0,0 +1,22 @@
+package swag
+
+type SavePayProfile struct {
+	Method     string  `json:"method" binding:"required,oneof=CPM PERCENT"`
+	LoadedRate float64 `json:"loaded_rate" binding:"required_if=Method CPM,gte=0"`
+	EmptyRate  float64 `json:"empty_rate" binding:"gte=0"`
+	Percent    float64 `json:"percent" binding:"required_if=Method PERCENT,gte=0,lte=100"`
+}
+
+type CreateSettlementAdjustment struct {
+	DriverId    string  `json:"driver_id" binding:"required,uuid"`
+	Type        string  `json:"type" binding:"required,oneof=DEDUCTION ADVANCE REIMBURSEMENT"`
+	Description string  `json:"description" binding:"required"`
+	Amount      float64 `json:"amount" binding:"gt=0"`
+	Date        string  `json:"date" binding:"required,date"`
+	Recurring   bool    `json:"recurring"`
+	EndDate     string  `json:"end_date" binding:"omitempty,date"`
+}
+
+type RunSettlements struct {
+	WeekStart string `json:"week_start" binding:"required,date"`
+}
@@ -1,17 +1,37 @@
 package swag
 
 type CreateUpdateTransaction struct {
-	From         string  `json:"from"`
-	To           string  `json:"to"`
-	PuTime       string  `json:"pu_time"`
-	DeliveryTime string  `json:"delivery_time"`
+	From         string  `json:"from" binding:"required"`
+	To           string  `json:"to" binding:"required"`
+	PuTime       string  `json:"pu_time" binding:"required,datetime"`
+	DeliveryTime string  `json:"delivery_time" binding:"required,datetime"`
 	Success      bool    `json:"success"`
-	LoadedMiles  int64   `json:"loaded_miles"`
-	TotalMiles   int64   `json:"total_miles"`
+	LoadedMiles  int64   `json:"loaded_miles" binding:"gte=0"`
+	TotalMiles   int64   `json:"total_miles" binding:"gte=0"`
 	Provider     string  `json:"provider"`
-	Cost         int64   `json:"cost"`
-	Rate         float64 `json:"rate"`
-	DriverId     string  `json:"driver_id"`
-	EmployeeId   string  `json:"employee_id"`
+	ProviderId   string  `json:"provider_id" binding:"omitempty,uuid"`
+	Cost         int64   `json:"cost" binding:"gte=0"`
+	Rate         float64 `json:"rate" binding:"gte=0"`
+	DriverId     string  `json:"driver_id" binding:"required,uuid"`
+	EmployeeId   string  `json:"employee_id" binding:"required,uuid"`
+	CargoID      string  `json:"cargo_id"`
+}
+
+// UpdateTransaction takes the times as RFC 3339, like the transactions are
+// read back, unlike CreateUpdateTransaction.
+type UpdateTransaction struct {
+	From         string  `json:"from" binding:"required"`
+	To           string  `json:"to" binding:"required"`
+	PuTime       string  `json:"pu_time" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	DeliveryTime string  `json:"delivery_time" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Success      bool    `json:"success"`
+	LoadedMiles  int64   `json:"loaded_miles" binding:"gte=0"`
+	TotalMiles   int64   `json:"total_miles" binding:"gte=0"`
+	Provider     string  `json:"provider"`
+	ProviderId   string  `json:"provider_id" binding:"omitempty,uuid"`
+	Cost         int64   `json:"cost" binding:"gte=0"`
+	Rate         float64 `json:"rate" binding:"gte=0"`
+	DriverId     string  `json:"driver_id" binding:"required,uuid"`
+	EmployeeId   string  `json:"employee_id" binding:"required,uuid"`
 	CargoID      string  `json:"cargo_id"`
 }
@@ -0,0 +1,10 @@
+package swag
+
+type CreateUpdateWebhookSubscription struct {
+	Name       string `json:"name" binding:"required"`
+	URL        string `json:"url" binding:"required,url"`
+	Secret     string `json:"secret"`
+	EventTypes string `json:"event_types"`
+	CompanyId  string `json:"company_id" binding:"omitempty,uuid"`
+	Active     bool   `json:"active"`
+}
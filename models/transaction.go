@@ -14,7 +14,8 @@ type Transaction struct {
 	DeliveryTime time.Time      `gorm:"type:timestamp;not null" json:"delivery_time"`
 	LoadedMiles  int64          `gorm:"type:int;not null" json:"loaded_miles"`
 	TotalMiles   int64          `gorm:"type:int;not null" json:"total_miles"`
-	Provider     string         `gorm:"type:varchar(50);not null" json:"provider"`
+	Provider     string         `gorm:"type:varchar(90);not null" json:"provider"`
+	ProviderId   *uuid.UUID     `gorm:"type:uuid;index" json:"provider_id"`
 	Cost         int64          `gorm:"type:int;not null" json:"cost"`
 	Rate         float64        `gorm:"type:decimal(10,2);not null" json:"rate"`
 	DriverId     uuid.UUID      `gorm:"type:uuid;not null" json:"driver_id"`
@@ -22,20 +23,23 @@ type Transaction struct {
 	EmployeeId   uuid.UUID      `gorm:"type:uuid;not null" json:"employee_id"`
 	Employee     Employee       `gorm:"foreignKey:EmployeeId" swaggerignore:"true" json:"employee"`
 	CargoID      string         `gorm:"type:varchar(90); not null" json:"cargo_id"`
+	Stops        JSONBStops     `gorm:"type:jsonb;" json:"stops"`
 	Success      bool           `gorm:"not null" json:"success"`
+	InvoiceId    *uuid.UUID     `gorm:"type:uuid;index" json:"invoice_id"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
 }
 
 type GetAllTransReq struct {
-	Page           uint64 `json:"page"`
-	Limit          uint64 `json:"limit"`
-	CargoID        string `json:"cargo_id"`
-	Provider       string `json:"provider"`
-	DriverName     string `json:"driver_name"`
-	DispatcherName string `json:"dispatcher_name"`
-	Success        string `json:"success"`
+	Page           uint64    `json:"page"`
+	Limit          uint64    `json:"limit"`
+	CargoID        string    `json:"cargo_id"`
+	Provider       string    `json:"provider"`
+	ProviderId     uuid.UUID `json:"provider_id"`
+	DriverName     string    `json:"driver_name"`
+	DispatcherName string    `json:"dispatcher_name"`
+	Success        string    `json:"success"`
 }
 
 type GetAllTransResp struct {
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const (
+	EventLoadCovered   = "load.covered"
+	EventLoadPickedUp  = "load.picked_up"
+	EventLoadDelivered = "load.delivered"
+	EventLoadCancelled = "load.cancelled"
+
+	DeliveryStatusPending   = "PENDING"
+	DeliveryStatusSucceeded = "SUCCEEDED"
+	DeliveryStatusFailed    = "FAILED"
+)
+
+// WebhookSubscription receives the events of the listed types, comma
+// separated, or all of them when EventTypes is empty. A nil CompanyId
+// subscribes to every company.
+type WebhookSubscription struct {
+	Id         uuid.UUID      `gorm:"primary_key;type:uuid;" json:"id"`
+	Name       string         `gorm:"type:varchar(90);not null" json:"name"`
+	URL        string         `gorm:"column:url;type:varchar(255);not null" json:"url"`
+	Secret     string         `gorm:"type:varchar(100);not null" json:"-"`
+	EventTypes string         `gorm:"type:varchar(255);not null;default:''" json:"event_types"`
+	CompanyId  *uuid.UUID     `gorm:"type:uuid;" json:"company_id"`
+	Active     bool           `gorm:"not null" json:"active"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+}
+
+// Wants reports whether the subscription receives the event.
+func (s *WebhookSubscription) Wants(event *WebhookEvent) bool {
+	if s.CompanyId != nil && *s.CompanyId != event.CompanyId {
+		return false
+	}
+
+	types := splitList(s.EventTypes)
+	if len(types) == 0 {
+		return true
+	}
+	for _, eventType := range types {
+		if strings.EqualFold(eventType, event.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookEvent is a row of the outbox. It is written in the same transaction
+// as the change it describes and fanned out to the subscriptions afterwards.
+type WebhookEvent struct {
+	Id           uuid.UUID    `gorm:"primary_key;type:uuid;" json:"id"`
+	Type         string       `gorm:"type:varchar(30);not null;index" json:"type"`
+	CompanyId    uuid.UUID    `gorm:"type:uuid;not null" json:"company_id"`
+	Payload      EventPayload `gorm:"type:jsonb;not null" json:"payload"`
+	OccurredAt   time.Time    `gorm:"type:timestamp;not null" json:"occurred_at"`
+	DispatchedAt *time.Time   `gorm:"type:timestamp;index" json:"dispatched_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// EventPayload describes the load an event is about.
+type EventPayload struct {
+	LogisticId   uuid.UUID  `json:"logistic_id"`
+	DriverId     uuid.UUID  `json:"driver_id"`
+	DriverName   string     `json:"driver_name"`
+	TruckNumber  string     `json:"truck_number"`
+	Status       string     `json:"status"`
+	CargoId      *uuid.UUID `json:"cargo_id"`
+	LoadId       string     `json:"load_id"`
+	Provider     string     `json:"provider"`
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	PickUpTime   time.Time  `json:"pick_up_time"`
+	DeliveryTime time.Time  `json:"delivery_time"`
+	Reason       string     `json:"reason,omitempty"`
+}
+
+func (p *EventPayload) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p EventPayload) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// WebhookDelivery is one event sent to one subscription, with the outcome of
+// its last attempt. Replaying a delivery creates a new one.
+type WebhookDelivery struct {
+	Id             uuid.UUID           `gorm:"primary_key;type:uuid;" json:"id"`
+	EventId        uuid.UUID           `gorm:"type:uuid;not null;index" json:"event_id"`
+	Event          WebhookEvent        `gorm:"foreignKey:EventId" swaggerignore:"true" json:"event"`
+	SubscriptionId uuid.UUID           `gorm:"type:uuid;not null;index" json:"subscription_id"`
+	Subscription   WebhookSubscription `gorm:"foreignKey:SubscriptionId" swaggerignore:"true" json:"subscription"`
+	Status         string              `gorm:"type:varchar(20);not null;default:'PENDING';index" json:"status"`
+	Attempts       int                 `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time           `gorm:"type:timestamp;not null" json:"next_attempt_at"`
+	LastStatusCode int                 `gorm:"not null;default:0" json:"last_status_code"`
+	LastError      string              `gorm:"type:varchar(255);not null;default:''" json:"last_error"`
+	DeliveredAt    *time.Time          `gorm:"type:timestamp;" json:"delivered_at"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+type GetAllDeliveriesReq struct {
+	Page           uint64    `json:"page"`
+	Limit          uint64    `json:"limit"`
+	SubscriptionId uuid.UUID `json:"subscription_id"`
+	EventId        uuid.UUID `json:"event_id"`
+	Status         string    `json:"status"`
+}
+
+type GetAllDeliveriesResp struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Count      int64             `json:"count"`
+}
+
+type CreateSubscriptionResp struct {
+	Id     string `json:"id"`
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestWebhookSubscriptionWants(t *testing.T) {
+	var (
+		company = uuid.New()
+		other   = uuid.New()
+		event   = WebhookEvent{Type: EventLoadDelivered, CompanyId: company}
+	)
+
+	tests := []struct {
+		name         string
+		subscription WebhookSubscription
+		want         bool
+	}{
+		{name: "every event", subscription: WebhookSubscription{}, want: true},
+		{name: "listed type", subscription: WebhookSubscription{EventTypes: "load.covered, load.delivered"}, want: true},
+		{name: "listed in other case", subscription: WebhookSubscription{EventTypes: "LOAD.DELIVERED"}, want: true},
+		{name: "other types", subscription: WebhookSubscription{EventTypes: "load.covered,load.cancelled"}},
+		{name: "same company", subscription: WebhookSubscription{CompanyId: &company}, want: true},
+		{name: "other company", subscription: WebhookSubscription{CompanyId: &other, EventTypes: EventLoadDelivered}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.subscription.Wants(&event); got != tt.want {
+			t.Errorf("%s: got %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
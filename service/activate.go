@@ -1,17 +1,31 @@
 package service
 
 import (
+	"backend/etc/jobs"
 	"backend/service/services"
 	database "backend/st_database"
 )
 
-func New(store database.IStore) IService {
+func New(store database.IStore, runner *jobs.Runner) IService {
 	return &Service{
 		companyService:     services.NewCompanyService(store),
 		driverService:      services.NewDriverService(store),
 		employeeService:    services.NewEmployeeService(store),
 		logisticService:    services.NewLogisticService(store),
+		providerService:    services.NewProviderService(store),
+		cargoService:       services.NewCargoService(store),
 		transactionService: services.NewTransactionService(store),
+		invoiceService:     services.NewInvoiceService(store),
+		settlementService:  services.NewSettlementService(store),
+		complianceService:  services.NewComplianceService(store),
+		hosService:         services.NewHOSService(store),
+		equipmentService:   services.NewEquipmentService(store),
+		maintenanceService: services.NewMaintenanceService(store),
+		alertService:       services.NewAlertService(store),
+		webhookService:     services.NewWebhookService(store),
+		flagRuleService:    services.NewFlagRuleService(store),
+		jobService:         services.NewJobService(store, runner),
+		healthService:      services.NewHealthService(store, runner),
 		performanceService: services.NewPerformanceService(store),
 		historyService:     services.NewHistoryService(store),
 	}
@@ -25,8 +39,34 @@ func (s *Service) Employee() *services.EmployeeService { return s.employeeServic
 
 func (s *Service) Logistic() *services.LogisticService { return s.logisticService }
 
+func (s *Service) Provider() *services.ProviderService { return s.providerService }
+
+func (s *Service) Cargo() *services.CargoService { return s.cargoService }
+
 func (s *Service) Transaction() *services.TransactionService { return s.transactionService }
 
+func (s *Service) Invoice() *services.InvoiceService { return s.invoiceService }
+
+func (s *Service) Settlement() *services.SettlementService { return s.settlementService }
+
+func (s *Service) Compliance() *services.ComplianceService { return s.complianceService }
+
+func (s *Service) HOS() *services.HOSService { return s.hosService }
+
+func (s *Service) Equipment() *services.EquipmentService { return s.equipmentService }
+
+func (s *Service) Maintenance() *services.MaintenanceService { return s.maintenanceService }
+
+func (s *Service) Alert() *services.AlertService { return s.alertService }
+
+func (s *Service) Webhook() *services.WebhookService { return s.webhookService }
+
+func (s *Service) FlagRule() *services.FlagRuleService { return s.flagRuleService }
+
+func (s *Service) Job() *services.JobService { return s.jobService }
+
+func (s *Service) Health() *services.HealthService { return s.healthService }
+
 func (s *Service) Performance() *services.PerformanceService { return s.performanceService }
 
 func (s *Service) History() *services.HistoryService { return s.historyService }
@@ -7,7 +7,20 @@ type IService interface {
 	Driver() *services.DriverService
 	Employee() *services.EmployeeService
 	Logistic() *services.LogisticService
+	Provider() *services.ProviderService
+	Cargo() *services.CargoService
 	Transaction() *services.TransactionService
+	Invoice() *services.InvoiceService
+	Settlement() *services.SettlementService
+	Compliance() *services.ComplianceService
+	HOS() *services.HOSService
+	Equipment() *services.EquipmentService
+	Maintenance() *services.MaintenanceService
+	Alert() *services.AlertService
+	Webhook() *services.WebhookService
+	FlagRule() *services.FlagRuleService
+	Job() *services.JobService
+	Health() *services.HealthService
 	Performance() *services.PerformanceService
 	History() *services.HistoryService
 }
@@ -17,7 +30,20 @@ type Service struct {
 	driverService      *services.DriverService
 	employeeService    *services.EmployeeService
 	logisticService    *services.LogisticService
+	providerService    *services.ProviderService
+	cargoService       *services.CargoService
 	transactionService *services.TransactionService
+	invoiceService     *services.InvoiceService
+	settlementService  *services.SettlementService
+	complianceService  *services.ComplianceService
+	hosService         *services.HOSService
+	equipmentService   *services.EquipmentService
+	maintenanceService *services.MaintenanceService
+	alertService       *services.AlertService
+	webhookService     *services.WebhookService
+	flagRuleService    *services.FlagRuleService
+	jobService         *services.JobService
+	healthService      *services.HealthService
 	performanceService *services.PerformanceService
 	historyService     *services.HistoryService
 }
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"time"
+)
+
+type AlertService struct {
+	store database.IStore
+}
+
+func NewAlertService(store database.IStore) *AlertService {
+	return &AlertService{store: store}
+}
+
+func (s *AlertService) CreateRule(ctx context.Context, rule *models.AlertRule) (string, error) {
+	ctx, span := tracer.Start(ctx, "AlertService.CreateRule")
+	defer span.End()
+
+	if err := validateAlertRule(rule); err != nil {
+		return "", err
+	}
+
+	id, err := s.store.Alert().CreateRule(ctx, rule)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *AlertService) UpdateRule(ctx context.Context, rule *models.AlertRule) error {
+	ctx, span := tracer.Start(ctx, "AlertService.UpdateRule")
+	defer span.End()
+
+	if err := validateAlertRule(rule); err != nil {
+		return err
+	}
+
+	return s.store.Alert().UpdateRule(ctx, rule)
+}
+
+func (s *AlertService) DeleteRule(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "AlertService.DeleteRule")
+	defer span.End()
+
+	return s.store.Alert().DeleteRule(ctx, req)
+}
+
+func (s *AlertService) GetRule(ctx context.Context, req models.RequestId) (*models.AlertRule, error) {
+	ctx, span := tracer.Start(ctx, "AlertService.GetRule")
+	defer span.End()
+
+	rule, err := s.store.Alert().GetRule(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *AlertService) GetRules(ctx context.Context) ([]models.AlertRule, error) {
+	ctx, span := tracer.Start(ctx, "AlertService.GetRules")
+	defer span.End()
+
+	rules, err := s.store.Alert().GetRules(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *AlertService) Get(ctx context.Context, req models.RequestId) (*models.Alert, error) {
+	ctx, span := tracer.Start(ctx, "AlertService.Get")
+	defer span.End()
+
+	alert, err := s.store.Alert().GetAlert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (s *AlertService) GetAll(ctx context.Context, req models.GetAllAlertsReq) (*models.GetAllAlertsResp, error) {
+	ctx, span := tracer.Start(ctx, "AlertService.GetAll")
+	defer span.End()
+
+	resp, err := s.store.Alert().GetAllAlerts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Acknowledge stops an open alert from being sent again until it resolves.
+func (s *AlertService) Acknowledge(ctx context.Context, req models.RequestId, by models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "AlertService.Acknowledge")
+	defer span.End()
+
+	return s.store.Alert().Acknowledge(ctx, req.Id, by.Id, Utime.Now())
+}
+
+// Snooze holds back notifications of an alert for the given minutes.
+func (s *AlertService) Snooze(ctx context.Context, req models.RequestId, minutes int) error {
+	ctx, span := tracer.Start(ctx, "AlertService.Snooze")
+	defer span.End()
+
+	if minutes <= 0 {
+		return apperr.Validation(apperr.CodeValidation, "minutes must be positive")
+	}
+
+	return s.store.Alert().Snooze(ctx, req.Id, Utime.Now().Add(time.Duration(minutes)*time.Minute))
+}
+
+func validateAlertRule(rule *models.AlertRule) error {
+	if rule.Name == "" {
+		return apperr.Validation(apperr.CodeValidation, "name can't be empty")
+	}
+
+	switch rule.Kind {
+	case models.AlertKindStatusDuration, models.AlertKindETAPassed:
+		if len(rule.StatusList()) == 0 {
+			return apperr.Validation(apperr.CodeValidation, "rule of kind %s needs statuses", rule.Kind)
+		}
+	case models.AlertKindETANearPickup, models.AlertKindLateLoad, models.AlertKindDocumentExpiring:
+	default:
+		return apperr.Validation(apperr.CodeValidation, "invalid kind: %s", rule.Kind)
+	}
+
+	if rule.Threshold < 0 || rule.Cooldown < 0 {
+		return apperr.Validation(apperr.CodeValidation, "threshold and cooldown can't be negative")
+	}
+
+	switch rule.Severity {
+	case "":
+		rule.Severity = models.AlertSeverityWarning
+	case models.AlertSeverityInfo, models.AlertSeverityWarning, models.AlertSeverityCritical:
+	default:
+		return apperr.Validation(apperr.CodeValidation, "invalid severity: %s", rule.Severity)
+	}
+
+	if rule.Channels == "" {
+		rule.Channels = models.AlertChannelInbox
+	}
+	for _, channel := range rule.ChannelList() {
+		switch channel {
+		case models.AlertChannelInbox:
+		case models.AlertChannelEmail:
+			if len(rule.RecipientList()) == 0 {
+				return apperr.Validation(apperr.CodeValidation, "email channel needs recipients")
+			}
+		case models.AlertChannelWebhook:
+			if rule.WebhookURL == "" {
+				return apperr.Validation(apperr.CodeValidation, "webhook channel needs a webhook URL")
+			}
+		default:
+			return apperr.Validation(apperr.CodeValidation, "invalid channel: %s", channel)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"backend/models"
+	database "backend/st_database"
+	"context"
+)
+
+type CargoService struct {
+	store database.IStore
+}
+
+func NewCargoService(store database.IStore) *CargoService {
+	return &CargoService{store: store}
+}
+
+func (s *CargoService) Get(ctx context.Context, req models.RequestId) (*models.CargoResponse, error) {
+	ctx, span := tracer.Start(ctx, "CargoService.Get")
+	defer span.End()
+
+	resp, err := s.store.Cargo().GetWithDetails(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *CargoService) GetAll(ctx context.Context, req models.GetAllCargosReq) (*models.GetAllCargosResp, error) {
+	ctx, span := tracer.Start(ctx, "CargoService.GetAll")
+	defer span.End()
+
+	resp, err := s.store.Cargo().GetAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
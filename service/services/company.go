@@ -15,6 +15,9 @@ func NewCompanyService(store database.IStore) *CompanyService {
 }
 
 func (s *CompanyService) Create(ctx context.Context, company *models.Company) (string, error) {
+	ctx, span := tracer.Start(ctx, "CompanyService.Create")
+	defer span.End()
+
 	id, err := s.store.Company().Create(ctx, company)
 	if err != nil {
 		return "", err
@@ -24,14 +27,23 @@ func (s *CompanyService) Create(ctx context.Context, company *models.Company) (s
 }
 
 func (s *CompanyService) Update(ctx context.Context, company *models.Company) error {
+	ctx, span := tracer.Start(ctx, "CompanyService.Update")
+	defer span.End()
+
 	return s.store.Company().Update(ctx, company)
 }
 
 func (s *CompanyService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "CompanyService.Delete")
+	defer span.End()
+
 	return s.store.Company().Delete(ctx, req)
 }
 
 func (s *CompanyService) Get(ctx context.Context, req models.RequestId) (*models.Company, error) {
+	ctx, span := tracer.Start(ctx, "CompanyService.Get")
+	defer span.End()
+
 	company, err := s.store.Company().Get(ctx, req)
 	if err != nil {
 		return nil, err
@@ -41,6 +53,9 @@ func (s *CompanyService) Get(ctx context.Context, req models.RequestId) (*models
 }
 
 func (s *CompanyService) GetAll(ctx context.Context, req models.GetAllCompaniesReq) (*models.GetAllCompaniesResp, error) {
+	ctx, span := tracer.Start(ctx, "CompanyService.GetAll")
+	defer span.End()
+
 	resp, err := s.store.Company().GetAll(ctx, req)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ComplianceError is returned by UpdateWithCargo when the driver has expired
+// documents and must not be dispatched.
+type ComplianceError struct {
+	DriverName string
+	Documents  []string
+}
+
+func (e *ComplianceError) Error() string {
+	return fmt.Sprintf("driver %s has expired documents: %s", e.DriverName, strings.Join(e.Documents, ", "))
+}
+
+func (e *ComplianceError) AppError() *apperr.Error {
+	return &apperr.Error{Kind: apperr.KindConflict, Code: "DRIVER_NOT_COMPLIANT", Message: e.Error(), Err: e}
+}
+
+type ComplianceService struct {
+	store database.IStore
+}
+
+func NewComplianceService(store database.IStore) *ComplianceService {
+	return &ComplianceService{store: store}
+}
+
+func (s *ComplianceService) Save(ctx context.Context, compliance *models.DriverCompliance) error {
+	ctx, span := tracer.Start(ctx, "ComplianceService.Save")
+	defer span.End()
+
+	now := Utime.Now()
+	compliance.Evaluate(now, models.ComplianceWarnDays)
+	compliance.CheckedAt = &now
+
+	return s.store.Compliance().Save(ctx, compliance)
+}
+
+func (s *ComplianceService) Get(ctx context.Context, driverId uuid.UUID) (*models.DriverCompliance, error) {
+	ctx, span := tracer.Start(ctx, "ComplianceService.Get")
+	defer span.End()
+
+	compliance, err := s.store.Compliance().GetByDriver(ctx, driverId)
+	if err != nil {
+		return nil, err
+	}
+
+	return compliance, nil
+}
+
+// Expiring lists the drivers with documents expiring in the next days days,
+// including the ones already expired.
+func (s *ComplianceService) Expiring(ctx context.Context, days int) (*models.GetExpiringComplianceResp, error) {
+	ctx, span := tracer.Start(ctx, "ComplianceService.Expiring")
+	defer span.End()
+
+	now := Utime.Now()
+	records, err := s.store.Compliance().GetExpiring(ctx, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.GetExpiringComplianceResp{Drivers: []models.ExpiringCompliance{}}
+	for _, record := range records {
+		documents := record.Expiring(now, days)
+		if len(documents) == 0 {
+			continue
+		}
+
+		resp.Drivers = append(resp.Drivers, models.ExpiringCompliance{
+			DriverId:   record.DriverId,
+			DriverName: record.Driver.FullName(),
+			Compliance: record,
+			Documents:  documents,
+		})
+	}
+	resp.Count = int64(len(resp.Drivers))
+
+	return &resp, nil
+}
+
+// checkCompliance blocks dispatching a driver with expired documents and
+// returns warnings for documents that expire soon. Drivers without a
+// compliance record are not checked.
+func checkCompliance(ctx context.Context, store database.IStore, driver models.Driver, tx *gorm.DB) ([]string, error) {
+	compliance, err := store.Compliance().GetByDriver(ctx, driver.Id, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := Utime.Now()
+	if expired := compliance.Expired(now); len(expired) > 0 {
+		return nil, &ComplianceError{DriverName: driver.FullName(), Documents: expired}
+	}
+
+	var warnings []string
+	for _, d := range compliance.Expiring(now, models.ComplianceWarnDays) {
+		warnings = append(warnings, fmt.Sprintf("%s of the driver expires in %d day(s)", d.Document, d.DaysLeft))
+	}
+
+	return warnings, nil
+}
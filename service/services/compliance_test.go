@@ -0,0 +1,91 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestCheckCompliance(t *testing.T) {
+	var (
+		driver = models.Driver{Id: uuid.New(), Name: "John", Surname: "Smith"}
+		now    = Utime.Now()
+		day    = func(days int) *time.Time {
+			date := time.Date(now.Year(), now.Month(), now.Day()+days, 0, 0, 0, 0, time.UTC)
+			return &date
+		}
+	)
+
+	tests := []struct {
+		name         string
+		record       *models.DriverCompliance
+		wantExpired  []string
+		wantWarnings []string
+	}{
+		{
+			name: "no compliance record",
+		},
+		{
+			name:   "record of another driver",
+			record: &models.DriverCompliance{DriverId: uuid.New(), CDLExpiry: day(-1)},
+		},
+		{
+			name:   "current documents",
+			record: &models.DriverCompliance{DriverId: driver.Id, CDLExpiry: day(365), MedicalCardExpiry: day(31)},
+		},
+		{
+			name:         "expiring documents warn",
+			record:       &models.DriverCompliance{DriverId: driver.Id, CDLExpiry: day(0), MedicalCardExpiry: day(12)},
+			wantWarnings: []string{"CDL of the driver expires in 0 day(s)", "MEDICAL_CARD of the driver expires in 12 day(s)"},
+		},
+		{
+			name:        "expired documents block",
+			record:      &models.DriverCompliance{DriverId: driver.Id, CDLExpiry: day(5), NextDrugTest: day(-1), MVRNextReview: day(-30)},
+			wantExpired: []string{models.DocumentDrugTest, models.DocumentMVR},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore(t, models.Logistic{}, nil)
+			store.compliance.record = tt.record
+
+			warnings, err := checkCompliance(context.Background(), store, driver, nil)
+
+			if tt.wantExpired != nil {
+				var complianceErr *ComplianceError
+				if !errors.As(err, &complianceErr) {
+					t.Fatalf("got %v, want a compliance error", err)
+				}
+				if complianceErr.DriverName != "John Smith" || len(complianceErr.Documents) != len(tt.wantExpired) {
+					t.Fatalf("got %v, want %q expired", err, tt.wantExpired)
+				}
+				for i := range tt.wantExpired {
+					if complianceErr.Documents[i] != tt.wantExpired[i] {
+						t.Errorf("got %q expired, want %q", complianceErr.Documents, tt.wantExpired)
+					}
+				}
+				if code := complianceErr.AppError().Code; code != "DRIVER_NOT_COMPLIANT" {
+					t.Errorf("got code %q", code)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("checkCompliance: %v", err)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("got warnings %q, want %q", warnings, tt.wantWarnings)
+			}
+			for i := range tt.wantWarnings {
+				if warnings[i] != tt.wantWarnings[i] {
+					t.Errorf("got warning %q, want %q", warnings[i], tt.wantWarnings[i])
+				}
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@ func NewDriverService(store database.IStore) *DriverService {
 }
 
 func (s *DriverService) Create(ctx context.Context, driver *models.Driver) (string, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.Create")
+	defer span.End()
+
 	var id string
-	db := s.store.DB()
+	db := s.store.DB().WithContext(ctx)
 	err := db.Transaction(func(tx *gorm.DB) error {
 		idA, err := s.store.Driver().Create(ctx, driver, tx)
 		if err != nil {
@@ -46,6 +49,9 @@ func (s *DriverService) Create(ctx context.Context, driver *models.Driver) (stri
 }
 
 func (s *DriverService) Update(ctx context.Context, driver *models.Driver) error {
+	ctx, span := tracer.Start(ctx, "DriverService.Update")
+	defer span.End()
+
 	err := s.store.Driver().Update(ctx, driver)
 	if err != nil {
 		return err
@@ -55,6 +61,9 @@ func (s *DriverService) Update(ctx context.Context, driver *models.Driver) error
 }
 
 func (s *DriverService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "DriverService.Delete")
+	defer span.End()
+
 	err := s.store.Driver().Delete(ctx, req)
 	if err != nil {
 		return err
@@ -64,6 +73,9 @@ func (s *DriverService) Delete(ctx context.Context, req models.RequestId) error
 }
 
 func (s *DriverService) Get(ctx context.Context, req models.RequestId) (*models.Driver, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.Get")
+	defer span.End()
+
 	driver, err := s.store.Driver().Get(ctx, req)
 	if err != nil {
 		return nil, err
@@ -73,6 +85,9 @@ func (s *DriverService) Get(ctx context.Context, req models.RequestId) (*models.
 }
 
 func (s *DriverService) GetAll(ctx context.Context, req models.GetAllDriversReq) (*models.GetAllDriversResp, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.GetAll")
+	defer span.End()
+
 	resp, err := s.store.Driver().GetAll(ctx, req)
 	if err != nil {
 		return resp, err
@@ -1,12 +1,14 @@
 package services
 
 import (
+	"backend/etc/apperr"
 	"backend/etc/helpers"
 	"backend/etc/jwt"
 	"backend/models"
 	database "backend/st_database"
 	"context"
 	"errors"
+	"gorm.io/gorm"
 )
 
 type EmployeeService struct {
@@ -20,6 +22,9 @@ func NewEmployeeService(store database.IStore) *EmployeeService {
 }
 
 func (s *EmployeeService) Create(ctx context.Context, req *models.Employee) (string, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.Create")
+	defer span.End()
+
 	id, err := s.store.Employee().Create(ctx, req)
 	if err != nil {
 		return id, err
@@ -29,6 +34,9 @@ func (s *EmployeeService) Create(ctx context.Context, req *models.Employee) (str
 }
 
 func (s *EmployeeService) Update(ctx context.Context, req *models.Employee) error {
+	ctx, span := tracer.Start(ctx, "EmployeeService.Update")
+	defer span.End()
+
 	err := s.store.Employee().Update(ctx, req)
 	if err != nil {
 		return err
@@ -38,6 +46,9 @@ func (s *EmployeeService) Update(ctx context.Context, req *models.Employee) erro
 }
 
 func (s *EmployeeService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "EmployeeService.Delete")
+	defer span.End()
+
 	err := s.store.Employee().Delete(ctx, req)
 	if err != nil {
 		return err
@@ -47,6 +58,9 @@ func (s *EmployeeService) Delete(ctx context.Context, req models.RequestId) erro
 }
 
 func (s *EmployeeService) Get(ctx context.Context, req models.RequestId) (*models.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.Get")
+	defer span.End()
+
 	employee, err := s.store.Employee().Get(ctx, req)
 	if err != nil {
 		return nil, err
@@ -56,6 +70,9 @@ func (s *EmployeeService) Get(ctx context.Context, req models.RequestId) (*model
 }
 
 func (s *EmployeeService) GetAll(ctx context.Context, req models.GetAllEmployeesReq) (*models.GetAllEmployeesResp, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.GetAll")
+	defer span.End()
+
 	resp, err := s.store.Employee().GetAll(ctx, req)
 	if err != nil {
 		return nil, err
@@ -65,6 +82,9 @@ func (s *EmployeeService) GetAll(ctx context.Context, req models.GetAllEmployees
 }
 
 func (s *EmployeeService) Auth(ctx context.Context, req models.AuthReq) (models.AuthResp, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.Auth")
+	defer span.End()
+
 	var resp models.AuthResp
 
 	if req.Username == "admin1234" && req.Password == "admin1234" {
@@ -77,16 +97,17 @@ func (s *EmployeeService) Auth(ctx context.Context, req models.AuthReq) (models.
 		return resp, nil
 	}
 	employee, err := s.store.Employee().GetByUsername(ctx, req.Username)
-	if err != nil {
-		return resp, errors.New("did not find employee")
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return resp, apperr.Unauthorized("INVALID_CREDENTIALS", "invalid username or password")
+	} else if err != nil {
+		return resp, err
 	}
 
+	// A password that is not a bcrypt hash, like the one of the system
+	// employee, matches nothing.
 	matched, err := helpers.CheckPassword(req.Password, employee.Password)
-	if err != nil {
-		return resp, err
-	}
-	if !matched {
-		return resp, errors.New("password not match")
+	if err != nil || !matched {
+		return resp, apperr.Unauthorized("INVALID_CREDENTIALS", "invalid username or password")
 	}
 
 	resp.Token, err = jwt.GenerateToken(employee.Id.String(), employee.Username, int(employee.AccessLevel))
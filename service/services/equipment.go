@@ -0,0 +1,233 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EquipmentService struct {
+	store database.IStore
+}
+
+func NewEquipmentService(store database.IStore) *EquipmentService {
+	return &EquipmentService{store: store}
+}
+
+func (s *EquipmentService) CreateTruck(ctx context.Context, truck *models.Truck) (string, error) {
+	ctx, span := tracer.Start(ctx, "EquipmentService.CreateTruck")
+	defer span.End()
+
+	id, err := s.store.Equipment().CreateTruck(ctx, truck)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *EquipmentService) UpdateTruck(ctx context.Context, truck *models.Truck) error {
+	ctx, span := tracer.Start(ctx, "EquipmentService.UpdateTruck")
+	defer span.End()
+
+	return s.store.Equipment().UpdateTruck(ctx, truck)
+}
+
+func (s *EquipmentService) DeleteTruck(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "EquipmentService.DeleteTruck")
+	defer span.End()
+
+	drivers, err := s.store.Equipment().AssignedDrivers(ctx, &req.Id, nil)
+	if err != nil {
+		return err
+	}
+	if len(drivers) > 0 {
+		return apperr.Conflict("TRUCK_ASSIGNED", "truck is assigned to %s", drivers[0].FullName())
+	}
+
+	return s.store.Equipment().DeleteTruck(ctx, req)
+}
+
+func (s *EquipmentService) GetTruck(ctx context.Context, req models.RequestId) (*models.Truck, error) {
+	ctx, span := tracer.Start(ctx, "EquipmentService.GetTruck")
+	defer span.End()
+
+	truck, err := s.store.Equipment().GetTruck(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return truck, nil
+}
+
+func (s *EquipmentService) GetAllTrucks(ctx context.Context, req models.GetAllEquipmentReq) (*models.GetAllTrucksResp, error) {
+	ctx, span := tracer.Start(ctx, "EquipmentService.GetAllTrucks")
+	defer span.End()
+
+	resp, err := s.store.Equipment().GetAllTrucks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *EquipmentService) CreateTrailer(ctx context.Context, trailer *models.Trailer) (string, error) {
+	ctx, span := tracer.Start(ctx, "EquipmentService.CreateTrailer")
+	defer span.End()
+
+	id, err := s.store.Equipment().CreateTrailer(ctx, trailer)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *EquipmentService) UpdateTrailer(ctx context.Context, trailer *models.Trailer) error {
+	ctx, span := tracer.Start(ctx, "EquipmentService.UpdateTrailer")
+	defer span.End()
+
+	return s.store.Equipment().UpdateTrailer(ctx, trailer)
+}
+
+func (s *EquipmentService) DeleteTrailer(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "EquipmentService.DeleteTrailer")
+	defer span.End()
+
+	drivers, err := s.store.Equipment().AssignedDrivers(ctx, nil, &req.Id)
+	if err != nil {
+		return err
+	}
+	if len(drivers) > 0 {
+		return apperr.Conflict("TRAILER_ASSIGNED", "trailer is assigned to %s", drivers[0].FullName())
+	}
+
+	return s.store.Equipment().DeleteTrailer(ctx, req)
+}
+
+func (s *EquipmentService) GetTrailer(ctx context.Context, req models.RequestId) (*models.Trailer, error) {
+	ctx, span := tracer.Start(ctx, "EquipmentService.GetTrailer")
+	defer span.End()
+
+	trailer, err := s.store.Equipment().GetTrailer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return trailer, nil
+}
+
+func (s *EquipmentService) GetAllTrailers(ctx context.Context, req models.GetAllEquipmentReq) (*models.GetAllTrailersResp, error) {
+	ctx, span := tracer.Start(ctx, "EquipmentService.GetAllTrailers")
+	defer span.End()
+
+	resp, err := s.store.Equipment().GetAllTrailers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Assign puts a driver on a truck and trailer (either may be nil to drop it).
+// Equipment taken from another driver is removed from that driver, and every
+// change is kept as a dated assignment.
+func (s *EquipmentService) Assign(ctx context.Context, driverId uuid.UUID, truckId, trailerId *uuid.UUID, notes string, by models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "EquipmentService.Assign")
+	defer span.End()
+
+	driver, err := s.store.Driver().Get(ctx, models.RequestId{Id: driverId})
+	if err != nil {
+		return err
+	}
+
+	return s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var truckNumber string
+		if truckId != nil {
+			truck, err := s.store.Equipment().GetTruck(ctx, models.RequestId{Id: *truckId}, tx)
+			if err != nil {
+				return err
+			}
+			if truck.CompanyId != driver.CompanyId {
+				return apperr.Conflict("EQUIPMENT_UNAVAILABLE", "truck %s belongs to another company", truck.Number)
+			}
+			if truck.Status != models.EquipmentStatusActive {
+				return apperr.Conflict("EQUIPMENT_UNAVAILABLE", "truck %s is %s", truck.Number, truck.Status)
+			}
+			truckNumber = truck.Number
+		}
+
+		if trailerId != nil {
+			trailer, err := s.store.Equipment().GetTrailer(ctx, models.RequestId{Id: *trailerId}, tx)
+			if err != nil {
+				return err
+			}
+			if trailer.CompanyId != driver.CompanyId {
+				return apperr.Conflict("EQUIPMENT_UNAVAILABLE", "trailer %s belongs to another company", trailer.Number)
+			}
+			if trailer.Status != models.EquipmentStatusActive {
+				return apperr.Conflict("EQUIPMENT_UNAVAILABLE", "trailer %s is %s", trailer.Number, trailer.Status)
+			}
+		}
+
+		now := Utime.Now()
+		holders, err := s.store.Equipment().AssignedDrivers(ctx, truckId, trailerId, tx)
+		if err != nil {
+			return err
+		}
+		for _, holder := range holders {
+			if holder.Id == driverId {
+				continue
+			}
+
+			keep := models.EquipmentAssignment{
+				DriverId:   holder.Id,
+				TruckId:    holder.TruckId,
+				TrailerId:  holder.TrailerId,
+				StartedAt:  now,
+				Notes:      fmt.Sprintf("equipment moved to %s", driver.FullName()),
+				EmployeeId: by.Id,
+			}
+			if sameId(keep.TruckId, truckId) {
+				keep.TruckId = nil
+			}
+			if sameId(keep.TrailerId, trailerId) {
+				keep.TrailerId = nil
+			}
+			if err = s.store.Equipment().Assign(ctx, &keep, holder.TruckNumber, tx); err != nil {
+				return err
+			}
+		}
+
+		return s.store.Equipment().Assign(ctx, &models.EquipmentAssignment{
+			DriverId:   driverId,
+			TruckId:    truckId,
+			TrailerId:  trailerId,
+			StartedAt:  now,
+			Notes:      notes,
+			EmployeeId: by.Id,
+		}, truckNumber, tx)
+	})
+}
+
+func (s *EquipmentService) GetAllAssignments(ctx context.Context, req models.GetAllAssignmentsReq) (*models.GetAllAssignmentsResp, error) {
+	ctx, span := tracer.Start(ctx, "EquipmentService.GetAllAssignments")
+	defer span.End()
+
+	resp, err := s.store.Equipment().GetAllAssignments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func sameId(a, b *uuid.UUID) bool {
+	return a != nil && b != nil && *a == *b
+}
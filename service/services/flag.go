@@ -0,0 +1,96 @@
+package services
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+)
+
+type FlagRuleService struct {
+	store database.IStore
+}
+
+func NewFlagRuleService(store database.IStore) *FlagRuleService {
+	return &FlagRuleService{store: store}
+}
+
+func (s *FlagRuleService) Create(ctx context.Context, rule *models.FlagRule) (string, error) {
+	ctx, span := tracer.Start(ctx, "FlagRuleService.Create")
+	defer span.End()
+
+	if err := validateFlagRule(rule); err != nil {
+		return "", err
+	}
+
+	id, err := s.store.FlagRule().Create(ctx, rule)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *FlagRuleService) Update(ctx context.Context, rule *models.FlagRule) error {
+	ctx, span := tracer.Start(ctx, "FlagRuleService.Update")
+	defer span.End()
+
+	if err := validateFlagRule(rule); err != nil {
+		return err
+	}
+
+	return s.store.FlagRule().Update(ctx, rule)
+}
+
+func (s *FlagRuleService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "FlagRuleService.Delete")
+	defer span.End()
+
+	return s.store.FlagRule().Delete(ctx, req)
+}
+
+func (s *FlagRuleService) Get(ctx context.Context, req models.RequestId) (*models.FlagRule, error) {
+	ctx, span := tracer.Start(ctx, "FlagRuleService.Get")
+	defer span.End()
+
+	rule, err := s.store.FlagRule().Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *FlagRuleService) GetAll(ctx context.Context) ([]models.FlagRule, error) {
+	ctx, span := tracer.Start(ctx, "FlagRuleService.GetAll")
+	defer span.End()
+
+	rules, err := s.store.FlagRule().GetAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func validateFlagRule(rule *models.FlagRule) error {
+	if rule.Name == "" {
+		return apperr.Validation(apperr.CodeValidation, "name can't be empty")
+	}
+
+	if rule.Icon == "" {
+		return apperr.Validation(apperr.CodeValidation, "icon can't be empty")
+	}
+
+	switch rule.Condition {
+	case models.FlagConditionAfterStTime, models.FlagConditionBeforeStTime:
+	default:
+		return apperr.Validation(apperr.CodeValidation, "invalid condition: %s", rule.Condition)
+	}
+
+	if rule.Threshold < 0 {
+		return apperr.Validation(apperr.CodeValidation, "threshold can't be negative")
+	}
+
+	return nil
+}
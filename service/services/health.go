@@ -0,0 +1,67 @@
+package services
+
+import (
+	"backend/etc/jobs"
+	"backend/etc/search"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"errors"
+	"time"
+)
+
+// readyTimeout bounds each readiness check, so a hanging database fails the
+// probe instead of timing it out.
+const readyTimeout = 2 * time.Second
+
+type HealthService struct {
+	store  database.IStore
+	runner *jobs.Runner
+}
+
+func NewHealthService(store database.IStore, runner *jobs.Runner) *HealthService {
+	return &HealthService{store: store, runner: runner}
+}
+
+// Ready checks the database, the locations dataset and the background jobs.
+func (s *HealthService) Ready(ctx context.Context) models.Readiness {
+	checks := []struct {
+		name  string
+		check func(ctx context.Context) error
+	}{
+		{"database", s.pingDatabase},
+		{"locations", func(context.Context) error {
+			if !search.Loaded() {
+				return errors.New("locations are not loaded")
+			}
+			return nil
+		}},
+		{"jobs", s.runner.Health},
+	}
+
+	readiness := models.Readiness{Status: models.HealthStatusOK}
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+		err := c.check(checkCtx)
+		cancel()
+
+		result := models.HealthCheck{Name: c.name, Status: models.HealthStatusOK}
+		if err != nil {
+			result.Status = models.HealthStatusFail
+			result.Error = err.Error()
+			readiness.Status = models.HealthStatusFail
+		}
+		readiness.Checks = append(readiness.Checks, result)
+	}
+
+	return readiness
+}
+
+func (s *HealthService) pingDatabase(ctx context.Context) error {
+	sqlDB, err := s.store.DB().DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
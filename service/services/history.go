@@ -15,6 +15,9 @@ func NewHistoryService(store database.IStore) *HistoryService {
 }
 
 func (s *HistoryService) GetHistory(ctx context.Context, req models.RequestId) (*models.History, error) {
+	ctx, span := tracer.Start(ctx, "HistoryService.GetHistory")
+	defer span.End()
+
 	resp, err := s.store.History().Get(ctx, req)
 	if err != nil {
 		return nil, err
@@ -24,6 +27,9 @@ func (s *HistoryService) GetHistory(ctx context.Context, req models.RequestId) (
 }
 
 func (s *HistoryService) GetAll(ctx context.Context, req models.GetAllHistoryReq) (*models.GetAllHistoryResp, error) {
+	ctx, span := tracer.Start(ctx, "HistoryService.GetAll")
+	defer span.End()
+
 	resp, err := s.store.History().GetAll(ctx, req)
 	if err != nil {
 		return nil, err
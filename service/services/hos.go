@@ -0,0 +1,94 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// HOSError is returned by UpdateWithCargo when the trip can't fit the
+// driver's remaining hours and the caller did not override it.
+type HOSError struct {
+	DriverName string
+	Problems   []string
+}
+
+func (e *HOSError) Error() string {
+	return fmt.Sprintf("driver %s can't legally run this load: %s", e.DriverName, strings.Join(e.Problems, "; "))
+}
+
+func (e *HOSError) AppError() *apperr.Error {
+	return &apperr.Error{Kind: apperr.KindConflict, Code: "HOS_NOT_FEASIBLE", Message: e.Error(), Err: e}
+}
+
+type HOSService struct {
+	store database.IStore
+}
+
+func NewHOSService(store database.IStore) *HOSService {
+	return &HOSService{store: store}
+}
+
+// Save stores manually entered clocks. They count from now unless the time
+// they were read is given.
+func (s *HOSService) Save(ctx context.Context, hos *models.DriverHOS) error {
+	ctx, span := tracer.Start(ctx, "HOSService.Save")
+	defer span.End()
+
+	if hos.DriveLeft < 0 || hos.OnDutyLeft < 0 || hos.CycleLeft < 0 {
+		return apperr.Validation(apperr.CodeValidation, "remaining hours can't be negative")
+	}
+
+	if hos.ReportedAt.IsZero() {
+		hos.ReportedAt = Utime.Now()
+	}
+	hos.Source = models.HOSSourceManual
+
+	return s.store.HOS().Save(ctx, hos)
+}
+
+func (s *HOSService) Get(ctx context.Context, driverId uuid.UUID) (*models.DriverHOS, error) {
+	ctx, span := tracer.Start(ctx, "HOSService.Get")
+	defer span.End()
+
+	hos, err := s.store.HOS().GetByDriver(ctx, driverId)
+	if err != nil {
+		return nil, err
+	}
+
+	return hos, nil
+}
+
+// checkHOS checks whether the driver has the hours to run the cargo. Problems
+// block the assignment unless override is set, in which case they are
+// returned as warnings. Drivers without hours of service are not checked.
+func checkHOS(ctx context.Context, store database.IStore, driver models.Driver, cargo *models.Cargo, override bool, tx *gorm.DB) ([]string, error) {
+	hos, err := store.HOS().GetByDriver(ctx, driver.Id, tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var (
+		now      = Utime.Now()
+		warnings []string
+	)
+	if age := now.Sub(hos.ReportedAt); age > models.HOSStaleAfter {
+		warnings = append(warnings, fmt.Sprintf("hours of service of the driver were reported %.0f hours ago", age.Hours()))
+	}
+
+	problems := hos.Feasibility(now, Utime.Parse(cargo.PickUpTime), Utime.Parse(cargo.DeliveryTime), cargo.LoadedMiles, cargo.FreeMiles)
+	if len(problems) > 0 && !override {
+		return nil, &HOSError{DriverName: driver.FullName(), Problems: problems}
+	}
+
+	return append(warnings, problems...), nil
+}
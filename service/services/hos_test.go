@@ -0,0 +1,83 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestCheckHOS(t *testing.T) {
+	var (
+		driver = models.Driver{Id: uuid.New(), Name: "John", Surname: "Smith"}
+		now    = Utime.Now()
+		// 9 driving hours, 3 of them to the pickup in 4 hours
+		cargo = models.Cargo{
+			PickUpTime:   now.Add(4 * time.Hour),
+			DeliveryTime: now.Add(14 * time.Hour),
+			LoadedMiles:  300,
+			FreeMiles:    150,
+		}
+		fresh = models.DriverHOS{DriverId: driver.Id, DriveLeft: 11, OnDutyLeft: 14, CycleLeft: 70, ReportedAt: now.Add(-time.Hour)}
+		stale = models.DriverHOS{DriverId: driver.Id, DriveLeft: 11, OnDutyLeft: 14, CycleLeft: 70, ReportedAt: now.Add(-13*time.Hour - time.Minute)}
+		short = models.DriverHOS{DriverId: driver.Id, DriveLeft: 11, OnDutyLeft: 14, CycleLeft: 4, ReportedAt: now.Add(-time.Hour)}
+	)
+
+	const (
+		staleWarning = "hours of service of the driver were reported 13 hours ago"
+		cycleProblem = "trip needs 9.0 driving hours but only 4.0 are left in the cycle"
+	)
+
+	tests := []struct {
+		name         string
+		hos          *models.DriverHOS
+		override     bool
+		wantBlocked  bool
+		wantWarnings []string
+	}{
+		{name: "no hours of service", hos: nil},
+		{name: "hours of another driver", hos: &models.DriverHOS{DriverId: uuid.New(), ReportedAt: now}},
+		{name: "fits", hos: &fresh},
+		{name: "stale report", hos: &stale, wantWarnings: []string{staleWarning}},
+		{name: "does not fit", hos: &short, wantBlocked: true},
+		{name: "does not fit with override", hos: &short, override: true, wantWarnings: []string{cycleProblem}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore(t, models.Logistic{}, nil)
+			store.hos.hos = tt.hos
+
+			warnings, err := checkHOS(context.Background(), store, driver, &cargo, tt.override, nil)
+
+			if tt.wantBlocked {
+				var hosErr *HOSError
+				if !errors.As(err, &hosErr) {
+					t.Fatalf("got %v, want an HOS error", err)
+				}
+				if hosErr.DriverName != "John Smith" || len(hosErr.Problems) != 1 || hosErr.Problems[0] != cycleProblem {
+					t.Errorf("got %v", err)
+				}
+				if code := hosErr.AppError().Code; code != "HOS_NOT_FEASIBLE" {
+					t.Errorf("got code %q", code)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("checkHOS: %v", err)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("got warnings %q, want %q", warnings, tt.wantWarnings)
+			}
+			for i := range tt.wantWarnings {
+				if warnings[i] != tt.wantWarnings[i] {
+					t.Errorf("got warning %q, want %q", warnings[i], tt.wantWarnings[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/etc/pdf"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+type InvoiceService struct {
+	store database.IStore
+}
+
+func NewInvoiceService(store database.IStore) *InvoiceService {
+	return &InvoiceService{store: store}
+}
+
+// Generate bills the provider for the given delivered transactions. Every
+// transaction becomes a linehaul line; accessorials are attached as extra lines.
+func (s *InvoiceService) Generate(ctx context.Context, req models.GenerateInvoiceReq, by models.RequestId) (string, error) {
+	ctx, span := tracer.Start(ctx, "InvoiceService.Generate")
+	defer span.End()
+
+	if len(req.TransactionIds) == 0 {
+		return "", apperr.Validation(apperr.CodeValidation, "at least one transaction is required")
+	}
+	for i, transactionId := range req.TransactionIds {
+		if slices.Contains(req.TransactionIds[:i], transactionId) {
+			return "", apperr.Validation(apperr.CodeValidation, "transaction %s is listed more than once", transactionId)
+		}
+	}
+
+	var id string
+	err := s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		company, err := s.store.Company().Get(ctx, models.RequestId{Id: req.CompanyId})
+		if err != nil {
+			return err
+		}
+
+		provider, err := s.store.Provider().Get(ctx, models.RequestId{Id: req.ProviderId}, tx)
+		if err != nil {
+			return err
+		}
+
+		transactions, err := s.store.Transaction().GetForInvoice(ctx, req.TransactionIds, tx)
+		if err != nil {
+			return err
+		}
+		if len(transactions) != len(req.TransactionIds) {
+			return gorm.ErrRecordNotFound
+		}
+
+		var (
+			lines   []models.InvoiceLine
+			billed  = make(map[uuid.UUID]bool, len(transactions))
+			invoice = models.Invoice{
+				CompanyId:  company.Id,
+				ProviderId: provider.Id,
+				Status:     models.InvoiceStatusDraft,
+				Notes:      req.Notes,
+				EmployeeId: by.Id,
+			}
+		)
+		for _, t := range transactions {
+			switch {
+			case !t.Success:
+				return apperr.Conflict("LOAD_NOT_INVOICEABLE", "load %s was not delivered", t.CargoID)
+			case t.InvoiceId != nil:
+				return apperr.Conflict("LOAD_NOT_INVOICEABLE", "load %s is already invoiced", t.CargoID)
+			case t.ProviderId == nil || *t.ProviderId != provider.Id:
+				return apperr.Conflict("LOAD_NOT_INVOICEABLE", "load %s belongs to another provider", t.CargoID)
+			case t.Driver.CompanyId != company.Id:
+				return apperr.Conflict("LOAD_NOT_INVOICEABLE", "load %s was hauled by another company", t.CargoID)
+			}
+
+			transactionId := t.Id
+			billed[t.Id] = true
+			lines = append(lines, models.InvoiceLine{
+				TransactionId: &transactionId,
+				Type:          models.InvoiceLineLinehaul,
+				Description:   fmt.Sprintf("Load %s: %s - %s", t.CargoID, t.From, t.To),
+				Amount:        float64(t.Cost),
+			})
+		}
+
+		for _, a := range req.Accessorials {
+			if a.TransactionId != nil && !billed[*a.TransactionId] {
+				return apperr.Validation(apperr.CodeValidation, "accessorial %q refers to a transaction that is not on this invoice", a.Description)
+			}
+			if a.Type == models.InvoiceLineLinehaul {
+				return apperr.Validation(apperr.CodeValidation, "accessorials cannot be of type %s", models.InvoiceLineLinehaul)
+			}
+			lines = append(lines, a)
+		}
+
+		for _, l := range lines {
+			invoice.Total += l.Amount
+		}
+		invoice.Total = roundCents(invoice.Total)
+		invoice.Lines = lines
+
+		invoice.Sequence, err = s.store.Invoice().NextSequence(ctx, company.Id, tx)
+		if err != nil {
+			return err
+		}
+		invoice.Number = fmt.Sprintf("%s-%06d", company.SCAC, invoice.Sequence)
+
+		id, err = s.store.Invoice().Create(ctx, &invoice, tx)
+		if err != nil {
+			return err
+		}
+
+		return s.store.Transaction().SetInvoice(ctx, req.TransactionIds, invoice.Id, tx)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateStatus moves an invoice to SENT or VOID. Sending fixes the issue and
+// due dates; voiding releases its transactions so they can be billed again.
+func (s *InvoiceService) UpdateStatus(ctx context.Context, req models.RequestId, status string) error {
+	ctx, span := tracer.Start(ctx, "InvoiceService.UpdateStatus")
+	defer span.End()
+
+	return s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invoice, err := s.store.Invoice().Get(ctx, req, tx)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case status == models.InvoiceStatusSent && invoice.Status == models.InvoiceStatusDraft:
+			issued := Utime.Now()
+			due := issued.AddDate(0, 0, invoice.Provider.PaymentTerms)
+			invoice.IssueDate, invoice.DueDate = &issued, &due
+		case status == models.InvoiceStatusVoid && invoice.Status != models.InvoiceStatusVoid && invoice.AmountPaid == 0:
+			if err = s.store.Transaction().ReleaseInvoice(ctx, invoice.Id, tx); err != nil {
+				return err
+			}
+		default:
+			return apperr.Conflict("INVALID_INVOICE_STATUS", "invoice %s cannot go from %s to %s", invoice.Number, invoice.Status, status)
+		}
+
+		invoice.Status = status
+		return s.store.Invoice().UpdateStatus(ctx, invoice, tx)
+	})
+}
+
+// AddPayment records a payment against a sent invoice and marks it paid once
+// the balance is settled.
+func (s *InvoiceService) AddPayment(ctx context.Context, payment *models.InvoicePayment, by models.RequestId) (string, error) {
+	ctx, span := tracer.Start(ctx, "InvoiceService.AddPayment")
+	defer span.End()
+
+	if payment.Amount <= 0 {
+		return "", apperr.Validation(apperr.CodeValidation, "payment amount must be positive")
+	}
+
+	var id string
+	err := s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invoice, err := s.store.Invoice().Get(ctx, models.RequestId{Id: payment.InvoiceId}, tx)
+		if err != nil {
+			return err
+		}
+		if invoice.Status != models.InvoiceStatusSent {
+			return apperr.Conflict("INVALID_INVOICE_STATUS", "payments can only be recorded on sent invoices, invoice %s is %s", invoice.Number, invoice.Status)
+		}
+
+		if payment.Amount > roundCents(invoice.Balance()) {
+			return apperr.Validation("PAYMENT_EXCEEDS_BALANCE", "payment of %s exceeds the open balance of %s", formatMoney(payment.Amount), formatMoney(invoice.Balance()))
+		}
+
+		payment.EmployeeId = by.Id
+		id, err = s.store.Invoice().AddPayment(ctx, payment, tx)
+		if err != nil {
+			return err
+		}
+
+		invoice.AmountPaid = roundCents(invoice.AmountPaid + payment.Amount)
+		if roundCents(invoice.Balance()) <= 0 {
+			invoice.Status = models.InvoiceStatusPaid
+		}
+
+		return s.store.Invoice().UpdateStatus(ctx, invoice, tx)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// ShortPay closes a sent invoice whose provider will not pay the rest of it,
+// writing the open balance off as short paid.
+func (s *InvoiceService) ShortPay(ctx context.Context, req models.RequestId, reason string) error {
+	ctx, span := tracer.Start(ctx, "InvoiceService.ShortPay")
+	defer span.End()
+
+	return s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invoice, err := s.store.Invoice().Get(ctx, req, tx)
+		if err != nil {
+			return err
+		}
+		if invoice.Status != models.InvoiceStatusSent {
+			return apperr.Conflict("INVALID_INVOICE_STATUS", "only sent invoices can be short paid, invoice %s is %s", invoice.Number, invoice.Status)
+		}
+
+		now := Utime.Now()
+		invoice.ShortPaid = roundCents(invoice.Balance())
+		invoice.ShortPayReason = reason
+		invoice.ShortPaidAt = &now
+		invoice.Status = models.InvoiceStatusPaid
+
+		return s.store.Invoice().UpdateStatus(ctx, invoice, tx)
+	})
+}
+
+// SetFactoring records that an issued invoice was sold to a factoring company,
+// with the advance received and the reserve held back until the provider pays.
+func (s *InvoiceService) SetFactoring(ctx context.Context, factoring *models.Invoice) error {
+	ctx, span := tracer.Start(ctx, "InvoiceService.SetFactoring")
+	defer span.End()
+
+	return s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invoice, err := s.store.Invoice().Get(ctx, models.RequestId{Id: factoring.Id}, tx)
+		if err != nil {
+			return err
+		}
+		if invoice.Status != models.InvoiceStatusSent && invoice.Status != models.InvoiceStatusPaid {
+			return apperr.Conflict("INVALID_INVOICE_STATUS", "only issued invoices can be factored, invoice %s is %s", invoice.Number, invoice.Status)
+		}
+		if factoring.AdvanceAmount+factoring.ReserveAmount+factoring.FactoringFee > invoice.Total+0.005 {
+			return apperr.Validation(apperr.CodeValidation, "advance, reserve and fee exceed the invoice total of %s", formatMoney(invoice.Total))
+		}
+
+		if factoring.Factored && factoring.FactoredAt == nil {
+			now := Utime.Now()
+			factoring.FactoredAt = &now
+		}
+
+		return s.store.Invoice().UpdateFactoring(ctx, factoring, tx)
+	})
+}
+
+// Aging returns the open receivables per provider split into age buckets.
+func (s *InvoiceService) Aging(ctx context.Context, req models.GetARAgingReq) (*models.GetARAgingResp, error) {
+	ctx, span := tracer.Start(ctx, "InvoiceService.Aging")
+	defer span.End()
+
+	aging, err := s.store.Invoice().Aging(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.GetARAgingResp{Providers: aging}
+	for _, a := range aging {
+		resp.Totals.Days0To30 += a.Days0To30
+		resp.Totals.Days31To60 += a.Days31To60
+		resp.Totals.Days61To90 += a.Days61To90
+		resp.Totals.Days90Plus += a.Days90Plus
+		resp.Totals.Total += a.Total
+		resp.Totals.Invoices += a.Invoices
+	}
+
+	return &resp, nil
+}
+
+// Ledger lists every invoice, payment and short pay of a provider in date
+// order with the running balance the provider owes.
+func (s *InvoiceService) Ledger(ctx context.Context, providerId uuid.UUID) (*models.ProviderLedger, error) {
+	ctx, span := tracer.Start(ctx, "InvoiceService.Ledger")
+	defer span.End()
+
+	invoices, err := s.store.Invoice().GetForLedger(ctx, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := models.ProviderLedger{ProviderId: providerId, Entries: []models.LedgerEntry{}}
+	for _, invoice := range invoices {
+		issued := invoice.CreatedAt
+		if invoice.IssueDate != nil {
+			issued = *invoice.IssueDate
+		}
+		ledger.Entries = append(ledger.Entries, models.LedgerEntry{
+			Date:          issued,
+			Type:          models.LedgerEntryInvoice,
+			InvoiceId:     invoice.Id,
+			InvoiceNumber: invoice.Number,
+			Debit:         invoice.Total,
+		})
+
+		for _, payment := range invoice.Payments {
+			ledger.Entries = append(ledger.Entries, models.LedgerEntry{
+				Date:          payment.PaidAt,
+				Type:          models.LedgerEntryPayment,
+				InvoiceId:     invoice.Id,
+				InvoiceNumber: invoice.Number,
+				Reference:     payment.Reference,
+				Credit:        payment.Amount,
+			})
+		}
+
+		if invoice.ShortPaid > 0 && invoice.ShortPaidAt != nil {
+			ledger.Entries = append(ledger.Entries, models.LedgerEntry{
+				Date:          *invoice.ShortPaidAt,
+				Type:          models.LedgerEntryShortPay,
+				InvoiceId:     invoice.Id,
+				InvoiceNumber: invoice.Number,
+				Reference:     invoice.ShortPayReason,
+				Credit:        invoice.ShortPaid,
+			})
+		}
+
+		ledger.Invoiced += invoice.Total
+		ledger.Paid += invoice.AmountPaid
+		ledger.ShortPaid += invoice.ShortPaid
+		if invoice.Factored {
+			ledger.Factored += invoice.Total
+			if invoice.ReserveReleasedAt == nil {
+				ledger.Reserve += invoice.ReserveAmount
+			}
+		}
+	}
+
+	sort.SliceStable(ledger.Entries, func(i, j int) bool {
+		return ledger.Entries[i].Date.Before(ledger.Entries[j].Date)
+	})
+
+	var balance float64
+	for i := range ledger.Entries {
+		balance = roundCents(balance + ledger.Entries[i].Debit - ledger.Entries[i].Credit)
+		ledger.Entries[i].Balance = balance
+	}
+	ledger.Balance = balance
+
+	return &ledger, nil
+}
+
+// Delete removes a draft invoice. Its number is not reused.
+func (s *InvoiceService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "InvoiceService.Delete")
+	defer span.End()
+
+	return s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invoice, err := s.store.Invoice().Get(ctx, req, tx)
+		if err != nil {
+			return err
+		}
+		if invoice.Status != models.InvoiceStatusDraft {
+			return apperr.Conflict("INVALID_INVOICE_STATUS", "only draft invoices can be deleted, invoice %s is %s", invoice.Number, invoice.Status)
+		}
+
+		if err = s.store.Transaction().ReleaseInvoice(ctx, invoice.Id, tx); err != nil {
+			return err
+		}
+
+		return s.store.Invoice().Delete(ctx, req, tx)
+	})
+}
+
+func (s *InvoiceService) Get(ctx context.Context, req models.RequestId) (*models.Invoice, error) {
+	ctx, span := tracer.Start(ctx, "InvoiceService.Get")
+	defer span.End()
+
+	invoice, err := s.store.Invoice().Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (s *InvoiceService) GetAll(ctx context.Context, req models.GetAllInvoicesReq) (*models.GetAllInvoicesResp, error) {
+	ctx, span := tracer.Start(ctx, "InvoiceService.GetAll")
+	defer span.End()
+
+	resp, err := s.store.Invoice().GetAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PDF renders the invoice as a printable document.
+func (s *InvoiceService) PDF(ctx context.Context, req models.RequestId) (*models.Invoice, []byte, error) {
+	ctx, span := tracer.Start(ctx, "InvoiceService.PDF")
+	defer span.End()
+
+	invoice, err := s.store.Invoice().Get(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := pdf.New()
+	doc.Text(18, true, invoice.Company.Name)
+	doc.Text(10, false, invoice.Company.Address)
+	doc.Text(10, false, fmt.Sprintf("MC %d   DOT %d   SCAC %s", invoice.Company.MC, invoice.Company.DOT, invoice.Company.SCAC))
+	doc.Gap(20)
+
+	doc.Text(14, true, "INVOICE "+invoice.Number)
+	if invoice.Status != models.InvoiceStatusSent {
+		doc.Text(10, true, "Status: "+invoice.Status)
+	}
+	doc.Text(10, false, "Issue date: "+formatDate(invoice.IssueDate))
+	doc.Text(10, false, "Due date:   "+formatDate(invoice.DueDate))
+	doc.Gap(10)
+
+	doc.Text(10, true, "Bill to:")
+	doc.Text(10, false, invoice.Provider.Name)
+	if invoice.Provider.MC != "" {
+		doc.Text(10, false, "MC "+invoice.Provider.MC)
+	}
+	if invoice.Provider.ContactEmail != "" {
+		doc.Text(10, false, invoice.Provider.ContactEmail)
+	}
+	doc.Gap(20)
+
+	doc.TextAt(0, 10, true, "Type")
+	doc.TextAt(80, 10, true, "Description")
+	doc.TextAt(430, 10, true, "Amount")
+	doc.Break(10)
+	for _, l := range invoice.Lines {
+		doc.TextAt(0, 10, false, l.Type)
+		doc.TextAt(80, 10, false, truncate(l.Description, 55))
+		doc.TextAt(430, 10, false, formatMoney(l.Amount))
+		doc.Break(10)
+	}
+	doc.Gap(10)
+
+	doc.TextAt(300, 11, true, "Total")
+	doc.TextAt(430, 11, true, formatMoney(invoice.Total))
+	doc.Break(11)
+	doc.TextAt(300, 11, false, "Paid")
+	doc.TextAt(430, 11, false, formatMoney(invoice.AmountPaid))
+	doc.Break(11)
+	if invoice.ShortPaid > 0 {
+		doc.TextAt(300, 11, false, "Short paid")
+		doc.TextAt(430, 11, false, formatMoney(invoice.ShortPaid))
+		doc.Break(11)
+	}
+	doc.TextAt(300, 11, true, "Balance due")
+	doc.TextAt(430, 11, true, formatMoney(invoice.Balance()))
+	doc.Break(11)
+
+	if invoice.Notes != "" {
+		doc.Gap(20)
+		doc.Text(10, true, "Notes:")
+		doc.Text(10, false, invoice.Notes)
+	}
+
+	return invoice, doc.Bytes(), nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func formatMoney(v float64) string {
+	return fmt.Sprintf("$%.2f", v)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("01/02/2006")
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
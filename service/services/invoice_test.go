@@ -0,0 +1,459 @@
+package services
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+// invoiceFixture has two companies sharing the provider TQL, with payment
+// terms of 30 days, and delivered loads of the first company.
+type invoiceFixture struct {
+	store    *fakeStore
+	service  *InvoiceService
+	ssls     models.Company
+	acme     models.Company
+	provider models.Provider
+}
+
+func newInvoiceFixture(t *testing.T) *invoiceFixture {
+	t.Helper()
+
+	f := &invoiceFixture{
+		store:    newFakeStore(t, models.Logistic{}, nil),
+		ssls:     models.Company{Id: uuid.New(), SCAC: "SSLS"},
+		acme:     models.Company{Id: uuid.New(), SCAC: "ACME"},
+		provider: models.Provider{Id: uuid.New(), Name: "TQL", PaymentTerms: 30},
+	}
+	f.store.companies.companies[f.ssls.Id] = f.ssls
+	f.store.companies.companies[f.acme.Id] = f.acme
+	f.store.providers.providers[f.provider.Id] = f.provider
+	f.service = NewInvoiceService(f.store)
+
+	return f
+}
+
+// load adds a delivered load of company for the provider and returns its id.
+func (f *invoiceFixture) load(company models.Company, cost int64) uuid.UUID {
+	transaction := models.Transaction{
+		Id:         uuid.New(),
+		CargoID:    "L-" + uuid.NewString()[:4],
+		From:       "Dallas, TX",
+		To:         "Austin, TX",
+		Cost:       cost,
+		ProviderId: &f.provider.Id,
+		Driver:     models.Driver{CompanyId: company.Id},
+		Success:    true,
+	}
+	f.store.transactions.transactions[transaction.Id] = transaction
+
+	return transaction.Id
+}
+
+func (f *invoiceFixture) generate(t *testing.T, company models.Company, accessorials []models.InvoiceLine, transactionIds ...uuid.UUID) models.Invoice {
+	t.Helper()
+
+	id, err := f.service.Generate(context.Background(), models.GenerateInvoiceReq{
+		CompanyId:      company.Id,
+		ProviderId:     f.provider.Id,
+		TransactionIds: transactionIds,
+		Accessorials:   accessorials,
+	}, models.RequestId{Id: uuid.New()})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	return f.store.invoices.invoices[uuid.MustParse(id)]
+}
+
+// errorCode returns the code of an application error, or "" for other errors.
+func errorCode(err error) string {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
+
+func TestGenerateInvoiceNumbersPerCompany(t *testing.T) {
+	f := newInvoiceFixture(t)
+
+	var numbers []string
+	for _, company := range []models.Company{f.ssls, f.ssls, f.acme, f.ssls} {
+		numbers = append(numbers, f.generate(t, company, nil, f.load(company, 1000)).Number)
+	}
+
+	want := []string{"SSLS-000001", "SSLS-000002", "ACME-000001", "SSLS-000003"}
+	for i := range want {
+		if numbers[i] != want[i] {
+			t.Errorf("invoice %d got number %s, want %s", i+1, numbers[i], want[i])
+		}
+	}
+}
+
+func TestGenerateInvoiceLines(t *testing.T) {
+	f := newInvoiceFixture(t)
+	first, second := f.load(f.ssls, 1200), f.load(f.ssls, 800)
+
+	invoice := f.generate(t, f.ssls, []models.InvoiceLine{
+		{TransactionId: &second, Type: models.InvoiceLineDetention, Description: "Detention 2h", Amount: 75.125},
+		{Type: models.InvoiceLineLumper, Description: "Lumper", Amount: 60},
+	}, first, second)
+
+	if invoice.Status != models.InvoiceStatusDraft || invoice.IssueDate != nil {
+		t.Errorf("got a %s invoice issued at %v, want an unissued draft", invoice.Status, invoice.IssueDate)
+	}
+	if len(invoice.Lines) != 4 || invoice.Lines[0].Type != models.InvoiceLineLinehaul || invoice.Lines[1].Type != models.InvoiceLineLinehaul {
+		t.Errorf("got lines %+v, want a linehaul per load and the accessorials", invoice.Lines)
+	}
+	if invoice.Total != 2135.13 {
+		t.Errorf("got total %.2f, want 2135.13", invoice.Total)
+	}
+	for _, id := range []uuid.UUID{first, second} {
+		if billed := f.store.transactions.transactions[id].InvoiceId; billed == nil || *billed != invoice.Id {
+			t.Errorf("load %s was not linked to the invoice", id)
+		}
+	}
+}
+
+func TestGenerateInvoiceRefuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine)
+		wantCode string
+	}{
+		{
+			name:     "no loads",
+			setup:    func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) { return nil, nil },
+			wantCode: apperr.CodeValidation,
+		},
+		{
+			name: "load listed twice",
+			setup: func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) {
+				id := f.load(f.ssls, 1000)
+				return []uuid.UUID{id, id}, nil
+			},
+			wantCode: apperr.CodeValidation,
+		},
+		{
+			name: "cancelled load",
+			setup: func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) {
+				id := f.load(f.ssls, 1000)
+				transaction := f.store.transactions.transactions[id]
+				transaction.Success = false
+				f.store.transactions.transactions[id] = transaction
+				return []uuid.UUID{id}, nil
+			},
+			wantCode: "LOAD_NOT_INVOICEABLE",
+		},
+		{
+			name: "load already invoiced",
+			setup: func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) {
+				id := f.load(f.ssls, 1000)
+				invoiceId := uuid.New()
+				transaction := f.store.transactions.transactions[id]
+				transaction.InvoiceId = &invoiceId
+				f.store.transactions.transactions[id] = transaction
+				return []uuid.UUID{id}, nil
+			},
+			wantCode: "LOAD_NOT_INVOICEABLE",
+		},
+		{
+			name: "load of another provider",
+			setup: func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) {
+				id, other := f.load(f.ssls, 1000), uuid.New()
+				transaction := f.store.transactions.transactions[id]
+				transaction.ProviderId = &other
+				f.store.transactions.transactions[id] = transaction
+				return []uuid.UUID{id}, nil
+			},
+			wantCode: "LOAD_NOT_INVOICEABLE",
+		},
+		{
+			name: "load of another company",
+			setup: func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) {
+				return []uuid.UUID{f.load(f.acme, 1000)}, nil
+			},
+			wantCode: "LOAD_NOT_INVOICEABLE",
+		},
+		{
+			name: "accessorial of a load not on the invoice",
+			setup: func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) {
+				other := f.load(f.ssls, 500)
+				return []uuid.UUID{f.load(f.ssls, 1000)}, []models.InvoiceLine{
+					{TransactionId: &other, Type: models.InvoiceLineDetention, Amount: 50},
+				}
+			},
+			wantCode: apperr.CodeValidation,
+		},
+		{
+			name: "linehaul accessorial",
+			setup: func(f *invoiceFixture) ([]uuid.UUID, []models.InvoiceLine) {
+				return []uuid.UUID{f.load(f.ssls, 1000)}, []models.InvoiceLine{{Type: models.InvoiceLineLinehaul, Amount: 50}}
+			},
+			wantCode: apperr.CodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInvoiceFixture(t)
+			ids, accessorials := tt.setup(f)
+
+			_, err := f.service.Generate(context.Background(), models.GenerateInvoiceReq{
+				CompanyId:      f.ssls.Id,
+				ProviderId:     f.provider.Id,
+				TransactionIds: ids,
+				Accessorials:   accessorials,
+			}, models.RequestId{Id: uuid.New()})
+			if code := errorCode(err); code != tt.wantCode {
+				t.Errorf("got %v (%s), want %s", err, code, tt.wantCode)
+			}
+			if len(f.store.invoices.invoices) != 0 || f.store.invoices.counters[f.ssls.Id] != 0 {
+				t.Errorf("a refused invoice was numbered or stored")
+			}
+		})
+	}
+}
+
+func TestInvoiceStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       string
+		amountPaid float64
+		to         string
+		wantErr    bool
+	}{
+		{name: "send a draft", from: models.InvoiceStatusDraft, to: models.InvoiceStatusSent},
+		{name: "void a draft", from: models.InvoiceStatusDraft, to: models.InvoiceStatusVoid},
+		{name: "void an unpaid invoice", from: models.InvoiceStatusSent, to: models.InvoiceStatusVoid},
+		{name: "send twice", from: models.InvoiceStatusSent, to: models.InvoiceStatusSent, wantErr: true},
+		{name: "mark paid by hand", from: models.InvoiceStatusSent, to: models.InvoiceStatusPaid, wantErr: true},
+		{name: "void a partly paid invoice", from: models.InvoiceStatusSent, amountPaid: 100, to: models.InvoiceStatusVoid, wantErr: true},
+		{name: "void twice", from: models.InvoiceStatusVoid, to: models.InvoiceStatusVoid, wantErr: true},
+		{name: "send a void invoice", from: models.InvoiceStatusVoid, to: models.InvoiceStatusSent, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInvoiceFixture(t)
+			load := f.load(f.ssls, 1000)
+			invoice := f.generate(t, f.ssls, nil, load)
+			invoice.Status, invoice.AmountPaid, invoice.Provider = tt.from, tt.amountPaid, f.provider
+			f.store.invoices.invoices[invoice.Id] = invoice
+
+			err := f.service.UpdateStatus(context.Background(), models.RequestId{Id: invoice.Id}, tt.to)
+
+			stored := f.store.invoices.invoices[invoice.Id]
+			if tt.wantErr {
+				if code := errorCode(err); code != "INVALID_INVOICE_STATUS" {
+					t.Fatalf("got %v, want INVALID_INVOICE_STATUS", err)
+				}
+				if stored.Status != tt.from {
+					t.Errorf("invoice moved to %s", stored.Status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateStatus: %v", err)
+			}
+			if stored.Status != tt.to {
+				t.Errorf("got status %s, want %s", stored.Status, tt.to)
+			}
+
+			switch tt.to {
+			case models.InvoiceStatusSent:
+				if stored.IssueDate == nil || stored.DueDate == nil || !stored.DueDate.Equal(stored.IssueDate.AddDate(0, 0, 30)) {
+					t.Errorf("got issued %v and due %v, want due 30 days after issue", stored.IssueDate, stored.DueDate)
+				}
+			case models.InvoiceStatusVoid:
+				if f.store.transactions.transactions[load].InvoiceId != nil {
+					t.Errorf("voided invoice still holds its load")
+				}
+			}
+		})
+	}
+}
+
+func TestInvoicePayments(t *testing.T) {
+	f := newInvoiceFixture(t)
+	invoice := f.generate(t, f.ssls, nil, f.load(f.ssls, 1000))
+	invoice.Status = models.InvoiceStatusSent
+	f.store.invoices.invoices[invoice.Id] = invoice
+
+	steps := []struct {
+		amount     float64
+		wantCode   string
+		wantStatus string
+		wantPaid   float64
+	}{
+		{amount: 0, wantCode: apperr.CodeValidation, wantStatus: models.InvoiceStatusSent},
+		{amount: 400.10, wantStatus: models.InvoiceStatusSent, wantPaid: 400.10},
+		{amount: 600, wantCode: "PAYMENT_EXCEEDS_BALANCE", wantStatus: models.InvoiceStatusSent, wantPaid: 400.10},
+		{amount: 599.90, wantStatus: models.InvoiceStatusPaid, wantPaid: 1000},
+		{amount: 1, wantCode: "INVALID_INVOICE_STATUS", wantStatus: models.InvoiceStatusPaid, wantPaid: 1000},
+	}
+
+	for i, step := range steps {
+		_, err := f.service.AddPayment(context.Background(), &models.InvoicePayment{InvoiceId: invoice.Id, Amount: step.amount}, models.RequestId{Id: uuid.New()})
+		if code := errorCode(err); code != step.wantCode || (step.wantCode == "" && err != nil) {
+			t.Errorf("payment %d of %.2f got %v, want %q", i+1, step.amount, err, step.wantCode)
+		}
+
+		stored := f.store.invoices.invoices[invoice.Id]
+		if stored.Status != step.wantStatus || stored.AmountPaid != step.wantPaid {
+			t.Errorf("after payment %d got %s with %.2f paid, want %s with %.2f", i+1, stored.Status, stored.AmountPaid, step.wantStatus, step.wantPaid)
+		}
+	}
+	if len(f.store.invoices.payments) != 2 {
+		t.Errorf("got %d payments recorded, want 2", len(f.store.invoices.payments))
+	}
+}
+
+func TestInvoiceShortPay(t *testing.T) {
+	f := newInvoiceFixture(t)
+	invoice := f.generate(t, f.ssls, nil, f.load(f.ssls, 1000))
+	invoice.Status, invoice.AmountPaid = models.InvoiceStatusSent, 850
+	f.store.invoices.invoices[invoice.Id] = invoice
+
+	if err := f.service.ShortPay(context.Background(), models.RequestId{Id: invoice.Id}, "damaged freight"); err != nil {
+		t.Fatalf("ShortPay: %v", err)
+	}
+
+	stored := f.store.invoices.invoices[invoice.Id]
+	if stored.Status != models.InvoiceStatusPaid || stored.ShortPaid != 150 || stored.Balance() != 0 || stored.ShortPaidAt == nil {
+		t.Errorf("got %s invoice short paid %.2f with balance %.2f, want it closed with 150 written off", stored.Status, stored.ShortPaid, stored.Balance())
+	}
+
+	err := f.service.ShortPay(context.Background(), models.RequestId{Id: invoice.Id}, "again")
+	if code := errorCode(err); code != "INVALID_INVOICE_STATUS" {
+		t.Errorf("short paying a paid invoice got %v", err)
+	}
+}
+
+func TestSetFactoring(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		factored models.Invoice
+		wantCode string
+	}{
+		{
+			name:     "sent invoice",
+			status:   models.InvoiceStatusSent,
+			factored: models.Invoice{Factored: true, FactoringCompany: "RTS", AdvanceAmount: 900, ReserveAmount: 70, FactoringFee: 30},
+		},
+		{
+			name:     "paid invoice",
+			status:   models.InvoiceStatusPaid,
+			factored: models.Invoice{Factored: true, FactoringCompany: "RTS", AdvanceAmount: 970, FactoringFee: 30},
+		},
+		{
+			name:     "draft",
+			status:   models.InvoiceStatusDraft,
+			factored: models.Invoice{Factored: true, FactoringCompany: "RTS", AdvanceAmount: 900},
+			wantCode: "INVALID_INVOICE_STATUS",
+		},
+		{
+			name:     "more than the total",
+			status:   models.InvoiceStatusSent,
+			factored: models.Invoice{Factored: true, FactoringCompany: "RTS", AdvanceAmount: 900, ReserveAmount: 70, FactoringFee: 30.01},
+			wantCode: apperr.CodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInvoiceFixture(t)
+			invoice := f.generate(t, f.ssls, nil, f.load(f.ssls, 1000))
+			invoice.Status = tt.status
+			f.store.invoices.invoices[invoice.Id] = invoice
+
+			factored := tt.factored
+			factored.Id = invoice.Id
+			err := f.service.SetFactoring(context.Background(), &factored)
+
+			stored := f.store.invoices.invoices[invoice.Id]
+			if tt.wantCode != "" {
+				if code := errorCode(err); code != tt.wantCode {
+					t.Fatalf("got %v, want %q", err, tt.wantCode)
+				}
+				if stored.Factored {
+					t.Errorf("a refused factoring was saved")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SetFactoring: %v", err)
+			}
+			if !stored.Factored || stored.FactoredAt == nil || stored.AdvanceAmount != tt.factored.AdvanceAmount {
+				t.Errorf("got factored %t at %v with advance %.2f", stored.Factored, stored.FactoredAt, stored.AdvanceAmount)
+			}
+			if stored.Status != tt.status {
+				t.Errorf("got status %s, want it kept", stored.Status)
+			}
+		})
+	}
+}
+
+func TestProviderLedger(t *testing.T) {
+	var (
+		f      = newInvoiceFixture(t)
+		first  = f.generate(t, f.ssls, nil, f.load(f.ssls, 1000))
+		second = f.generate(t, f.ssls, nil, f.load(f.ssls, 500))
+		day    = func(d int) *time.Time {
+			date := time.Date(2024, time.May, d, 0, 0, 0, 0, time.UTC)
+			return &date
+		}
+	)
+
+	first.Status, first.IssueDate, first.AmountPaid = models.InvoiceStatusPaid, day(1), 900
+	first.ShortPaid, first.ShortPayReason, first.ShortPaidAt = 100, "damaged freight", day(20)
+	first.Factored, first.ReserveAmount = true, 80
+	second.Status, second.IssueDate, second.AmountPaid = models.InvoiceStatusSent, day(10), 200
+	for _, invoice := range []models.Invoice{first, second} {
+		f.store.invoices.invoices[invoice.Id] = invoice
+	}
+	f.store.invoices.payments = []models.InvoicePayment{
+		{InvoiceId: first.Id, Amount: 900, PaidAt: *day(15), Reference: "ACH-1"},
+		{InvoiceId: second.Id, Amount: 200, PaidAt: *day(25), Reference: "ACH-2"},
+	}
+
+	ledger, err := f.service.Ledger(context.Background(), f.provider.Id)
+	if err != nil {
+		t.Fatalf("Ledger: %v", err)
+	}
+
+	want := []struct {
+		entryType string
+		number    string
+		balance   float64
+	}{
+		{models.LedgerEntryInvoice, first.Number, 1000},
+		{models.LedgerEntryInvoice, second.Number, 1500},
+		{models.LedgerEntryPayment, first.Number, 600},
+		{models.LedgerEntryShortPay, first.Number, 500},
+		{models.LedgerEntryPayment, second.Number, 300},
+	}
+	if len(ledger.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(ledger.Entries), len(want))
+	}
+	for i, w := range want {
+		entry := ledger.Entries[i]
+		if entry.Type != w.entryType || entry.InvoiceNumber != w.number || entry.Balance != w.balance {
+			t.Errorf("entry %d: got %s of %s with balance %.2f, want %s of %s with %.2f", i+1, entry.Type, entry.InvoiceNumber, entry.Balance, w.entryType, w.number, w.balance)
+		}
+	}
+
+	if ledger.Invoiced != 1500 || ledger.Paid != 1100 || ledger.ShortPaid != 100 || ledger.Balance != 300 {
+		t.Errorf("got invoiced %.2f, paid %.2f, short paid %.2f and balance %.2f", ledger.Invoiced, ledger.Paid, ledger.ShortPaid, ledger.Balance)
+	}
+	if ledger.Factored != 1000 || ledger.Reserve != 80 {
+		t.Errorf("got %.2f factored with %.2f in reserve, want 1000 and 80", ledger.Factored, ledger.Reserve)
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"backend/etc/jobs"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"github.com/google/uuid"
+)
+
+type JobService struct {
+	store  database.IStore
+	runner *jobs.Runner
+}
+
+func NewJobService(store database.IStore, runner *jobs.Runner) *JobService {
+	return &JobService{store: store, runner: runner}
+}
+
+func (s *JobService) GetAll(ctx context.Context) ([]models.JobInfo, error) {
+	ctx, span := tracer.Start(ctx, "JobService.GetAll")
+	defer span.End()
+
+	return s.runner.Jobs(ctx)
+}
+
+func (s *JobService) GetAllRuns(ctx context.Context, req models.GetAllJobRunsReq) (*models.GetAllJobRunsResp, error) {
+	ctx, span := tracer.Start(ctx, "JobService.GetAllRuns")
+	defer span.End()
+
+	resp, err := s.store.Job().GetAllRuns(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Trigger starts a job right away, on behalf of the employee.
+func (s *JobService) Trigger(ctx context.Context, name string, by uuid.UUID) (*models.JobRun, error) {
+	ctx, span := tracer.Start(ctx, "JobService.Trigger")
+	defer span.End()
+
+	return s.runner.Trigger(ctx, name, &by)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"backend/etc/Utime"
+	"backend/etc/apperr"
 	"backend/models"
 	"backend/models/swag"
 	database "backend/st_database"
@@ -10,8 +11,47 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"slices"
+	"time"
 )
 
+// duplicateLoadWindow is how far apart two pickups on the same lane of the
+// same provider may be to still be reported as a possible double booking.
+const duplicateLoadWindow = 12 * time.Hour
+
+// DuplicateLoadError is returned by UpdateWithCargo when the load number was
+// already booked with the same provider and the caller did not override it.
+type DuplicateLoadError struct {
+	Duplicates []models.DuplicateLoad
+}
+
+func (e *DuplicateLoadError) Error() string {
+	return fmt.Sprintf("load was already booked with this provider (%d match(es))", len(e.Duplicates))
+}
+
+func (e *DuplicateLoadError) AppError() *apperr.Error {
+	return &apperr.Error{Kind: apperr.KindConflict, Code: "DUPLICATE_LOAD", Message: e.Error(), Err: e}
+}
+
+// VersionConflictError is returned by Update and UpdateWithCargo when the
+// logistic, or the cargo of it when Cargo is set, was changed since the
+// version the caller sent.
+type VersionConflictError struct {
+	Current *models.Logistic
+	Cargo   bool
+}
+
+func (e *VersionConflictError) Error() string {
+	if e.Cargo {
+		return fmt.Sprintf("cargo was changed in the meantime (now at version %d)", e.Current.Cargo.Version)
+	}
+	return fmt.Sprintf("logistic was changed in the meantime (now at version %d)", e.Current.Version)
+}
+
+func (e *VersionConflictError) AppError() *apperr.Error {
+	return &apperr.Error{Kind: apperr.KindConflict, Code: "VERSION_CONFLICT", Message: e.Error(), Err: e}
+}
+
 type LogisticService struct {
 	store database.IStore
 }
@@ -21,6 +61,9 @@ func NewLogisticService(store database.IStore) *LogisticService {
 }
 
 func (s *LogisticService) Create(ctx context.Context, req *models.Logistic) (string, error) {
+	ctx, span := tracer.Start(ctx, "LogisticService.Create")
+	defer span.End()
+
 	id, err := s.store.Logistic().Create(ctx, req)
 	if err != nil {
 		return "", err
@@ -30,24 +73,44 @@ func (s *LogisticService) Create(ctx context.Context, req *models.Logistic) (str
 }
 
 func (s *LogisticService) Update(ctx context.Context, req *models.Logistic, by models.RequestId) error {
-	db := s.store.DB()
+	ctx, span := tracer.Start(ctx, "LogisticService.Update")
+	defer span.End()
+
+	db := s.store.DB().WithContext(ctx)
 	err := db.Transaction(func(tx *gorm.DB) error {
-		oldLogistic, getErr := s.store.Logistic().Get(ctx, models.RequestId{Id: req.Id})
+		oldLogistic, getErr := s.store.Logistic().Get(ctx, models.RequestId{Id: req.Id}, tx)
 		if getErr != nil {
 			return getErr
 		}
 
 		if oldLogistic == nil {
-			return fmt.Errorf("logistic with ID %s not found", req.Id)
+			return apperr.NotFound(apperr.CodeNotFound, "logistic with ID %s not found", req.Id)
+		}
+
+		if req.Version != 0 && req.Version != oldLogistic.Version {
+			return &VersionConflictError{Current: oldLogistic}
 		}
 
 		err := s.store.Logistic().Update(ctx, req, tx)
 		if err != nil {
 			return err
 		}
+		req.Version = oldLogistic.Version + 1
+
+		err = trackDowntime(ctx, s.store, oldLogistic, req.Status, req.Notion, tx)
+		if err != nil {
+			return err
+		}
+
+		if pickedUp(oldLogistic, req.Status) {
+			err = emitLoadEvent(ctx, s.store, models.EventLoadPickedUp, oldLogistic, &oldLogistic.Cargo, req.Status, "", tx)
+			if err != nil {
+				return err
+			}
+		}
 
 		_, err = s.store.History().Create(ctx, &models.History{
-			DriverName: oldLogistic.Driver.Name + oldLogistic.Driver.Surname,
+			DriverName: oldLogistic.Driver.FullName(),
 			LogisticId: req.Id,
 			FromLogistic: models.JSONBLogistic{
 				Post:       oldLogistic.Post,
@@ -85,6 +148,9 @@ func (s *LogisticService) Update(ctx context.Context, req *models.Logistic, by m
 }
 
 func (s *LogisticService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "LogisticService.Delete")
+	defer span.End()
+
 	err := s.store.Logistic().Delete(ctx, req)
 	if err != nil {
 		return err
@@ -94,6 +160,9 @@ func (s *LogisticService) Delete(ctx context.Context, req models.RequestId) erro
 }
 
 func (s *LogisticService) Get(ctx context.Context, req models.RequestId) (*models.Logistic, error) {
+	ctx, span := tracer.Start(ctx, "LogisticService.Get")
+	defer span.End()
+
 	resp, err := s.store.Logistic().Get(ctx, req)
 	if err != nil {
 		return nil, err
@@ -103,27 +172,105 @@ func (s *LogisticService) Get(ctx context.Context, req models.RequestId) (*model
 }
 
 func (s *LogisticService) GetAll(ctx context.Context, req models.GetAllLogisticsReq) (*models.GetAllLogisticsResp, error) {
+	ctx, span := tracer.Start(ctx, "LogisticService.GetAll")
+	defer span.End()
+
 	resp, err := s.store.Logistic().GetAll(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
+	rules, err := s.store.FlagRule().GetAll(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Flags are evaluated here instead of read from the row so the board does
+	// not lag behind the updater.
+	now := Utime.Now()
+	for i := range resp.Companies {
+		for j := range resp.Companies[i].Logistics {
+			logistic := &resp.Companies[i].Logistics[j]
+			logistic.Flags = models.EvaluateFlags(rules, logistic.CompanyId, logistic.Status, logistic.StTime, now)
+			logistic.Emoji = logistic.Flags.Icon()
+		}
+	}
+
 	return resp, nil
 }
 
-func (s *LogisticService) UpdateWithCargo(ctx context.Context, logistic *models.Logistic, cargo *models.Cargo, create bool, by models.RequestId) (string, error) {
+func (s *LogisticService) UpdateWithCargo(ctx context.Context, logistic *models.Logistic, cargo *models.Cargo, opts models.UpdateWithCargoOptions, by models.RequestId) (*models.UpdateWithCargoResp, error) {
+	ctx, span := tracer.Start(ctx, "LogisticService.UpdateWithCargo")
+	defer span.End()
+
 	var (
-		db  = s.store.DB()
-		id  string
-		err error
+		db     = s.store.DB().WithContext(ctx)
+		create = opts.Create
+		resp   models.UpdateWithCargoResp
+		id     string
+		err    error
 	)
 	transErr := db.Transaction(func(tx *gorm.DB) error {
-		oldLogistic, errG := s.store.Logistic().Get(ctx, models.RequestId{Id: logistic.Id})
+		oldLogistic, errG := s.store.Logistic().Get(ctx, models.RequestId{Id: logistic.Id}, tx)
 		if errG != nil {
 			return errG
 		}
 
+		if logistic.Version != 0 && logistic.Version != oldLogistic.Version {
+			return &VersionConflictError{Current: oldLogistic}
+		}
+
+		provider, errP := resolveProvider(ctx, s.store, cargo.ProviderId, cargo.Provider, tx)
+		if errP != nil {
+			return errP
+		}
+
+		if create && provider.Blacklisted {
+			return apperr.Conflict("PROVIDER_BLACKLISTED", "provider %s is blacklisted", provider.Name)
+		}
+
+		if create {
+			warnings, errC := checkCompliance(ctx, s.store, oldLogistic.Driver, tx)
+			if errC != nil {
+				return errC
+			}
+			resp.Warnings = append(resp.Warnings, warnings...)
+
+			warnings, errC = checkHOS(ctx, s.store, oldLogistic.Driver, cargo, opts.OverrideHOS, tx)
+			if errC != nil {
+				return errC
+			}
+			resp.Warnings = append(resp.Warnings, warnings...)
+		}
+		cargo.ProviderId = &provider.Id
+		cargo.Provider = provider.Name
+
+		if errS := prepareStops(cargo); errS != nil {
+			return errS
+		}
+
 		if create && cargo.Id == uuid.Nil {
+			duplicates, errD := s.store.Cargo().FindDuplicates(ctx, cargo, duplicateLoadWindow, tx)
+			if errD != nil {
+				return errD
+			}
+			linkDuplicates(duplicates)
+
+			if !opts.OverrideDuplicate {
+				for _, duplicate := range duplicates {
+					if duplicate.Exact {
+						return &DuplicateLoadError{Duplicates: duplicates}
+					}
+				}
+			}
+
+			for _, duplicate := range duplicates {
+				resp.Warnings = append(resp.Warnings, duplicate.String())
+			}
+			resp.Duplicates = duplicates
+
+			cargo.DriverId = &oldLogistic.DriverId
+			cargo.Status = models.CargoStatusActive
 			id, err = s.store.Cargo().Create(ctx, cargo, tx)
 			if err != nil {
 				return err
@@ -135,7 +282,7 @@ func (s *LogisticService) UpdateWithCargo(ctx context.Context, logistic *models.
 			}
 
 			_, errH := s.store.History().Create(ctx, &models.History{
-				DriverName: oldLogistic.Driver.Name + oldLogistic.Driver.Surname,
+				DriverName: oldLogistic.Driver.FullName(),
 				LogisticId: logistic.Id,
 				FromLogistic: models.JSONBLogistic{
 					Post:       oldLogistic.Post,
@@ -155,42 +302,50 @@ func (s *LogisticService) UpdateWithCargo(ctx context.Context, logistic *models.
 					Location:   logistic.Location,
 					Notion:     logistic.Notion,
 				},
-				FromCargo: nil,
-				ToCargo: &models.JSONBCargo{
-					Id:           cargo.Id,
-					CargoID:      cargo.CargoID,
-					Provider:     cargo.Provider,
-					LoadedMiles:  cargo.LoadedMiles,
-					FreeMiles:    cargo.FreeMiles,
-					From:         cargo.From,
-					To:           cargo.To,
-					Cost:         cargo.Cost,
-					Rate:         cargo.Rate,
-					PickUpTime:   cargo.PickUpTime,
-					DeliveryTime: cargo.DeliveryTime,
-					EmployeeId:   cargo.EmployeeId,
-				},
+				FromCargo:  nil,
+				ToCargo:    models.NewJSONBCargo(cargo),
 				EmployeeId: by.Id,
-			})
+			}, tx)
 			if errH != nil {
 				return errH
 			}
 
 			logistic.CargoId = &cargoId
+
+			errE := emitLoadEvent(ctx, s.store, models.EventLoadCovered, oldLogistic, cargo, logistic.Status, "", tx)
+			if errE != nil {
+				return errE
+			}
 		} else {
+			oldCargo, errG := s.store.Cargo().Get(ctx, models.RequestId{Id: cargo.Id}, tx)
+			if errG != nil {
+				return errG
+			}
+
+			if cargo.Version != 0 && cargo.Version != oldCargo.Version {
+				oldLogistic.Cargo = *oldCargo
+				return &VersionConflictError{Current: oldLogistic, Cargo: true}
+			}
+
+			keepStopProgress(oldCargo.Stops, cargo.Stops)
+
+			if rescheduled(oldCargo, cargo) {
+				warnings, errC := checkHOS(ctx, s.store, oldLogistic.Driver, cargo, opts.OverrideHOS, tx)
+				if errC != nil {
+					return errC
+				}
+				resp.Warnings = append(resp.Warnings, warnings...)
+			}
+
 			err = s.store.Cargo().Update(ctx, cargo, tx)
 			if err != nil {
 				return err
 			}
+			cargo.Version = oldCargo.Version + 1
 			id = cargo.Id.String()
 
-			oldCargo, errG := s.store.Cargo().Get(ctx, models.RequestId{Id: cargo.Id})
-			if errG != nil {
-				return errG
-			}
-
 			_, errH := s.store.History().Create(ctx, &models.History{
-				DriverName: oldLogistic.Driver.Name + oldLogistic.Driver.Surname,
+				DriverName: oldLogistic.Driver.FullName(),
 				LogisticId: logistic.Id,
 				FromLogistic: models.JSONBLogistic{
 					Post:       oldLogistic.Post,
@@ -210,63 +365,58 @@ func (s *LogisticService) UpdateWithCargo(ctx context.Context, logistic *models.
 					Location:   logistic.Location,
 					Notion:     logistic.Notion,
 				},
-				FromCargo: &models.JSONBCargo{
-					Id:           oldCargo.Id,
-					CargoID:      oldCargo.CargoID,
-					Provider:     oldCargo.Provider,
-					LoadedMiles:  oldCargo.LoadedMiles,
-					FreeMiles:    oldCargo.FreeMiles,
-					From:         oldCargo.From,
-					To:           oldCargo.To,
-					Cost:         oldCargo.Cost,
-					Rate:         oldCargo.Rate,
-					PickUpTime:   oldCargo.PickUpTime,
-					DeliveryTime: oldCargo.DeliveryTime,
-					EmployeeId:   oldCargo.EmployeeId,
-				},
-				ToCargo: &models.JSONBCargo{
-					Id:           cargo.Id,
-					CargoID:      cargo.CargoID,
-					Provider:     cargo.Provider,
-					LoadedMiles:  cargo.LoadedMiles,
-					FreeMiles:    cargo.FreeMiles,
-					From:         cargo.From,
-					To:           cargo.To,
-					Cost:         cargo.Cost,
-					Rate:         cargo.Rate,
-					PickUpTime:   cargo.PickUpTime,
-					DeliveryTime: cargo.DeliveryTime,
-					EmployeeId:   cargo.EmployeeId,
-				},
+				FromCargo:  models.NewJSONBCargo(oldCargo),
+				ToCargo:    models.NewJSONBCargo(cargo),
 				EmployeeId: by.Id,
-			})
+			}, tx)
 			if errH != nil {
 				return errH
 			}
+
+			if pickedUp(oldLogistic, logistic.Status) {
+				errE := emitLoadEvent(ctx, s.store, models.EventLoadPickedUp, oldLogistic, cargo, logistic.Status, "", tx)
+				if errE != nil {
+					return errE
+				}
+			}
 		}
 
 		err = s.store.Logistic().Update(ctx, logistic, tx)
 		if err != nil {
 			return err
 		}
+		logistic.Version = oldLogistic.Version + 1
 
-		return nil
+		return trackDowntime(ctx, s.store, oldLogistic, logistic.Status, logistic.Notion, tx)
 	})
 	if transErr != nil {
-		return "", transErr
+		return nil, transErr
 	}
 
-	return id, nil
+	resp.Id = id
+	resp.CargoVersion = cargo.Version
+	return &resp, nil
 }
 
 func (s *LogisticService) Terminate(ctx context.Context, req models.RequestId, success bool, by models.RequestId) error {
-	db := s.store.DB()
+	ctx, span := tracer.Start(ctx, "LogisticService.Terminate")
+	defer span.End()
+
+	db := s.store.DB().WithContext(ctx)
 	err := db.Transaction(func(tx *gorm.DB) error {
-		logistic, err := s.store.Logistic().Get(ctx, req)
+		logistic, err := s.store.Logistic().Get(ctx, req, tx)
 		if err != nil {
 			return err
 		}
-		_, err = s.store.Transaction().Create(ctx, &models.Transaction{
+		if logistic.CargoId != nil {
+			cargo, errG := s.store.Cargo().Get(ctx, models.RequestId{Id: *logistic.CargoId}, tx)
+			if errG != nil {
+				return errG
+			}
+			logistic.Cargo = *cargo
+		}
+
+		transactionId, err := s.store.Transaction().Create(ctx, &models.Transaction{
 			From:         logistic.Cargo.From,
 			To:           logistic.Cargo.To,
 			PuTime:       logistic.Cargo.PickUpTime,
@@ -274,17 +424,35 @@ func (s *LogisticService) Terminate(ctx context.Context, req models.RequestId, s
 			LoadedMiles:  logistic.Cargo.LoadedMiles,
 			TotalMiles:   logistic.Cargo.LoadedMiles + logistic.Cargo.FreeMiles,
 			Provider:     logistic.Cargo.Provider,
+			ProviderId:   logistic.Cargo.ProviderId,
 			Cost:         logistic.Cargo.Cost,
 			Rate:         logistic.Cargo.Rate,
 			DriverId:     logistic.DriverId,
 			EmployeeId:   logistic.Cargo.EmployeeId,
 			CargoID:      logistic.Cargo.CargoID,
+			Stops:        models.NewJSONBStops(logistic.Cargo.Stops),
 			Success:      success,
 		}, tx)
 		if err != nil {
 			return err
 		}
 
+		if logistic.CargoId != nil {
+			status := models.CargoStatusDelivered
+			if !success {
+				status = models.CargoStatusCancelled
+			}
+			txId, errP := uuid.Parse(transactionId)
+			if errP != nil {
+				return errP
+			}
+
+			errC := s.store.Cargo().Close(ctx, models.RequestId{Id: *logistic.CargoId}, status, txId, tx)
+			if errC != nil {
+				return errC
+			}
+		}
+
 		if logistic.StTime == nil {
 			time := Utime.Now()
 			logistic.StTime = &time
@@ -306,8 +474,22 @@ func (s *LogisticService) Terminate(ctx context.Context, req models.RequestId, s
 			return errU
 		}
 
+		if errD := trackDowntime(ctx, s.store, logistic, models.LogisticStatusReady, "", tx); errD != nil {
+			return errD
+		}
+
+		if logistic.CargoId != nil {
+			event := models.EventLoadDelivered
+			if !success {
+				event = models.EventLoadCancelled
+			}
+			if errE := emitLoadEvent(ctx, s.store, event, logistic, &logistic.Cargo, logistic.Status, "", tx); errE != nil {
+				return errE
+			}
+		}
+
 		_, errH := s.store.History().Create(ctx, &models.History{
-			DriverName: logistic.Driver.Name + logistic.Driver.Surname,
+			DriverName: logistic.Driver.FullName(),
 			LogisticId: logistic.Id,
 			FromLogistic: models.JSONBLogistic{
 				Post:       logistic.Post,
@@ -327,20 +509,7 @@ func (s *LogisticService) Terminate(ctx context.Context, req models.RequestId, s
 				Location:   logistic.Location,
 				Notion:     "",
 			},
-			FromCargo: &models.JSONBCargo{
-				Id:           logistic.Cargo.Id,
-				CargoID:      logistic.Cargo.CargoID,
-				Provider:     logistic.Cargo.Provider,
-				LoadedMiles:  logistic.Cargo.LoadedMiles,
-				FreeMiles:    logistic.Cargo.FreeMiles,
-				From:         logistic.Cargo.From,
-				To:           logistic.Cargo.To,
-				Cost:         logistic.Cargo.Cost,
-				Rate:         logistic.Cargo.Rate,
-				PickUpTime:   logistic.Cargo.PickUpTime,
-				DeliveryTime: logistic.Cargo.DeliveryTime,
-				EmployeeId:   logistic.Cargo.EmployeeId,
-			},
+			FromCargo:  models.NewJSONBCargo(&logistic.Cargo),
 			ToCargo:    nil,
 			EmployeeId: by.Id,
 		}, tx)
@@ -358,18 +527,27 @@ func (s *LogisticService) Terminate(ctx context.Context, req models.RequestId, s
 }
 
 func (s *LogisticService) CancelLate(ctx context.Context, req swag.CancelLogistic, reqId models.RequestId, empId models.RequestId, compId models.RequestId) error {
-	db := s.store.DB()
+	ctx, span := tracer.Start(ctx, "LogisticService.CancelLate")
+	defer span.End()
+
+	db := s.store.DB().WithContext(ctx)
 
 	err := db.Transaction(func(tx *gorm.DB) error {
-		logistic, getErr := s.store.Logistic().Get(ctx, reqId)
+		logistic, getErr := s.store.Logistic().Get(ctx, reqId, tx)
 		if getErr != nil {
 			return getErr
 		}
 
 		if logistic.CargoId == nil {
-			return errors.New("cargo not found")
+			return apperr.NotFound(apperr.CodeNotFound, "cargo not found")
 		}
 
+		cargo, getErr := s.store.Cargo().Get(ctx, models.RequestId{Id: *logistic.CargoId}, tx)
+		if getErr != nil {
+			return getErr
+		}
+		logistic.Cargo = *cargo
+
 		if req.Cancel {
 			_, err := s.store.Performance().Create(ctx, &models.Performance{
 				Reason:     req.Reason,
@@ -384,7 +562,7 @@ func (s *LogisticService) CancelLate(ctx context.Context, req swag.CancelLogisti
 				return err
 			}
 
-			_, err = s.store.Transaction().Create(ctx, &models.Transaction{
+			transactionId, err := s.store.Transaction().Create(ctx, &models.Transaction{
 				From:         logistic.Cargo.From,
 				To:           logistic.Cargo.To,
 				PuTime:       logistic.Cargo.PickUpTime,
@@ -392,17 +570,29 @@ func (s *LogisticService) CancelLate(ctx context.Context, req swag.CancelLogisti
 				LoadedMiles:  logistic.Cargo.LoadedMiles,
 				TotalMiles:   logistic.Cargo.LoadedMiles + logistic.Cargo.FreeMiles,
 				Provider:     logistic.Cargo.Provider,
+				ProviderId:   logistic.Cargo.ProviderId,
 				Cost:         logistic.Cargo.Cost,
 				Rate:         logistic.Cargo.Rate,
 				DriverId:     logistic.DriverId,
 				EmployeeId:   logistic.Cargo.EmployeeId,
 				CargoID:      logistic.Cargo.CargoID,
+				Stops:        models.NewJSONBStops(logistic.Cargo.Stops),
 				Success:      false,
 			}, tx)
 			if err != nil {
 				return err
 			}
 
+			txId, err := uuid.Parse(transactionId)
+			if err != nil {
+				return err
+			}
+
+			err = s.store.Cargo().Close(ctx, models.RequestId{Id: *logistic.CargoId}, models.CargoStatusCancelled, txId, tx)
+			if err != nil {
+				return err
+			}
+
 			if logistic.StTime == nil {
 				time := Utime.Now()
 				logistic.StTime = &time
@@ -423,8 +613,17 @@ func (s *LogisticService) CancelLate(ctx context.Context, req swag.CancelLogisti
 				return errU
 			}
 
+			if errD := trackDowntime(ctx, s.store, logistic, models.LogisticStatusReady, "", tx); errD != nil {
+				return errD
+			}
+
+			errE := emitLoadEvent(ctx, s.store, models.EventLoadCancelled, logistic, &logistic.Cargo, logistic.Status, req.Reason, tx)
+			if errE != nil {
+				return errE
+			}
+
 			_, errH := s.store.History().Create(ctx, &models.History{
-				DriverName: logistic.Driver.Name + logistic.Driver.Surname,
+				DriverName: logistic.Driver.FullName(),
 				LogisticId: logistic.Id,
 				FromLogistic: models.JSONBLogistic{
 					Post:       logistic.Post,
@@ -444,20 +643,7 @@ func (s *LogisticService) CancelLate(ctx context.Context, req swag.CancelLogisti
 					Location:   logistic.Location,
 					Notion:     "",
 				},
-				FromCargo: &models.JSONBCargo{
-					Id:           logistic.Cargo.Id,
-					CargoID:      logistic.Cargo.CargoID,
-					Provider:     logistic.Cargo.Provider,
-					LoadedMiles:  logistic.Cargo.LoadedMiles,
-					FreeMiles:    logistic.Cargo.FreeMiles,
-					From:         logistic.Cargo.From,
-					To:           logistic.Cargo.To,
-					Cost:         logistic.Cargo.Cost,
-					Rate:         logistic.Cargo.Rate,
-					PickUpTime:   logistic.Cargo.PickUpTime,
-					DeliveryTime: logistic.Cargo.DeliveryTime,
-					EmployeeId:   logistic.Cargo.EmployeeId,
-				},
+				FromCargo:  models.NewJSONBCargo(&logistic.Cargo),
 				ToCargo:    nil,
 				EmployeeId: empId.Id,
 			}, tx)
@@ -487,8 +673,97 @@ func (s *LogisticService) CancelLate(ctx context.Context, req swag.CancelLogisti
 	return nil
 }
 
+// UpdateStopStatus marks a stop as pending, arrived or completed. The change
+// is written to the history of the logistic the cargo is on, a cargo no
+// longer on the board has none.
+func (s *LogisticService) UpdateStopStatus(ctx context.Context, req models.RequestId, status string, by models.RequestId) (*models.Stop, error) {
+	ctx, span := tracer.Start(ctx, "LogisticService.UpdateStopStatus")
+	defer span.End()
+
+	var stop *models.Stop
+	db := s.store.DB().WithContext(ctx)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		stop, err = s.store.Cargo().GetStop(ctx, req, tx)
+		if err != nil {
+			return err
+		}
+
+		oldCargo, err := s.store.Cargo().Get(ctx, models.RequestId{Id: stop.CargoId}, tx)
+		if err != nil {
+			return err
+		}
+
+		now := Utime.Now()
+		stop.Status = status
+		switch status {
+		case models.StopStatusPending:
+			stop.ArrivedAt = nil
+			stop.CompletedAt = nil
+		case models.StopStatusArrived:
+			stop.ArrivedAt = &now
+			stop.CompletedAt = nil
+		case models.StopStatusCompleted:
+			if stop.ArrivedAt == nil {
+				stop.ArrivedAt = &now
+			}
+			stop.CompletedAt = &now
+		default:
+			return apperr.Validation(apperr.CodeValidation, "unknown stop status %q", status)
+		}
+
+		if err = s.store.Cargo().UpdateStop(ctx, stop, tx); err != nil {
+			return err
+		}
+
+		logistic, err := s.store.Logistic().GetByCargo(ctx, stop.CargoId, tx)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		newCargo := *oldCargo
+		newCargo.Stops = slices.Clone(oldCargo.Stops)
+		for i := range newCargo.Stops {
+			if newCargo.Stops[i].Id == stop.Id {
+				newCargo.Stops[i] = *stop
+			}
+		}
+
+		state := models.JSONBLogistic{
+			Post:       logistic.Post,
+			Status:     logistic.Status,
+			UpdateTime: logistic.UpdateTime,
+			StTime:     logistic.StTime,
+			State:      logistic.State,
+			Location:   logistic.Location,
+			Notion:     logistic.Notion,
+		}
+		_, err = s.store.History().Create(ctx, &models.History{
+			DriverName:   logistic.Driver.FullName(),
+			LogisticId:   logistic.Id,
+			FromLogistic: state,
+			ToLogistic:   state,
+			FromCargo:    models.NewJSONBCargo(oldCargo),
+			ToCargo:      models.NewJSONBCargo(&newCargo),
+			EmployeeId:   by.Id,
+		}, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stop, nil
+}
+
 func (s *LogisticService) GetOverview(ctx context.Context) (models.GetOverview, error) {
-	resp, err := s.store.Logistic().Overview(ctx)
+	ctx, span := tracer.Start(ctx, "LogisticService.GetOverview")
+	defer span.End()
+
+	resp, err := s.store.Logistic().Overview(ctx, Utime.Now())
 	if err != nil {
 		return models.GetOverview{}, err
 	}
@@ -509,3 +784,94 @@ func (s *LogisticService) GetOverview(ctx context.Context) (models.GetOverview,
 
 	return resp, nil
 }
+
+// prepareStops makes sure a cargo always carries an ordered stop list and
+// keeps the legacy From/To and PickUpTime/DeliveryTime columns pointing at
+// the first pickup and the last delivery. Single-lane requests without stops
+// are turned into a pickup and a delivery stop.
+func prepareStops(cargo *models.Cargo) error {
+	if len(cargo.Stops) == 0 {
+		cargo.Stops = []models.Stop{
+			{Type: models.StopTypePickup, Location: cargo.From, AppointmentTime: cargo.PickUpTime},
+			{Type: models.StopTypeDelivery, Location: cargo.To, AppointmentTime: cargo.DeliveryTime},
+		}
+		return nil
+	}
+
+	if len(cargo.Stops) < 2 {
+		return apperr.Validation(apperr.CodeValidation, "cargo must have at least a pickup and a delivery stop")
+	}
+
+	first, last := cargo.Stops[0], cargo.Stops[len(cargo.Stops)-1]
+	if first.Type != models.StopTypePickup {
+		return apperr.Validation(apperr.CodeValidation, "first stop must be a pickup")
+	}
+	if last.Type != models.StopTypeDelivery {
+		return apperr.Validation(apperr.CodeValidation, "last stop must be a delivery")
+	}
+
+	for i, stop := range cargo.Stops {
+		if stop.Type != models.StopTypePickup && stop.Type != models.StopTypeDelivery {
+			return apperr.Validation(apperr.CodeValidation, "stop %d has unknown type %q", i+1, stop.Type)
+		}
+		if stop.Status != "" && !slices.Contains(models.StopStatuses, stop.Status) {
+			return apperr.Validation(apperr.CodeValidation, "stop %d has unknown status %q", i+1, stop.Status)
+		}
+		if i > 0 && stop.AppointmentTime.Before(cargo.Stops[i-1].AppointmentTime) {
+			return apperr.Validation(apperr.CodeValidation, "stop %d is scheduled before stop %d", i+1, i)
+		}
+	}
+
+	cargo.From = first.Location
+	cargo.PickUpTime = first.AppointmentTime
+	cargo.To = last.Location
+	cargo.DeliveryTime = last.AppointmentTime
+
+	return nil
+}
+
+// keepStopProgress copies the status of stops that did not change from the
+// stored cargo, so that editing a load does not reset stops already visited.
+// Stops sent with an id are matched by it, the others by position.
+func keepStopProgress(oldStops, newStops []models.Stop) {
+	for i := range newStops {
+		j := i
+		if newStops[i].Id != uuid.Nil {
+			j = slices.IndexFunc(oldStops, func(old models.Stop) bool { return old.Id == newStops[i].Id })
+		}
+		if j < 0 || j >= len(oldStops) {
+			continue
+		}
+
+		old := oldStops[j]
+		if newStops[i].Status != "" && newStops[i].Status != old.Status {
+			continue
+		}
+		if old.Type == newStops[i].Type && old.Location == newStops[i].Location {
+			newStops[i].Status = old.Status
+			newStops[i].ArrivedAt = old.ArrivedAt
+			newStops[i].CompletedAt = old.CompletedAt
+		}
+	}
+}
+
+func linkDuplicates(duplicates []models.DuplicateLoad) {
+	for i := range duplicates {
+		if duplicates[i].CargoId != nil {
+			duplicates[i].CargoLink = "/v1/cargos/" + duplicates[i].CargoId.String()
+		}
+		if duplicates[i].LogisticId != nil {
+			duplicates[i].LogisticLink = "/v1/logistics/" + duplicates[i].LogisticId.String()
+		}
+		if duplicates[i].TransactionId != nil {
+			duplicates[i].TransactionLink = "/v1/transactions/" + duplicates[i].TransactionId.String()
+		}
+	}
+}
+
+// rescheduled reports whether the times or miles of the cargo changed, so
+// that the hours of service of the driver have to be checked again.
+func rescheduled(old, cargo *models.Cargo) bool {
+	return !old.PickUpTime.Equal(cargo.PickUpTime) || !old.DeliveryTime.Equal(cargo.DeliveryTime) ||
+		old.LoadedMiles != cargo.LoadedMiles || old.FreeMiles != cargo.FreeMiles
+}
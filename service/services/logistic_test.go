@@ -0,0 +1,218 @@
+package services
+
+import (
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+// boardFixture is a logistic at version 3 carrying a cargo at version 5.
+func boardFixture(t *testing.T) (*fakeStore, models.Logistic, models.Cargo) {
+	t.Helper()
+
+	var (
+		pickUp = time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+		cargo  = models.Cargo{
+			Id:           uuid.New(),
+			CargoID:      "L-1",
+			Provider:     "TQL",
+			From:         "Dallas, TX",
+			To:           "Austin, TX",
+			PickUpTime:   pickUp,
+			DeliveryTime: pickUp.Add(24 * time.Hour),
+			LoadedMiles:  200,
+			Version:      5,
+		}
+		logistic = models.Logistic{
+			Id:       uuid.New(),
+			DriverId: uuid.New(),
+			Status:   "COVERED",
+			Version:  3,
+		}
+	)
+	logistic.Driver = models.Driver{Id: logistic.DriverId, Name: "John", Surname: "Smith"}
+
+	return newFakeStore(t, logistic, &cargo), logistic, cargo
+}
+
+func TestUpdateWithCargoVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		logisticVersion int64
+		cargoVersion    int64
+		wantCargo       bool
+		wantConflict    bool
+	}{
+		{name: "current versions", logisticVersion: 3, cargoVersion: 5},
+		{name: "no versions overwrite", logisticVersion: 0, cargoVersion: 0},
+		{name: "outdated logistic", logisticVersion: 2, cargoVersion: 5, wantConflict: true},
+		{name: "outdated cargo", logisticVersion: 3, cargoVersion: 4, wantConflict: true, wantCargo: true},
+		{name: "outdated cargo without If-Match", logisticVersion: 0, cargoVersion: 4, wantConflict: true, wantCargo: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, stored, cargo := boardFixture(t)
+			service := NewLogisticService(store)
+
+			logistic := models.Logistic{Id: stored.Id, Status: "ETA", Version: tt.logisticVersion}
+			cargo.Version = tt.cargoVersion
+			cargo.Cost = 1500
+
+			resp, err := service.UpdateWithCargo(context.Background(), &logistic, &cargo, models.UpdateWithCargoOptions{}, models.RequestId{Id: uuid.New()})
+
+			if !tt.wantConflict {
+				if err != nil {
+					t.Fatalf("UpdateWithCargo: %v", err)
+				}
+				if resp.CargoVersion != 6 || store.cargos.cargos[cargo.Id].Version != 6 {
+					t.Errorf("got cargo version %d in the response, %d stored, want 6", resp.CargoVersion, store.cargos.cargos[cargo.Id].Version)
+				}
+				if logistic.Version != 4 {
+					t.Errorf("got logistic version %d, want 4", logistic.Version)
+				}
+				if len(store.history.entries) != 1 {
+					t.Errorf("got %d history entries, want 1", len(store.history.entries))
+				}
+				return
+			}
+
+			var conflictErr *VersionConflictError
+			if !errors.As(err, &conflictErr) {
+				t.Fatalf("got %v, want a version conflict", err)
+			}
+			if conflictErr.Cargo != tt.wantCargo {
+				t.Errorf("got conflict on the cargo %t, want %t", conflictErr.Cargo, tt.wantCargo)
+			}
+			if conflictErr.Current.Version != 3 || conflictErr.Current.Cargo.Version != 5 {
+				t.Errorf("got current versions %d and %d, want 3 and 5", conflictErr.Current.Version, conflictErr.Current.Cargo.Version)
+			}
+			if store.cargos.updates != 0 || store.logistics.updates != 0 || len(store.history.entries) != 0 {
+				t.Errorf("a conflicting update was written")
+			}
+		})
+	}
+}
+
+// newLoad is a load of a lane other than the one of boardFixture.
+func newLoad() models.Cargo {
+	pickUp := time.Date(2024, time.May, 3, 8, 0, 0, 0, time.UTC)
+
+	return models.Cargo{
+		CargoID:      "L-2",
+		Provider:     "Coyote",
+		From:         "Austin, TX",
+		To:           "Houston, TX",
+		PickUpTime:   pickUp,
+		DeliveryTime: pickUp.Add(8 * time.Hour),
+	}
+}
+
+func TestUpdateWithCargoNewLoadStartsAtVersionOne(t *testing.T) {
+	store, stored, _ := boardFixture(t)
+	service := NewLogisticService(store)
+
+	logistic, cargo := models.Logistic{Id: stored.Id, Status: "COVERED"}, newLoad()
+	resp, err := service.UpdateWithCargo(context.Background(), &logistic, &cargo, models.UpdateWithCargoOptions{Create: true}, models.RequestId{Id: uuid.New()})
+	if err != nil {
+		t.Fatalf("UpdateWithCargo: %v", err)
+	}
+	if resp.CargoVersion != 1 {
+		t.Errorf("got cargo version %d, want 1", resp.CargoVersion)
+	}
+	if resp.Id == "" || store.logistics.logistic.CargoId == nil || store.logistics.logistic.CargoId.String() != resp.Id {
+		t.Errorf("new cargo %q is not on the logistic", resp.Id)
+	}
+}
+
+func TestUpdateWithCargoDuplicates(t *testing.T) {
+	var (
+		cargoId    = uuid.New()
+		logisticId = uuid.New()
+		pickUp     = time.Date(2024, time.May, 3, 9, 0, 0, 0, time.UTC)
+		exact      = models.DuplicateLoad{CargoId: &cargoId, LogisticId: &logisticId, LoadId: "L-2", Provider: "Coyote", Status: "ACTIVE", Exact: true}
+		sameLane   = models.DuplicateLoad{CargoId: &cargoId, LoadId: "9921", Provider: "TQL", From: "Austin, TX", To: "Houston, TX", PickUpTime: pickUp}
+	)
+
+	tests := []struct {
+		name         string
+		duplicates   []models.DuplicateLoad
+		override     bool
+		wantRefused  bool
+		wantWarnings []string
+	}{
+		{
+			name: "no duplicates",
+		},
+		{
+			name:         "same lane is a warning",
+			duplicates:   []models.DuplicateLoad{sameLane},
+			wantWarnings: []string{"load 9921 of TQL runs the same lane Austin, TX - Houston, TX at 2024-05-03 09:00"},
+		},
+		{
+			name:        "same load is refused",
+			duplicates:  []models.DuplicateLoad{sameLane, exact},
+			wantRefused: true,
+		},
+		{
+			name:       "same load with override",
+			duplicates: []models.DuplicateLoad{exact},
+			override:   true,
+			wantWarnings: []string{
+				"load L-2 was already booked with Coyote (ACTIVE)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, stored, _ := boardFixture(t)
+			store.cargos.duplicates = append([]models.DuplicateLoad(nil), tt.duplicates...)
+			service := NewLogisticService(store)
+
+			logistic, cargo := models.Logistic{Id: stored.Id, Status: "COVERED"}, newLoad()
+			opts := models.UpdateWithCargoOptions{Create: true, OverrideDuplicate: tt.override}
+			resp, err := service.UpdateWithCargo(context.Background(), &logistic, &cargo, opts, models.RequestId{Id: uuid.New()})
+
+			if tt.wantRefused {
+				var duplicateErr *DuplicateLoadError
+				if !errors.As(err, &duplicateErr) {
+					t.Fatalf("got %v, want a duplicate load error", err)
+				}
+				if len(duplicateErr.Duplicates) != 2 || duplicateErr.Duplicates[1].LogisticLink != "/v1/logistics/"+logisticId.String() {
+					t.Errorf("got duplicates %+v, want them linked", duplicateErr.Duplicates)
+				}
+				if len(store.cargos.cargos) != 1 || store.logistics.updates != 0 {
+					t.Errorf("a refused load was booked")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UpdateWithCargo: %v", err)
+			}
+			if len(store.cargos.cargos) != 2 {
+				t.Errorf("load was not booked")
+			}
+			if len(resp.Warnings) != len(tt.wantWarnings) {
+				t.Fatalf("got warnings %q, want %q", resp.Warnings, tt.wantWarnings)
+			}
+			for i := range tt.wantWarnings {
+				if resp.Warnings[i] != tt.wantWarnings[i] {
+					t.Errorf("got warning %q, want %q", resp.Warnings[i], tt.wantWarnings[i])
+				}
+			}
+			if len(resp.Duplicates) != len(tt.duplicates) {
+				t.Errorf("got %d duplicates in the response, want %d", len(resp.Duplicates), len(tt.duplicates))
+			}
+			for _, duplicate := range resp.Duplicates {
+				if duplicate.CargoLink != "/v1/cargos/"+cargoId.String() {
+					t.Errorf("got cargo link %q", duplicate.CargoLink)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+type MaintenanceService struct {
+	store database.IStore
+}
+
+func NewMaintenanceService(store database.IStore) *MaintenanceService {
+	return &MaintenanceService{store: store}
+}
+
+// CreateRecord saves the record and, once the service is completed, moves the
+// truck odometer forward and restarts the matching schedules.
+func (s *MaintenanceService) CreateRecord(ctx context.Context, record *models.MaintenanceRecord) (string, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.CreateRecord")
+	defer span.End()
+
+	var id string
+	err := s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := s.store.Equipment().GetTruck(ctx, models.RequestId{Id: record.TruckId}, tx); err != nil {
+			return err
+		}
+
+		var err error
+		id, err = s.store.Maintenance().CreateRecord(ctx, record, tx)
+		if err != nil {
+			return err
+		}
+
+		return s.applyRecord(ctx, record, tx)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateRecord keeps the record on the truck it was created for.
+func (s *MaintenanceService) UpdateRecord(ctx context.Context, record *models.MaintenanceRecord) error {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.UpdateRecord")
+	defer span.End()
+
+	old, err := s.store.Maintenance().GetRecord(ctx, models.RequestId{Id: record.Id})
+	if err != nil {
+		return err
+	}
+	record.TruckId = old.TruckId
+
+	return s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.store.Maintenance().UpdateRecord(ctx, record, tx); err != nil {
+			return err
+		}
+
+		return s.applyRecord(ctx, record, tx)
+	})
+}
+
+func (s *MaintenanceService) applyRecord(ctx context.Context, record *models.MaintenanceRecord, tx *gorm.DB) error {
+	if record.Odometer > 0 {
+		if err := s.store.Equipment().RaiseOdometer(ctx, record.TruckId, record.Odometer, tx); err != nil {
+			return err
+		}
+	}
+
+	if record.CompletedAt == nil {
+		return nil
+	}
+
+	return s.store.Maintenance().MarkServiced(ctx, record.TruckId, record.ServiceType, record.Odometer, *record.CompletedAt, tx)
+}
+
+func (s *MaintenanceService) DeleteRecord(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.DeleteRecord")
+	defer span.End()
+
+	return s.store.Maintenance().DeleteRecord(ctx, req)
+}
+
+func (s *MaintenanceService) GetRecord(ctx context.Context, req models.RequestId) (*models.MaintenanceRecord, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.GetRecord")
+	defer span.End()
+
+	record, err := s.store.Maintenance().GetRecord(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *MaintenanceService) GetAllRecords(ctx context.Context, req models.GetAllMaintenanceReq) (*models.GetAllMaintenanceRecordsResp, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.GetAllRecords")
+	defer span.End()
+
+	resp, err := s.store.Maintenance().GetAllRecords(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CreateSchedule starts counting the intervals from today and the current
+// odometer of the truck unless the last service is given.
+func (s *MaintenanceService) CreateSchedule(ctx context.Context, schedule *models.MaintenanceSchedule) (string, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.CreateSchedule")
+	defer span.End()
+
+	if schedule.IntervalMiles <= 0 && schedule.IntervalDays <= 0 {
+		return "", apperr.Validation(apperr.CodeValidation, "interval in miles or days is required")
+	}
+
+	truck, err := s.store.Equipment().GetTruck(ctx, models.RequestId{Id: schedule.TruckId})
+	if err != nil {
+		return "", err
+	}
+
+	if schedule.LastDate == nil {
+		now := Utime.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		schedule.LastDate = &today
+	}
+	if schedule.LastOdometer == 0 {
+		schedule.LastOdometer = truck.Odometer
+	}
+
+	id, err := s.store.Maintenance().CreateSchedule(ctx, schedule)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *MaintenanceService) UpdateSchedule(ctx context.Context, schedule *models.MaintenanceSchedule) error {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.UpdateSchedule")
+	defer span.End()
+
+	if schedule.IntervalMiles <= 0 && schedule.IntervalDays <= 0 {
+		return apperr.Validation(apperr.CodeValidation, "interval in miles or days is required")
+	}
+
+	return s.store.Maintenance().UpdateSchedule(ctx, schedule)
+}
+
+func (s *MaintenanceService) DeleteSchedule(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.DeleteSchedule")
+	defer span.End()
+
+	return s.store.Maintenance().DeleteSchedule(ctx, req)
+}
+
+func (s *MaintenanceService) GetAllSchedules(ctx context.Context, req models.GetAllMaintenanceReq) (*models.GetAllMaintenanceSchedulesResp, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.GetAllSchedules")
+	defer span.End()
+
+	resp, err := s.store.Maintenance().GetAllSchedules(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Due lists the schedules of active trucks that are due within the given
+// number of days or miles, overdue ones first.
+func (s *MaintenanceService) Due(ctx context.Context, days int, miles int64) ([]models.MaintenanceDue, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.Due")
+	defer span.End()
+
+	schedules, err := s.store.Maintenance().GetActiveSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now = Utime.Now()
+		due = make([]models.MaintenanceDue, 0)
+	)
+	for _, schedule := range schedules {
+		item := models.MaintenanceDue{
+			Schedule:    schedule,
+			TruckNumber: schedule.Truck.Number,
+			Odometer:    schedule.Truck.Odometer,
+		}
+		soon := false
+
+		if schedule.IntervalDays > 0 && schedule.LastDate != nil {
+			date := schedule.LastDate.AddDate(0, 0, schedule.IntervalDays)
+			left := int(date.Sub(now).Hours() / 24)
+			item.DueDate, item.DaysLeft = &date, &left
+			soon = soon || left <= days
+			item.Overdue = item.Overdue || date.Before(now)
+		}
+
+		if schedule.IntervalMiles > 0 {
+			odometer := schedule.LastOdometer + schedule.IntervalMiles
+			left := odometer - schedule.Truck.Odometer
+			item.DueOdometer, item.MilesLeft = &odometer, &left
+			soon = soon || left <= miles
+			item.Overdue = item.Overdue || left < 0
+		}
+
+		if soon {
+			due = append(due, item)
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if due[i].Overdue != due[j].Overdue {
+			return due[i].Overdue
+		}
+		return due[i].TruckNumber < due[j].TruckNumber
+	})
+
+	return due, nil
+}
+
+func (s *MaintenanceService) GetAllDowntimes(ctx context.Context, req models.GetAllDowntimesReq) (*models.GetAllDowntimesResp, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.GetAllDowntimes")
+	defer span.End()
+
+	resp, err := s.store.Maintenance().GetAllDowntimes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DowntimeReport sums downtime per truck and company for the days from
+// req.From through req.To.
+func (s *MaintenanceService) DowntimeReport(ctx context.Context, req models.DowntimeReportReq) (*models.DowntimeReport, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceService.DowntimeReport")
+	defer span.End()
+
+	if req.To.Before(req.From) {
+		return nil, apperr.Validation(apperr.CodeValidation, "to must not be before from")
+	}
+	to := req.To
+	req.To = req.To.AddDate(0, 0, 1)
+
+	report, err := s.store.Maintenance().DowntimeReport(ctx, req, Utime.Now())
+	if err != nil {
+		return nil, err
+	}
+	report.To = to
+
+	return report, nil
+}
+
+// trackDowntime opens a downtime for the driver's truck when the logistic
+// goes to TRUCK ISSUES and closes it when the logistic is READY again.
+func trackDowntime(ctx context.Context, store database.IStore, logistic *models.Logistic, status, reason string, tx *gorm.DB) error {
+	if status != models.LogisticStatusTruckIssues && status != models.LogisticStatusReady {
+		return nil
+	}
+
+	open, err := store.Maintenance().GetOpenDowntime(ctx, logistic.Id, tx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if status == models.LogisticStatusReady {
+		if open == nil {
+			return nil
+		}
+		return store.Maintenance().CloseDowntime(ctx, open.Id, Utime.Now(), tx)
+	}
+
+	if open != nil {
+		return nil
+	}
+
+	if logistic.Driver.Id == uuid.Nil {
+		return fmt.Errorf("driver of logistic %s is not loaded", logistic.Id)
+	}
+
+	_, err = store.Maintenance().OpenDowntime(ctx, &models.Downtime{
+		TruckId:     logistic.Driver.TruckId,
+		TruckNumber: logistic.Driver.TruckNumber,
+		DriverId:    logistic.DriverId,
+		CompanyId:   logistic.Driver.CompanyId,
+		LogisticId:  logistic.Id,
+		Reason:      reason,
+		StartedAt:   Utime.Now(),
+	}, tx)
+
+	return err
+}
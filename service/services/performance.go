@@ -15,6 +15,9 @@ func NewPerformanceService(store database.IStore) *PerformanceService {
 }
 
 func (s *PerformanceService) Create(ctx context.Context, req *models.Performance) (string, error) {
+	ctx, span := tracer.Start(ctx, "PerformanceService.Create")
+	defer span.End()
+
 	id, err := s.store.Performance().Create(ctx, req)
 	if err != nil {
 		return "", err
@@ -24,6 +27,9 @@ func (s *PerformanceService) Create(ctx context.Context, req *models.Performance
 }
 
 func (s *PerformanceService) Update(ctx context.Context, req *models.Performance) error {
+	ctx, span := tracer.Start(ctx, "PerformanceService.Update")
+	defer span.End()
+
 	err := s.store.Performance().Update(ctx, req)
 	if err != nil {
 		return err
@@ -33,6 +39,9 @@ func (s *PerformanceService) Update(ctx context.Context, req *models.Performance
 }
 
 func (s *PerformanceService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "PerformanceService.Delete")
+	defer span.End()
+
 	err := s.store.Performance().Delete(ctx, req)
 	if err != nil {
 		return err
@@ -42,6 +51,9 @@ func (s *PerformanceService) Delete(ctx context.Context, req models.RequestId) e
 }
 
 func (s *PerformanceService) Get(ctx context.Context, req models.RequestId) (*models.Performance, error) {
+	ctx, span := tracer.Start(ctx, "PerformanceService.Get")
+	defer span.End()
+
 	resp, err := s.store.Performance().Get(ctx, req)
 	if err != nil {
 		return resp, err
@@ -51,6 +63,9 @@ func (s *PerformanceService) Get(ctx context.Context, req models.RequestId) (*mo
 }
 
 func (s *PerformanceService) GetAll(ctx context.Context, req models.GetAllPerformancesReq) (*models.GetAllPerformancesResp, error) {
+	ctx, span := tracer.Start(ctx, "PerformanceService.GetAll")
+	defer span.End()
+
 	resp, err := s.store.Performance().GetAll(ctx, req)
 	if err != nil {
 		return resp, err
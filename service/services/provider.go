@@ -0,0 +1,108 @@
+package services
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProviderService struct {
+	store database.IStore
+}
+
+func NewProviderService(store database.IStore) *ProviderService {
+	return &ProviderService{store: store}
+}
+
+func (s *ProviderService) Create(ctx context.Context, provider *models.Provider) (string, error) {
+	ctx, span := tracer.Start(ctx, "ProviderService.Create")
+	defer span.End()
+
+	id, err := s.store.Provider().Create(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *ProviderService) Update(ctx context.Context, provider *models.Provider) error {
+	ctx, span := tracer.Start(ctx, "ProviderService.Update")
+	defer span.End()
+
+	return s.store.Provider().Update(ctx, provider)
+}
+
+func (s *ProviderService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "ProviderService.Delete")
+	defer span.End()
+
+	return s.store.Provider().Delete(ctx, req)
+}
+
+func (s *ProviderService) Get(ctx context.Context, req models.RequestId) (*models.Provider, error) {
+	ctx, span := tracer.Start(ctx, "ProviderService.Get")
+	defer span.End()
+
+	provider, err := s.store.Provider().Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	aging, err := s.store.Invoice().Aging(ctx, models.GetARAgingReq{ProviderId: provider.Id})
+	if err != nil {
+		return nil, err
+	}
+	provider.Aging = &models.ARAging{ProviderId: provider.Id, ProviderName: provider.Name}
+	if len(aging) > 0 {
+		provider.Aging = &aging[0]
+	}
+
+	return provider, nil
+}
+
+func (s *ProviderService) GetAll(ctx context.Context, req models.GetAllProvidersReq) (*models.GetAllProvidersResp, error) {
+	ctx, span := tracer.Start(ctx, "ProviderService.GetAll")
+	defer span.End()
+
+	resp, err := s.store.Provider().GetAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *ProviderService) Stats(ctx context.Context, req models.RequestId) (*models.ProviderStats, error) {
+	ctx, span := tracer.Start(ctx, "ProviderService.Stats")
+	defer span.End()
+
+	if _, err := s.store.Provider().Get(ctx, req); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.store.Provider().Stats(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// resolveProvider returns the provider referenced by id or, for clients that
+// still send a free-text name, the provider matching that name (created on
+// first use).
+func resolveProvider(ctx context.Context, store database.IStore, providerId *uuid.UUID, name string, tx *gorm.DB) (*models.Provider, error) {
+	if providerId != nil && *providerId != uuid.Nil {
+		return store.Provider().Get(ctx, models.RequestId{Id: *providerId}, tx)
+	}
+
+	if name == "" {
+		return nil, apperr.Validation(apperr.CodeValidation, "provider is required")
+	}
+
+	return store.Provider().GetOrCreateByName(ctx, name, tx)
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type SettlementService struct {
+	store database.IStore
+}
+
+func NewSettlementService(store database.IStore) *SettlementService {
+	return &SettlementService{store: store}
+}
+
+func (s *SettlementService) SavePayProfile(ctx context.Context, profile *models.PayProfile) error {
+	ctx, span := tracer.Start(ctx, "SettlementService.SavePayProfile")
+	defer span.End()
+
+	return s.store.Settlement().SavePayProfile(ctx, profile)
+}
+
+func (s *SettlementService) GetPayProfile(ctx context.Context, driverId uuid.UUID) (*models.PayProfile, error) {
+	ctx, span := tracer.Start(ctx, "SettlementService.GetPayProfile")
+	defer span.End()
+
+	profile, err := s.store.Settlement().GetPayProfile(ctx, driverId)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (s *SettlementService) CreateAdjustment(ctx context.Context, adjustment *models.SettlementAdjustment) (string, error) {
+	ctx, span := tracer.Start(ctx, "SettlementService.CreateAdjustment")
+	defer span.End()
+
+	id, err := s.store.Settlement().CreateAdjustment(ctx, adjustment)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *SettlementService) DeleteAdjustment(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "SettlementService.DeleteAdjustment")
+	defer span.End()
+
+	return s.store.Settlement().DeleteAdjustment(ctx, req)
+}
+
+func (s *SettlementService) GetAllAdjustments(ctx context.Context, req models.GetAllAdjustmentsReq) (*models.GetAllAdjustmentsResp, error) {
+	ctx, span := tracer.Start(ctx, "SettlementService.GetAllAdjustments")
+	defer span.End()
+
+	resp, err := s.store.Settlement().GetAllAdjustments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Run computes the settlements of every driver with a pay profile for the week
+// starting on weekStart (a Monday). Draft settlements of that week are
+// recomputed; locked ones are left as they are and reported as skipped.
+// Earlier loads no settlement has paid yet are settled with the week.
+func (s *SettlementService) Run(ctx context.Context, weekStart time.Time) (*models.SettlementRunResp, error) {
+	ctx, span := tracer.Start(ctx, "SettlementService.Run")
+	defer span.End()
+
+	var (
+		resp    = models.SettlementRunResp{WeekStart: weekStart}
+		weekEnd = weekStart.AddDate(0, 0, 6)
+		until   = weekStart.AddDate(0, 0, 7)
+	)
+
+	err := s.store.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		profiles, err := s.store.Settlement().GetPayProfiles(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			driverName := profile.Driver.FullName()
+
+			settlement, err := s.store.Settlement().GetByWeek(ctx, profile.DriverId, weekStart, tx)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				settlement = &models.Settlement{
+					DriverId:  profile.DriverId,
+					WeekStart: weekStart,
+					WeekEnd:   weekEnd,
+					Status:    models.SettlementStatusDraft,
+				}
+			} else if err != nil {
+				return err
+			} else if settlement.Status == models.SettlementStatusLocked {
+				resp.Skipped = append(resp.Skipped, driverName+": week is locked")
+				continue
+			}
+
+			transactions, err := s.store.Transaction().GetUnsettled(ctx, profile.DriverId, until, settlement.Id, tx)
+			if err != nil {
+				return err
+			}
+
+			adjustments, err := s.store.Settlement().GetAdjustmentsForWeek(ctx, profile.DriverId, weekStart, weekEnd, settlement.Id, tx)
+			if err != nil {
+				return err
+			}
+
+			if settlement.Id == uuid.Nil && len(transactions) == 0 && len(adjustments) == 0 {
+				continue
+			}
+
+			computeSettlement(settlement, profile, transactions, adjustments)
+
+			if err = s.store.Settlement().Save(ctx, settlement, tx); err != nil {
+				return err
+			}
+
+			settlement.Driver = profile.Driver
+			settlement.Lines = nil
+			resp.Settlements = append(resp.Settlements, *settlement)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	missing, err := s.store.Settlement().DriversWithoutProfile(ctx, weekStart, until)
+	if err != nil {
+		return nil, err
+	}
+	for _, driver := range missing {
+		resp.Skipped = append(resp.Skipped, driver.FullName()+": no pay profile")
+	}
+
+	return &resp, nil
+}
+
+// computeSettlement fills the totals and lines of a settlement from the
+// driver's delivered loads and adjustments.
+func computeSettlement(settlement *models.Settlement, profile models.PayProfile, transactions []models.Transaction, adjustments []models.SettlementAdjustment) {
+	*settlement = models.Settlement{
+		Id:        settlement.Id,
+		DriverId:  settlement.DriverId,
+		WeekStart: settlement.WeekStart,
+		WeekEnd:   settlement.WeekEnd,
+		Status:    settlement.Status,
+		Method:    profile.Method,
+	}
+
+	for _, t := range transactions {
+		var (
+			transactionId = t.Id
+			emptyMiles    = max(t.TotalMiles-t.LoadedMiles, 0)
+			pay           float64
+			description   = fmt.Sprintf("Load %s: %s - %s", t.CargoID, t.From, t.To)
+		)
+		switch profile.Method {
+		case models.PayMethodPercent:
+			pay = float64(t.Cost) * profile.Percent / 100
+			description += fmt.Sprintf(" (%.2f%% of $%d)", profile.Percent, t.Cost)
+		default:
+			pay = float64(t.LoadedMiles)*profile.LoadedRate + float64(emptyMiles)*profile.EmptyRate
+			description += fmt.Sprintf(" (%d loaded, %d empty mi)", t.LoadedMiles, emptyMiles)
+		}
+		pay = roundCents(pay)
+
+		settlement.Loads++
+		settlement.LoadedMiles += t.LoadedMiles
+		settlement.EmptyMiles += emptyMiles
+		settlement.Gross += float64(t.Cost)
+		settlement.Pay += pay
+		settlement.Lines = append(settlement.Lines, models.SettlementLine{
+			TransactionId: &transactionId,
+			Type:          models.SettlementLineLoad,
+			Description:   description,
+			Miles:         t.TotalMiles,
+			Amount:        pay,
+		})
+	}
+
+	for _, a := range adjustments {
+		adjustmentId := a.Id
+		amount := roundCents(a.Amount)
+		switch a.Type {
+		case models.SettlementLineDeduction:
+			settlement.Deductions += amount
+			amount = -amount
+		case models.SettlementLineAdvance:
+			settlement.Advances += amount
+			amount = -amount
+		case models.SettlementLineReimbursement:
+			settlement.Reimbursements += amount
+		default:
+			continue
+		}
+
+		settlement.Lines = append(settlement.Lines, models.SettlementLine{
+			AdjustmentId: &adjustmentId,
+			Type:         a.Type,
+			Description:  a.Description,
+			Amount:       amount,
+		})
+	}
+
+	settlement.Pay = roundCents(settlement.Pay)
+	settlement.NetPay = roundCents(settlement.Pay - settlement.Deductions - settlement.Advances + settlement.Reimbursements)
+}
+
+func (s *SettlementService) Lock(ctx context.Context, req models.RequestId, by models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "SettlementService.Lock")
+	defer span.End()
+
+	return s.store.Settlement().Lock(ctx, req, Utime.Now(), by)
+}
+
+func (s *SettlementService) Get(ctx context.Context, req models.RequestId) (*models.Settlement, error) {
+	ctx, span := tracer.Start(ctx, "SettlementService.Get")
+	defer span.End()
+
+	settlement, err := s.store.Settlement().Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return settlement, nil
+}
+
+func (s *SettlementService) GetAll(ctx context.Context, req models.GetAllSettlementsReq) (*models.GetAllSettlementsResp, error) {
+	ctx, span := tracer.Start(ctx, "SettlementService.GetAll")
+	defer span.End()
+
+	resp, err := s.store.Settlement().GetAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Export renders a locked settlement as a CSV pay statement. Drafts are not
+// exported because a later run may still change them.
+func (s *SettlementService) Export(ctx context.Context, req models.RequestId) (*models.Settlement, []byte, error) {
+	ctx, span := tracer.Start(ctx, "SettlementService.Export")
+	defer span.End()
+
+	settlement, err := s.store.Settlement().Get(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if settlement.Status != models.SettlementStatusLocked {
+		return nil, nil, apperr.Conflict("SETTLEMENT_NOT_LOCKED", "only locked settlements can be exported")
+	}
+
+	var (
+		buf   bytes.Buffer
+		w     = csv.NewWriter(&buf)
+		money = func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	)
+	records := [][]string{
+		{"Driver", settlement.Driver.FullName()},
+		{"Week", settlement.WeekStart.Format("2006-01-02") + " - " + settlement.WeekEnd.Format("2006-01-02")},
+		{"Pay method", settlement.Method},
+		{},
+		{"Type", "Description", "Miles", "Amount"},
+	}
+	for _, l := range settlement.Lines {
+		records = append(records, []string{l.Type, l.Description, strconv.FormatInt(l.Miles, 10), money(l.Amount)})
+	}
+	records = append(records,
+		[]string{},
+		[]string{"Loads", strconv.FormatInt(settlement.Loads, 10)},
+		[]string{"Loaded miles", strconv.FormatInt(settlement.LoadedMiles, 10)},
+		[]string{"Empty miles", strconv.FormatInt(settlement.EmptyMiles, 10)},
+		[]string{"Gross", money(settlement.Gross)},
+		[]string{"Pay", money(settlement.Pay)},
+		[]string{"Deductions", money(-settlement.Deductions)},
+		[]string{"Advances", money(-settlement.Advances)},
+		[]string{"Reimbursements", money(settlement.Reimbursements)},
+		[]string{"Net pay", money(settlement.NetPay)},
+	)
+
+	if err = w.WriteAll(records); err != nil {
+		return nil, nil, err
+	}
+
+	return settlement, buf.Bytes(), nil
+}
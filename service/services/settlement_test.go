@@ -0,0 +1,150 @@
+package services
+
+import (
+	"backend/models"
+	"context"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestComputeSettlement(t *testing.T) {
+	var (
+		load = models.Transaction{Id: uuid.New(), CargoID: "L-1", From: "Dallas, TX", To: "Austin, TX", LoadedMiles: 500, TotalMiles: 550, Cost: 2000}
+		// a load with fewer total than loaded miles has no empty miles
+		short       = models.Transaction{Id: uuid.New(), CargoID: "L-2", From: "Austin, TX", To: "Waco, TX", LoadedMiles: 101, TotalMiles: 90, Cost: 333}
+		adjustments = []models.SettlementAdjustment{
+			{Id: uuid.New(), Type: models.SettlementLineDeduction, Description: "Insurance", Amount: 100},
+			{Id: uuid.New(), Type: models.SettlementLineAdvance, Description: "Fuel advance", Amount: 50.006},
+			{Id: uuid.New(), Type: models.SettlementLineReimbursement, Description: "Scale ticket", Amount: 12.5},
+			{Id: uuid.New(), Type: "BONUS", Description: "Unknown", Amount: 1000},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		profile     models.PayProfile
+		loads       []models.Transaction
+		adjustments []models.SettlementAdjustment
+		want        models.Settlement
+		wantLines   []string
+	}{
+		{
+			name:      "cents per mile",
+			profile:   models.PayProfile{Method: models.PayMethodCPM, LoadedRate: 0.6, EmptyRate: 0.3},
+			loads:     []models.Transaction{load, short},
+			want:      models.Settlement{Method: models.PayMethodCPM, Loads: 2, LoadedMiles: 601, EmptyMiles: 50, Gross: 2333, Pay: 375.6, NetPay: 375.6},
+			wantLines: []string{"Load L-1: Dallas, TX - Austin, TX (500 loaded, 50 empty mi)", "Load L-2: Austin, TX - Waco, TX (101 loaded, 0 empty mi)"},
+		},
+		{
+			name:      "percent of the gross",
+			profile:   models.PayProfile{Method: models.PayMethodPercent, Percent: 27.5},
+			loads:     []models.Transaction{load, short},
+			want:      models.Settlement{Method: models.PayMethodPercent, Loads: 2, LoadedMiles: 601, EmptyMiles: 50, Gross: 2333, Pay: 641.58, NetPay: 641.58},
+			wantLines: []string{"Load L-1: Dallas, TX - Austin, TX (27.50% of $2000)", "Load L-2: Austin, TX - Waco, TX (27.50% of $333)"},
+		},
+		{
+			name:        "adjustments without loads",
+			profile:     models.PayProfile{Method: models.PayMethodCPM, LoadedRate: 0.6},
+			adjustments: adjustments,
+			want:        models.Settlement{Method: models.PayMethodCPM, Deductions: 100, Advances: 50.01, Reimbursements: 12.5, NetPay: -137.51},
+			wantLines:   []string{"Insurance", "Fuel advance", "Scale ticket"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlement := models.Settlement{Id: uuid.New(), Status: models.SettlementStatusDraft, Loads: 9, Pay: 999}
+			computeSettlement(&settlement, tt.profile, tt.loads, tt.adjustments)
+
+			got := settlement
+			got.Id, got.Status, got.Lines = uuid.Nil, "", nil
+			if got.Method != tt.want.Method || got.Loads != tt.want.Loads || got.LoadedMiles != tt.want.LoadedMiles ||
+				got.EmptyMiles != tt.want.EmptyMiles || got.Gross != tt.want.Gross || got.Pay != tt.want.Pay ||
+				got.Deductions != tt.want.Deductions || got.Advances != tt.want.Advances ||
+				got.Reimbursements != tt.want.Reimbursements || got.NetPay != tt.want.NetPay {
+				t.Errorf("got totals %+v, want %+v", got, tt.want)
+			}
+			if settlement.Status != models.SettlementStatusDraft {
+				t.Errorf("got status %q, want it kept", settlement.Status)
+			}
+
+			if len(settlement.Lines) != len(tt.wantLines) {
+				t.Fatalf("got %d lines, want %d", len(settlement.Lines), len(tt.wantLines))
+			}
+			var sum float64
+			for i, line := range settlement.Lines {
+				if line.Description != tt.wantLines[i] {
+					t.Errorf("got line %q, want %q", line.Description, tt.wantLines[i])
+				}
+				sum += line.Amount
+			}
+			if roundCents(sum) != settlement.NetPay {
+				t.Errorf("lines add up to %.2f, net pay is %.2f", sum, settlement.NetPay)
+			}
+		})
+	}
+}
+
+func TestSettlementRun(t *testing.T) {
+	var (
+		weekStart = time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC)
+		earlier   = weekStart.AddDate(0, 0, -7)
+		john      = models.Driver{Id: uuid.New(), Name: "John", Surname: "Smith"}
+		jane      = models.Driver{Id: uuid.New(), Name: "Jane", Surname: "Doe"}
+		jim       = models.Driver{Id: uuid.New(), Name: "Jim", Surname: "Beam"}
+		store     = newFakeStore(t, models.Logistic{}, nil)
+		service   = NewSettlementService(store)
+	)
+
+	store.settlements.profiles = []models.PayProfile{
+		{DriverId: john.Id, Driver: john, Method: models.PayMethodCPM, LoadedRate: 0.5},
+		{DriverId: jane.Id, Driver: jane, Method: models.PayMethodPercent, Percent: 25},
+	}
+	store.settlements.missing = []models.Driver{jim}
+	store.settlements.settlements[settlementKey{jane.Id, weekStart}] = models.Settlement{Id: uuid.New(), DriverId: jane.Id, WeekStart: weekStart, Status: models.SettlementStatusLocked}
+
+	var (
+		// paid by the settlement of the week before
+		paid   = models.Transaction{Id: uuid.New(), DriverId: john.Id, CargoID: "L-1", LoadedMiles: 100, Success: true, DeliveryTime: earlier.AddDate(0, 0, 1)}
+		late   = models.Transaction{Id: uuid.New(), DriverId: john.Id, CargoID: "L-2", LoadedMiles: 200, Success: true, DeliveryTime: earlier.AddDate(0, 0, 6)}
+		week   = models.Transaction{Id: uuid.New(), DriverId: john.Id, CargoID: "L-3", LoadedMiles: 300, Success: true, DeliveryTime: weekStart.AddDate(0, 0, 6).Add(23 * time.Hour)}
+		next   = models.Transaction{Id: uuid.New(), DriverId: john.Id, CargoID: "L-4", LoadedMiles: 400, Success: true, DeliveryTime: weekStart.AddDate(0, 0, 7)}
+		failed = models.Transaction{Id: uuid.New(), DriverId: john.Id, CargoID: "L-5", LoadedMiles: 500, DeliveryTime: weekStart.AddDate(0, 0, 1)}
+		janes  = models.Transaction{Id: uuid.New(), DriverId: jane.Id, CargoID: "L-6", Cost: 1000, Success: true, DeliveryTime: weekStart.AddDate(0, 0, 1)}
+	)
+	for _, transaction := range []models.Transaction{paid, late, week, next, failed, janes} {
+		store.transactions.transactions[transaction.Id] = transaction
+	}
+	store.transactions.settled[paid.Id] = uuid.New()
+
+	for run := 1; run <= 2; run++ {
+		resp, err := service.Run(context.Background(), weekStart)
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+
+		if len(resp.Settlements) != 1 {
+			t.Fatalf("run %d: got %d settlements, want John's only", run, len(resp.Settlements))
+		}
+		settlement := resp.Settlements[0]
+		if settlement.DriverId != john.Id || settlement.Loads != 2 || settlement.LoadedMiles != 500 || settlement.Pay != 250 {
+			t.Errorf("run %d: got %d loads, %d loaded miles and pay %.2f, want the late and the week's load", run, settlement.Loads, settlement.LoadedMiles, settlement.Pay)
+		}
+		if !settlement.WeekEnd.Equal(weekStart.AddDate(0, 0, 6)) || settlement.Status != models.SettlementStatusDraft {
+			t.Errorf("run %d: got week end %s and status %q", run, settlement.WeekEnd, settlement.Status)
+		}
+
+		want := []string{"Jane Doe: week is locked", "Jim Beam: no pay profile"}
+		if len(resp.Skipped) != len(want) || resp.Skipped[0] != want[0] || resp.Skipped[1] != want[1] {
+			t.Errorf("run %d: got skipped %q, want %q", run, resp.Skipped, want)
+		}
+	}
+
+	if len(store.settlements.settlements) != 2 {
+		t.Errorf("got %d settlements stored, want the draft recomputed in place", len(store.settlements.settlements))
+	}
+	if store.transactions.settled[janes.Id] != uuid.Nil {
+		t.Errorf("a load was settled on a locked week")
+	}
+}
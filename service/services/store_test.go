@@ -0,0 +1,405 @@
+package services
+
+import (
+	"backend/models"
+	database "backend/st_database"
+	"backend/st_database/storage"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeConn is a database connection that only opens and closes transactions,
+// so services can run their transactions around the fake repositories.
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return fakeConn{}, nil }
+func (fakeConn) Commit() error             { return nil }
+func (fakeConn) Rollback() error           { return nil }
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return fakeConnector{} }
+func (fakeConnector) Open(string) (driver.Conn, error)             { return fakeConn{}, nil }
+
+// fakeStore keeps the board in memory: one logistic with its driver, the
+// cargos, and what the services write next to them. Repositories it does not
+// fake panic if used.
+type fakeStore struct {
+	database.IStore
+	db *gorm.DB
+
+	logistics    *fakeLogistics
+	cargos       *fakeCargos
+	companies    *fakeCompanies
+	providers    *fakeProviders
+	transactions *fakeTransactions
+	invoices     *fakeInvoices
+	settlements  *fakeSettlements
+	history      *fakeHistory
+	compliance   *fakeCompliance
+	hos          *fakeHOS
+	webhooks     *fakeWebhooks
+}
+
+func (s *fakeStore) DB() *gorm.DB                     { return s.db }
+func (s *fakeStore) Logistic() storage.Logistic       { return s.logistics }
+func (s *fakeStore) Cargo() storage.Cargo             { return s.cargos }
+func (s *fakeStore) Company() storage.Company         { return s.companies }
+func (s *fakeStore) Provider() storage.Provider       { return s.providers }
+func (s *fakeStore) Transaction() storage.Transaction { return s.transactions }
+func (s *fakeStore) Invoice() storage.Invoice         { return s.invoices }
+func (s *fakeStore) Settlement() storage.Settlement   { return s.settlements }
+func (s *fakeStore) History() storage.History         { return s.history }
+func (s *fakeStore) Compliance() storage.Compliance   { return s.compliance }
+func (s *fakeStore) HOS() storage.HOS                 { return s.hos }
+func (s *fakeStore) Webhook() storage.Webhook         { return s.webhooks }
+
+// newFakeStore puts logistic on the board, with cargo attached when it is
+// not nil.
+func newFakeStore(t *testing.T, logistic models.Logistic, cargo *models.Cargo) *fakeStore {
+	t.Helper()
+
+	sqlDB := sql.OpenDB(fakeConnector{})
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &fakeStore{
+		db:           db,
+		logistics:    &fakeLogistics{logistic: logistic},
+		cargos:       &fakeCargos{cargos: map[uuid.UUID]models.Cargo{}},
+		companies:    &fakeCompanies{companies: map[uuid.UUID]models.Company{}},
+		providers:    &fakeProviders{providers: map[uuid.UUID]models.Provider{}},
+		transactions: &fakeTransactions{transactions: map[uuid.UUID]models.Transaction{}, settled: map[uuid.UUID]uuid.UUID{}},
+		invoices:     &fakeInvoices{invoices: map[uuid.UUID]models.Invoice{}, counters: map[uuid.UUID]int64{}},
+		history:      &fakeHistory{},
+		compliance:   &fakeCompliance{},
+		hos:          &fakeHOS{},
+		webhooks:     &fakeWebhooks{},
+	}
+	store.settlements = &fakeSettlements{settlements: map[settlementKey]models.Settlement{}, transactions: store.transactions}
+	if cargo != nil {
+		store.cargos.cargos[cargo.Id] = *cargo
+		store.logistics.logistic.CargoId, store.logistics.logistic.Cargo = &cargo.Id, *cargo
+	}
+
+	return store
+}
+
+type fakeLogistics struct {
+	storage.Logistic
+	logistic models.Logistic
+	updates  int
+}
+
+func (f *fakeLogistics) Get(_ context.Context, req models.RequestId, _ ...*gorm.DB) (*models.Logistic, error) {
+	if req.Id != f.logistic.Id {
+		return nil, gorm.ErrRecordNotFound
+	}
+	logistic := f.logistic
+	return &logistic, nil
+}
+
+func (f *fakeLogistics) Update(_ context.Context, update *models.Logistic, _ ...*gorm.DB) error {
+	f.updates++
+	f.logistic.Status, f.logistic.CargoId = update.Status, update.CargoId
+	f.logistic.Version++
+	return nil
+}
+
+type fakeCargos struct {
+	storage.Cargo
+	cargos     map[uuid.UUID]models.Cargo
+	duplicates []models.DuplicateLoad
+	updates    int
+}
+
+func (f *fakeCargos) Get(_ context.Context, req models.RequestId, _ ...*gorm.DB) (*models.Cargo, error) {
+	cargo, ok := f.cargos[req.Id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &cargo, nil
+}
+
+func (f *fakeCargos) Create(_ context.Context, cargo *models.Cargo, _ ...*gorm.DB) (string, error) {
+	cargo.Id, cargo.Version = uuid.New(), 1
+	f.cargos[cargo.Id] = *cargo
+	return cargo.Id.String(), nil
+}
+
+func (f *fakeCargos) Update(_ context.Context, cargo *models.Cargo, _ ...*gorm.DB) error {
+	f.updates++
+	version := f.cargos[cargo.Id].Version
+	f.cargos[cargo.Id] = *cargo
+	stored := f.cargos[cargo.Id]
+	stored.Version = version + 1
+	f.cargos[cargo.Id] = stored
+	return nil
+}
+
+func (f *fakeCargos) FindDuplicates(context.Context, *models.Cargo, time.Duration, ...*gorm.DB) ([]models.DuplicateLoad, error) {
+	return f.duplicates, nil
+}
+
+type fakeCompanies struct {
+	storage.Company
+	companies map[uuid.UUID]models.Company
+}
+
+func (f *fakeCompanies) Get(_ context.Context, req models.RequestId) (*models.Company, error) {
+	company, ok := f.companies[req.Id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &company, nil
+}
+
+type fakeProviders struct {
+	storage.Provider
+	providers map[uuid.UUID]models.Provider
+}
+
+func (f *fakeProviders) Get(_ context.Context, req models.RequestId, _ ...*gorm.DB) (*models.Provider, error) {
+	provider, ok := f.providers[req.Id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &provider, nil
+}
+
+func (f *fakeProviders) GetOrCreateByName(_ context.Context, name string, _ ...*gorm.DB) (*models.Provider, error) {
+	return &models.Provider{Id: uuid.NewSHA1(uuid.Nil, []byte(name)), Name: name}, nil
+}
+
+// fakeTransactions keeps in settled the settlement each settled load is on.
+type fakeTransactions struct {
+	storage.Transaction
+	transactions map[uuid.UUID]models.Transaction
+	settled      map[uuid.UUID]uuid.UUID
+}
+
+func (f *fakeTransactions) GetUnsettled(_ context.Context, driverId uuid.UUID, until time.Time, settlementId uuid.UUID, _ ...*gorm.DB) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	for id, transaction := range f.transactions {
+		if transaction.DriverId != driverId || !transaction.Success || !transaction.DeliveryTime.Before(until) {
+			continue
+		}
+		if on, ok := f.settled[id]; ok && on != settlementId {
+			continue
+		}
+		transactions = append(transactions, transaction)
+	}
+	slices.SortFunc(transactions, func(a, b models.Transaction) int { return a.DeliveryTime.Compare(b.DeliveryTime) })
+	return transactions, nil
+}
+
+func (f *fakeTransactions) GetForInvoice(_ context.Context, ids []uuid.UUID, _ ...*gorm.DB) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	for _, id := range ids {
+		if transaction, ok := f.transactions[id]; ok {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, nil
+}
+
+func (f *fakeTransactions) SetInvoice(_ context.Context, ids []uuid.UUID, invoiceId uuid.UUID, _ ...*gorm.DB) error {
+	for _, id := range ids {
+		transaction := f.transactions[id]
+		transaction.InvoiceId = &invoiceId
+		f.transactions[id] = transaction
+	}
+	return nil
+}
+
+func (f *fakeTransactions) ReleaseInvoice(_ context.Context, invoiceId uuid.UUID, _ ...*gorm.DB) error {
+	for id, transaction := range f.transactions {
+		if transaction.InvoiceId != nil && *transaction.InvoiceId == invoiceId {
+			transaction.InvoiceId = nil
+			f.transactions[id] = transaction
+		}
+	}
+	return nil
+}
+
+// fakeInvoices numbers invoices from a counter per company, as the invoice
+// counters table does.
+type fakeInvoices struct {
+	storage.Invoice
+	invoices map[uuid.UUID]models.Invoice
+	counters map[uuid.UUID]int64
+	payments []models.InvoicePayment
+}
+
+func (f *fakeInvoices) NextSequence(_ context.Context, companyId uuid.UUID, _ ...*gorm.DB) (int64, error) {
+	f.counters[companyId]++
+	return f.counters[companyId], nil
+}
+
+func (f *fakeInvoices) Create(_ context.Context, invoice *models.Invoice, _ ...*gorm.DB) (string, error) {
+	invoice.Id = uuid.New()
+	f.invoices[invoice.Id] = *invoice
+	return invoice.Id.String(), nil
+}
+
+func (f *fakeInvoices) Get(_ context.Context, req models.RequestId, _ ...*gorm.DB) (*models.Invoice, error) {
+	invoice, ok := f.invoices[req.Id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &invoice, nil
+}
+
+func (f *fakeInvoices) UpdateStatus(_ context.Context, invoice *models.Invoice, _ ...*gorm.DB) error {
+	f.invoices[invoice.Id] = *invoice
+	return nil
+}
+
+func (f *fakeInvoices) AddPayment(_ context.Context, payment *models.InvoicePayment, _ ...*gorm.DB) (string, error) {
+	payment.Id = uuid.New()
+	f.payments = append(f.payments, *payment)
+	return payment.Id.String(), nil
+}
+
+type settlementKey struct {
+	driverId  uuid.UUID
+	weekStart time.Time
+}
+
+type fakeSettlements struct {
+	storage.Settlement
+	profiles     []models.PayProfile
+	adjustments  []models.SettlementAdjustment
+	settlements  map[settlementKey]models.Settlement
+	missing      []models.Driver
+	transactions *fakeTransactions
+}
+
+func (f *fakeSettlements) GetPayProfiles(context.Context, ...*gorm.DB) ([]models.PayProfile, error) {
+	return f.profiles, nil
+}
+
+func (f *fakeSettlements) GetByWeek(_ context.Context, driverId uuid.UUID, weekStart time.Time, _ ...*gorm.DB) (*models.Settlement, error) {
+	settlement, ok := f.settlements[settlementKey{driverId, weekStart}]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &settlement, nil
+}
+
+func (f *fakeSettlements) GetAdjustmentsForWeek(_ context.Context, driverId uuid.UUID, _, weekEnd time.Time, _ uuid.UUID, _ ...*gorm.DB) ([]models.SettlementAdjustment, error) {
+	var adjustments []models.SettlementAdjustment
+	for _, adjustment := range f.adjustments {
+		if adjustment.DriverId == driverId && !adjustment.Date.After(weekEnd) {
+			adjustments = append(adjustments, adjustment)
+		}
+	}
+	return adjustments, nil
+}
+
+func (f *fakeSettlements) Save(_ context.Context, settlement *models.Settlement, _ ...*gorm.DB) error {
+	if settlement.Id == uuid.Nil {
+		settlement.Id = uuid.New()
+	}
+	for _, line := range settlement.Lines {
+		if line.TransactionId != nil {
+			f.transactions.settled[*line.TransactionId] = settlement.Id
+		}
+	}
+	f.settlements[settlementKey{settlement.DriverId, settlement.WeekStart}] = *settlement
+	return nil
+}
+
+func (f *fakeSettlements) DriversWithoutProfile(context.Context, time.Time, time.Time) ([]models.Driver, error) {
+	return f.missing, nil
+}
+
+func (f *fakeInvoices) UpdateFactoring(_ context.Context, factoring *models.Invoice, _ ...*gorm.DB) error {
+	invoice := f.invoices[factoring.Id]
+	invoice.Factored, invoice.FactoringCompany, invoice.FactoredAt = factoring.Factored, factoring.FactoringCompany, factoring.FactoredAt
+	invoice.AdvanceAmount, invoice.ReserveAmount, invoice.FactoringFee = factoring.AdvanceAmount, factoring.ReserveAmount, factoring.FactoringFee
+	invoice.ReserveReleasedAt = factoring.ReserveReleasedAt
+	f.invoices[factoring.Id] = invoice
+	return nil
+}
+
+func (f *fakeInvoices) GetForLedger(_ context.Context, providerId uuid.UUID) ([]models.Invoice, error) {
+	var invoices []models.Invoice
+	for _, invoice := range f.invoices {
+		if invoice.ProviderId == providerId && (invoice.Status == models.InvoiceStatusSent || invoice.Status == models.InvoiceStatusPaid) {
+			invoice.Payments = nil
+			for _, payment := range f.payments {
+				if payment.InvoiceId == invoice.Id {
+					invoice.Payments = append(invoice.Payments, payment)
+				}
+			}
+			invoices = append(invoices, invoice)
+		}
+	}
+	return invoices, nil
+}
+
+type fakeHistory struct {
+	storage.History
+	entries []models.History
+}
+
+func (f *fakeHistory) Create(_ context.Context, history *models.History, _ ...*gorm.DB) (string, error) {
+	f.entries = append(f.entries, *history)
+	return "", nil
+}
+
+// fakeCompliance has the compliance record of at most one driver.
+type fakeCompliance struct {
+	storage.Compliance
+	record *models.DriverCompliance
+}
+
+func (f *fakeCompliance) GetByDriver(_ context.Context, driverId uuid.UUID, _ ...*gorm.DB) (*models.DriverCompliance, error) {
+	if f.record == nil || f.record.DriverId != driverId {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return f.record, nil
+}
+
+// fakeHOS has the hours of service of at most one driver.
+type fakeHOS struct {
+	storage.HOS
+	hos *models.DriverHOS
+}
+
+func (f *fakeHOS) GetByDriver(_ context.Context, driverId uuid.UUID, _ ...*gorm.DB) (*models.DriverHOS, error) {
+	if f.hos == nil || f.hos.DriverId != driverId {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return f.hos, nil
+}
+
+type fakeWebhooks struct {
+	storage.Webhook
+	events []models.WebhookEvent
+}
+
+func (f *fakeWebhooks) CreateEvent(_ context.Context, event *models.WebhookEvent, _ ...*gorm.DB) error {
+	f.events = append(f.events, *event)
+	return nil
+}
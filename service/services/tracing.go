@@ -0,0 +1,7 @@
+package services
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for every service method, between the request span and
+// the spans of its queries.
+var tracer = otel.Tracer("backend/service/services")
@@ -17,6 +17,16 @@ func NewTransactionService(store database.IStore) *TransactionService {
 }
 
 func (s *TransactionService) Create(ctx context.Context, transaction *models.Transaction) (string, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.Create")
+	defer span.End()
+
+	provider, err := resolveProvider(ctx, s.store, transaction.ProviderId, transaction.Provider, nil)
+	if err != nil {
+		return "", err
+	}
+	transaction.ProviderId = &provider.Id
+	transaction.Provider = provider.Name
+
 	id, err := s.store.Transaction().Create(ctx, transaction)
 	if err != nil {
 		return "", err
@@ -26,14 +36,30 @@ func (s *TransactionService) Create(ctx context.Context, transaction *models.Tra
 }
 
 func (s *TransactionService) Update(ctx context.Context, transaction *models.Transaction) error {
+	ctx, span := tracer.Start(ctx, "TransactionService.Update")
+	defer span.End()
+
+	provider, err := resolveProvider(ctx, s.store, transaction.ProviderId, transaction.Provider, nil)
+	if err != nil {
+		return err
+	}
+	transaction.ProviderId = &provider.Id
+	transaction.Provider = provider.Name
+
 	return s.store.Transaction().Update(ctx, transaction)
 }
 
 func (s *TransactionService) Delete(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "TransactionService.Delete")
+	defer span.End()
+
 	return s.store.Transaction().Delete(ctx, req)
 }
 
 func (s *TransactionService) Get(ctx context.Context, req models.RequestId) (*models.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.Get")
+	defer span.End()
+
 	resp, err := s.store.Transaction().Get(ctx, req)
 	if err != nil {
 		return nil, err
@@ -43,6 +69,9 @@ func (s *TransactionService) Get(ctx context.Context, req models.RequestId) (*mo
 }
 
 func (s *TransactionService) GetAll(ctx context.Context, req models.GetAllTransReq) (*models.GetAllTransResp, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.GetAll")
+	defer span.End()
+
 	resp, err := s.store.Transaction().GetAll(ctx, req)
 	if err != nil {
 		return nil, err
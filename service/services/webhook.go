@@ -0,0 +1,211 @@
+package services
+
+import (
+	"backend/etc/Utime"
+	"backend/etc/apperr"
+	"backend/models"
+	database "backend/st_database"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"gorm.io/gorm"
+	"net/url"
+	"strings"
+)
+
+var eventTypes = []string{
+	models.EventLoadCovered,
+	models.EventLoadPickedUp,
+	models.EventLoadDelivered,
+	models.EventLoadCancelled,
+}
+
+type WebhookService struct {
+	store database.IStore
+}
+
+func NewWebhookService(store database.IStore) *WebhookService {
+	return &WebhookService{store: store}
+}
+
+// CreateSubscription generates a signing secret unless one is given and
+// returns it, since it is never shown again.
+func (s *WebhookService) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.CreateSubscriptionResp, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.CreateSubscription")
+	defer span.End()
+
+	if err := validateSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	if subscription.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+
+	id, err := s.store.Webhook().CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreateSubscriptionResp{Id: id, Secret: subscription.Secret}, nil
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ctx, span := tracer.Start(ctx, "WebhookService.UpdateSubscription")
+	defer span.End()
+
+	if err := validateSubscription(subscription); err != nil {
+		return err
+	}
+
+	return s.store.Webhook().UpdateSubscription(ctx, subscription)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, req models.RequestId) error {
+	ctx, span := tracer.Start(ctx, "WebhookService.DeleteSubscription")
+	defer span.End()
+
+	return s.store.Webhook().DeleteSubscription(ctx, req)
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, req models.RequestId) (*models.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetSubscription")
+	defer span.End()
+
+	subscription, err := s.store.Webhook().GetSubscription(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetSubscriptions")
+	defer span.End()
+
+	subscriptions, err := s.store.Webhook().GetSubscriptions(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *WebhookService) GetDelivery(ctx context.Context, req models.RequestId) (*models.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetDelivery")
+	defer span.End()
+
+	delivery, err := s.store.Webhook().GetDelivery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (s *WebhookService) GetAllDeliveries(ctx context.Context, req models.GetAllDeliveriesReq) (*models.GetAllDeliveriesResp, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetAllDeliveries")
+	defer span.End()
+
+	resp, err := s.store.Webhook().GetAllDeliveries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Replay sends the event of a delivery to its subscription once more as a new
+// delivery, so the log of the original one is kept.
+func (s *WebhookService) Replay(ctx context.Context, req models.RequestId) (string, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.Replay")
+	defer span.End()
+
+	delivery, err := s.store.Webhook().GetDelivery(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	replay := []models.WebhookDelivery{{
+		EventId:        delivery.EventId,
+		SubscriptionId: delivery.SubscriptionId,
+		Status:         models.DeliveryStatusPending,
+		NextAttemptAt:  Utime.Now(),
+	}}
+	if err = s.store.Webhook().CreateDeliveries(ctx, replay); err != nil {
+		return "", err
+	}
+
+	return replay[0].Id.String(), nil
+}
+
+func validateSubscription(subscription *models.WebhookSubscription) error {
+	if subscription.Name == "" {
+		return apperr.Validation(apperr.CodeValidation, "name can't be empty")
+	}
+
+	parsed, err := url.Parse(subscription.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return apperr.Validation(apperr.CodeValidation, "invalid url: %s", subscription.URL)
+	}
+
+	for _, eventType := range strings.Split(subscription.EventTypes, ",") {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" {
+			continue
+		}
+		known := false
+		for _, t := range eventTypes {
+			known = known || t == eventType
+		}
+		if !known {
+			return apperr.Validation(apperr.CodeValidation, "unknown event type: %s", eventType)
+		}
+	}
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// emitLoadEvent writes an event about the load of the logistic to the outbox
+// within tx. The dispatcher delivers it once tx is committed.
+func emitLoadEvent(ctx context.Context, store database.IStore, eventType string, logistic *models.Logistic, cargo *models.Cargo, status, reason string, tx *gorm.DB) error {
+	return store.Webhook().CreateEvent(ctx, &models.WebhookEvent{
+		Type:      eventType,
+		CompanyId: logistic.Driver.CompanyId,
+		Payload: models.EventPayload{
+			LogisticId:   logistic.Id,
+			DriverId:     logistic.DriverId,
+			DriverName:   logistic.Driver.FullName(),
+			TruckNumber:  logistic.Driver.TruckNumber,
+			Status:       status,
+			CargoId:      &cargo.Id,
+			LoadId:       cargo.CargoID,
+			Provider:     cargo.Provider,
+			From:         cargo.From,
+			To:           cargo.To,
+			PickUpTime:   cargo.PickUpTime,
+			DeliveryTime: cargo.DeliveryTime,
+			Reason:       reason,
+		},
+		OccurredAt: Utime.Now(),
+	}, tx)
+}
+
+// pickedUp reports whether a logistic carrying a load just left the pickup.
+func pickedUp(old *models.Logistic, status string) bool {
+	return old.CargoId != nil && old.Status == "AT PU" && status != "" && status != "AT PU"
+}
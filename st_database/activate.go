@@ -13,7 +13,18 @@ func New(db *gorm.DB) *Store {
 		employee:    storage.NewEmployeeRepo(db),
 		logistic:    storage.NewLogisticRepo(db),
 		cargo:       storage.NewCargoRepo(db),
+		provider:    storage.NewProviderRepo(db),
 		transaction: storage.NewTransactionRepo(db),
+		invoice:     storage.NewInvoiceRepo(db),
+		settlement:  storage.NewSettlementRepo(db),
+		compliance:  storage.NewComplianceRepo(db),
+		hos:         storage.NewHOSRepo(db),
+		equipment:   storage.NewEquipmentRepo(db),
+		maintenance: storage.NewMaintenanceRepo(db),
+		alert:       storage.NewAlertRepo(db),
+		webhook:     storage.NewWebhookRepo(db),
+		flagRule:    storage.NewFlagRuleRepo(db),
+		job:         storage.NewJobRepo(db),
 		performance: storage.NewPerformanceRepo(db),
 		history:     storage.NewHistoryRepo(db),
 	}
@@ -0,0 +1,297 @@
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migrations are pairs of sql/<version>_<name>.up.sql and .down.sql files.
+// Versions only ever grow and applied files are never edited, a change to the
+// schema is a new pair.
+//
+//go:embed sql/*.sql
+var files embed.FS
+
+// ErrSchemaTooNew is returned when the database was migrated by a newer
+// binary than this one.
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a known or applied migration. Unknown ones were applied by a
+// binary newer than this one.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	Unknown   bool
+}
+
+type applied struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Load returns the embedded migrations in version order.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		parts := fileName.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			return nil, fmt.Errorf("migration file %s is not named <version>_<name>.<up|down>.sql", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(files, "sql/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = migration
+		} else if migration.Name != parts[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, migration.Name, parts[2])
+		}
+
+		if parts[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns them. It refuses to touch a
+// database that has a version this binary does not know about. All of it runs
+// in one transaction, so a failing migration leaves the schema as it was.
+func Up(db *gorm.DB) ([]Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	err = db.Transaction(func(tx *gorm.DB) error {
+		done = nil
+
+		versions, err := begin(tx)
+		if err != nil {
+			return err
+		}
+
+		latest := migrations[len(migrations)-1].Version
+		for _, version := range versions {
+			if version.Version > latest {
+				return fmt.Errorf("%w: it is at version %d, this binary knows up to %d",
+					ErrSchemaTooNew, version.Version, latest)
+			}
+		}
+
+		for _, migration := range migrations {
+			if contains(versions, migration.Version) {
+				continue
+			}
+
+			if err = run(tx, migration.Up); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			err = tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name).Error
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return done, nil
+}
+
+// Down rolls back the last steps applied migrations and returns them, latest
+// first.
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var done []Migration
+	err = db.Transaction(func(tx *gorm.DB) error {
+		done = nil
+
+		versions, err := begin(tx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(versions) - 1; i >= 0 && len(done) < steps; i-- {
+			migration, ok := byVersion[versions[i].Version]
+			if !ok {
+				return fmt.Errorf("%w: version %d is not known to it", ErrSchemaTooNew, versions[i].Version)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s can't be rolled back", migration.Version, migration.Name)
+			}
+
+			if err = run(tx, migration.Down); err != nil {
+				return fmt.Errorf("rolling back migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			if err = tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error; err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return done, nil
+}
+
+// GetStatus returns every known migration and every applied one in version
+// order.
+func GetStatus(db *gorm.DB) ([]Status, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []applied
+	err = db.Transaction(func(tx *gorm.DB) error {
+		versions, err = begin(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		statuses = append(statuses, Status{Version: migration.Version, Name: migration.Name})
+	}
+
+	for i := range versions {
+		version := versions[i]
+		found := false
+		for j := range statuses {
+			if statuses[j].Version == version.Version {
+				statuses[j].AppliedAt = &version.AppliedAt
+				found = true
+				break
+			}
+		}
+		if !found {
+			statuses = append(statuses, Status{Version: version.Version, Name: version.Name, AppliedAt: &version.AppliedAt, Unknown: true})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// begin serializes migration runs of every instance for the rest of tx and
+// returns the applied versions in order.
+func begin(tx *gorm.DB) ([]applied, error) {
+	err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('schema_migrations'))").Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint PRIMARY KEY,
+			name varchar(255) NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT NOW()
+		)
+	`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []applied
+	err = tx.Raw("SELECT version, name, applied_at FROM schema_migrations ORDER BY version").Scan(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// run executes a migration file, which may hold several statements. Files
+// with nothing but comments are skipped.
+func run(tx *gorm.DB, sql string) error {
+	empty := true
+	for _, line := range strings.Split(sql, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return nil
+	}
+
+	return tx.Exec(sql).Error
+}
+
+func contains(versions []applied, version int64) bool {
+	for _, v := range versions {
+		if v.Version == version {
+			return true
+		}
+	}
+
+	return false
+}
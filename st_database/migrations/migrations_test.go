@@ -0,0 +1,184 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, migration := range migrations {
+		if i > 0 && migration.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d_%s is out of order", migration.Version, migration.Name)
+		}
+		if migration.Down == "" {
+			t.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+		}
+	}
+	if migrations[0].Version != 1 || migrations[0].Name != "baseline" {
+		t.Errorf("first migration is %d_%s, want 1_baseline", migrations[0].Version, migrations[0].Name)
+	}
+}
+
+func TestRunSkipsCommentOnlyFiles(t *testing.T) {
+	for _, sql := range []string{"", "-- Nothing to undo.\n", "\n  -- one\n\n-- two\n"} {
+		// A nil transaction would panic if the file was executed.
+		if err := run(nil, sql); err != nil {
+			t.Errorf("run(%q) = %v", sql, err)
+		}
+	}
+}
+
+// testDB opens the database of TEST_DATABASE_DSN in a schema of its own, which
+// is dropped after the test. Tests needing postgres are skipped without it.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The search path is set per connection, so there must only be one.
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	if err = db.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DROP SCHEMA " + schema + " CASCADE")
+		sqlDB.Close()
+	})
+	if err = db.Exec("SET search_path TO " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestUpFromAutoMigrateSchema(t *testing.T) {
+	db := testDB(t)
+
+	schema, err := os.ReadFile("testdata/automigrate.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Exec(string(schema)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// A load on the board and a delivered one, both with a free-text provider.
+	err = db.Exec(`
+		INSERT INTO companies (id, name, address, number, scac, dot, mc, drivers_number)
+		VALUES ('10000000-0000-0000-0000-000000000001', 'SSLS', 'Dallas, TX', '1', 'SSLS', 1, 1, 2);
+		INSERT INTO employees (id, name, surname, username, position, password, email, phone_number, birthday)
+		VALUES ('20000000-0000-0000-0000-000000000001', 'Ann', 'Lee', 'ann', 'DISPATCHER', 'x', 'ann@ssls', '', '1990-01-01');
+		INSERT INTO drivers (id, name, surname, type, position, truck_number, phone_number, mail, birthday, company_id)
+		VALUES ('30000000-0000-0000-0000-000000000001', 'John', 'Smith', 'SOLO', 'DRIVER', '101', '', '', '1980-01-01', '10000000-0000-0000-0000-000000000001');
+		INSERT INTO cargos (id, cargo_id, provider, loaded_miles, free_miles, "from", "to", cost, rate, pick_up_time, delivery_time, employee_id)
+		VALUES ('40000000-0000-0000-0000-000000000001', 'L-1', ' tql ', 500, 20, 'Dallas, TX', 'Austin, TX', 1000, 2, '2024-05-01 08:00', '2024-05-02 08:00', '20000000-0000-0000-0000-000000000001'),
+		       ('40000000-0000-0000-0000-000000000002', 'L-2', 'TQL', 300, 0, 'Austin, TX', 'Houston, TX', 900, 3, '2024-04-01 08:00', '2024-04-02 08:00', '20000000-0000-0000-0000-000000000001');
+		INSERT INTO logistics (id, driver_id, status, update_time, state, location, cargo_id)
+		VALUES ('50000000-0000-0000-0000-000000000001', '30000000-0000-0000-0000-000000000001', 'ETA', '2024-05-02 08:00', 'TX', 'Dallas, TX', '40000000-0000-0000-0000-000000000001');
+		INSERT INTO transactions (id, "from", "to", pu_time, delivery_time, loaded_miles, total_miles, provider, cost, rate, driver_id, employee_id, cargo_id, success, created_at)
+		VALUES ('60000000-0000-0000-0000-000000000001', 'Austin, TX', 'Houston, TX', '2024-04-01 08:00', '2024-04-02 08:00', 300, 300, 'TQL', 900, 3,
+		        '30000000-0000-0000-0000-000000000001', '20000000-0000-0000-0000-000000000001', 'L-2', TRUE, '2024-04-02 09:00');
+	`).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrations, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, err := Up(db)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(done) != len(migrations) {
+		t.Errorf("applied %d migrations, want %d", len(done), len(migrations))
+	}
+
+	var providers int64
+	db.Raw("SELECT COUNT(*) FROM providers").Scan(&providers)
+	if providers != 1 {
+		t.Errorf("got %d providers, want tql and TQL merged into one", providers)
+	}
+
+	var unlinked int64
+	db.Raw("SELECT (SELECT COUNT(*) FROM cargos WHERE provider_id IS NULL) + (SELECT COUNT(*) FROM transactions WHERE provider_id IS NULL)").Scan(&unlinked)
+	if unlinked != 0 {
+		t.Errorf("%d cargos and transactions were not linked to their provider", unlinked)
+	}
+
+	var stops int64
+	db.Raw("SELECT COUNT(*) FROM cargo_stops").Scan(&stops)
+	if stops != 4 {
+		t.Errorf("got %d stops, want a pickup and a delivery per cargo", stops)
+	}
+
+	var cargos []struct {
+		CargoId       string
+		DriverId      *string
+		Status        string
+		TransactionId *string
+		Version       int64
+	}
+	db.Raw("SELECT cargo_id, driver_id, status, transaction_id, version FROM cargos ORDER BY cargo_id").Scan(&cargos)
+	if len(cargos) != 2 || cargos[0].DriverId == nil || cargos[0].Status != "ACTIVE" || cargos[0].Version != 1 {
+		t.Errorf("active cargo was not backfilled: %+v", cargos)
+	} else if cargos[1].TransactionId == nil || cargos[1].Status != "DELIVERED" || cargos[1].DriverId == nil {
+		t.Errorf("delivered cargo was not backfilled: %+v", cargos[1])
+	}
+
+	for _, column := range []struct{ table, name string }{
+		{"drivers", "truck_id"}, {"logistics", "flags"}, {"logistics", "version"},
+		{"transactions", "invoice_id"}, {"transactions", "stops"}, {"performances", "draft"},
+	} {
+		if !db.Migrator().HasColumn(column.table, column.name) {
+			t.Errorf("%s.%s is missing", column.table, column.name)
+		}
+	}
+
+	if done, err = Up(db); err != nil || len(done) != 0 {
+		t.Errorf("second Up applied %d migrations, error %v", len(done), err)
+	}
+}
+
+func TestUpRefusesNewerSchema(t *testing.T) {
+	db := testDB(t)
+
+	if _, err := Up(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("INSERT INTO schema_migrations (version, name) VALUES (99999, 'from_the_future')").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Up(db); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("got %v, want ErrSchemaTooNew", err)
+	}
+	if _, err := Down(db, 1); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("Down got %v, want ErrSchemaTooNew", err)
+	}
+}
@@ -0,0 +1,35 @@
+DROP TABLE IF EXISTS
+    "job_runs",
+    "flag_rules",
+    "webhook_deliveries",
+    "webhook_events",
+    "webhook_subscriptions",
+    "alerts",
+    "alert_rules",
+    "downtimes",
+    "maintenance_schedules",
+    "maintenance_records",
+    "equipment_assignments",
+    "trailers",
+    "trucks",
+    "driver_hos",
+    "driver_compliances",
+    "settlement_lines",
+    "settlements",
+    "settlement_adjustments",
+    "pay_profiles",
+    "invoice_counters",
+    "invoice_payments",
+    "invoice_lines",
+    "invoices",
+    "histories",
+    "performances",
+    "transactions",
+    "cargo_stops",
+    "logistics",
+    "cargos",
+    "drivers",
+    "providers",
+    "employees",
+    "companies"
+CASCADE;
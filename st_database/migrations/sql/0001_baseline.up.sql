@@ -0,0 +1,719 @@
+-- Schema as it was created by gorm AutoMigrate before migrations were
+-- versioned. Every statement is guarded so that databases created by
+-- AutoMigrate take this version without changes. Tables AutoMigrate created
+-- before some of their columns existed get those columns at the end.
+
+CREATE TABLE IF NOT EXISTS "companies" (
+    "id" uuid,
+    "name" varchar(20) NOT NULL,
+    "address" varchar(50) NOT NULL,
+    "number" varchar(20) NOT NULL,
+    "scac" varchar(20) NOT NULL,
+    "start_date" date,
+    "dot" bigint NOT NULL,
+    "mc" bigint NOT NULL,
+    "drivers_number" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_companies_deleted_at" ON "companies" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "employees" (
+    "id" uuid,
+    "name" varchar(30) NOT NULL,
+    "surname" varchar(30) NOT NULL,
+    "username" varchar(50) NOT NULL,
+    "position" varchar(30) NOT NULL,
+    "access_level" bigint NOT NULL DEFAULT 3,
+    "password" varchar(200) NOT NULL,
+    "logo_id" varchar(255) DEFAULT NULL,
+    "email" varchar(50) NOT NULL,
+    "phone_number" varchar(50) NOT NULL,
+    "birthday" date NOT NULL,
+    "company" varchar(50) DEFAULT NULL,
+    "start_date" date,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_employees_username" UNIQUE ("username"),
+    CONSTRAINT "uni_employees_email" UNIQUE ("email")
+);
+CREATE INDEX IF NOT EXISTS "idx_employees_deleted_at" ON "employees" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "providers" (
+    "id" uuid,
+    "name" varchar(90) NOT NULL,
+    "normalized_name" varchar(90) NOT NULL,
+    "mc" varchar(20) NOT NULL DEFAULT '',
+    "dot" varchar(20) NOT NULL DEFAULT '',
+    "contact_name" varchar(90) NOT NULL DEFAULT '',
+    "contact_phone" varchar(20) NOT NULL DEFAULT '',
+    "contact_email" varchar(90) NOT NULL DEFAULT '',
+    "payment_terms" bigint NOT NULL DEFAULT 30,
+    "credit_notes" text NOT NULL DEFAULT '',
+    "blacklisted" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_providers_deleted_at" ON "providers" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_providers_normalized_name" ON "providers" ("normalized_name") WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "drivers" (
+    "id" uuid,
+    "name" varchar(50) NOT NULL,
+    "surname" varchar(50) NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "position" varchar(50) NOT NULL,
+    "truck_number" varchar NOT NULL,
+    "truck_id" uuid,
+    "trailer_id" uuid,
+    "phone_number" varchar(20) NOT NULL,
+    "mail" varchar(50) NOT NULL,
+    "birthday" date NOT NULL,
+    "start_date" date,
+    "company_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_companies_drivers" FOREIGN KEY ("company_id") REFERENCES "companies"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_drivers_deleted_at" ON "drivers" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "cargos" (
+    "id" uuid,
+    "cargo_id" varchar(90) NOT NULL,
+    "provider" varchar(90) NOT NULL,
+    "provider_id" uuid,
+    "loaded_miles" bigint NOT NULL,
+    "free_miles" bigint NOT NULL,
+    "from" varchar(90) NOT NULL,
+    "to" varchar(90) NOT NULL,
+    "cost" bigint NOT NULL,
+    "rate" decimal(10,2) NOT NULL,
+    "pick_up_time" timestamp NOT NULL,
+    "delivery_time" timestamp NOT NULL,
+    "employee_id" uuid,
+    "driver_id" uuid,
+    "status" varchar(20) NOT NULL DEFAULT 'ACTIVE',
+    "transaction_id" uuid,
+    "terminated_at" timestamp,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_cargos_deleted_at" ON "cargos" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_cargos_driver_id" ON "cargos" ("driver_id");
+CREATE INDEX IF NOT EXISTS "idx_cargos_provider_id" ON "cargos" ("provider_id");
+
+CREATE TABLE IF NOT EXISTS "logistics" (
+    "id" uuid,
+    "post" boolean DEFAULT false,
+    "driver_id" uuid NOT NULL,
+    "status" varchar(30) NOT NULL DEFAULT 'READY',
+    "update_time" timestamp NOT NULL,
+    "st_time" timestamp,
+    "state" varchar(90) NOT NULL,
+    "location" varchar(90) NOT NULL,
+    "emoji" varchar(30) NOT NULL DEFAULT '',
+    "flags" jsonb NOT NULL DEFAULT '[]',
+    "notion" varchar(255) NOT NULL DEFAULT '',
+    "cargo_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_logistics_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id"),
+    CONSTRAINT "fk_logistics_cargo" FOREIGN KEY ("cargo_id") REFERENCES "cargos"("id"),
+    CONSTRAINT "uni_logistics_driver_id" UNIQUE ("driver_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_logistics_deleted_at" ON "logistics" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "cargo_stops" (
+    "id" uuid,
+    "cargo_id" uuid NOT NULL,
+    "sequence" bigint NOT NULL,
+    "type" varchar(20) NOT NULL,
+    "location" varchar(90) NOT NULL,
+    "appointment_time" timestamp NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'PENDING',
+    "arrived_at" timestamp,
+    "completed_at" timestamp,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_cargos_stops" FOREIGN KEY ("cargo_id") REFERENCES "cargos"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_cargo_stops_cargo_id" ON "cargo_stops" ("cargo_id");
+CREATE INDEX IF NOT EXISTS "idx_cargo_stops_deleted_at" ON "cargo_stops" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "transactions" (
+    "id" uuid,
+    "from" varchar(50) NOT NULL,
+    "to" varchar(50) NOT NULL,
+    "pu_time" timestamp NOT NULL,
+    "delivery_time" timestamp NOT NULL,
+    "loaded_miles" bigint NOT NULL,
+    "total_miles" bigint NOT NULL,
+    "provider" varchar(50) NOT NULL,
+    "provider_id" uuid,
+    "cost" bigint NOT NULL,
+    "rate" decimal(10,2) NOT NULL,
+    "driver_id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL,
+    "cargo_id" varchar(90) NOT NULL,
+    "stops" jsonb,
+    "success" boolean NOT NULL,
+    "invoice_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_transactions_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id"),
+    CONSTRAINT "fk_transactions_employee" FOREIGN KEY ("employee_id") REFERENCES "employees"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_transactions_deleted_at" ON "transactions" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_transactions_invoice_id" ON "transactions" ("invoice_id");
+CREATE INDEX IF NOT EXISTS "idx_transactions_provider_id" ON "transactions" ("provider_id");
+
+CREATE TABLE IF NOT EXISTS "performances" (
+    "id" uuid,
+    "reason" varchar(255),
+    "whose_fault" varchar(255),
+    "status" varchar(30),
+    "section" varchar(255),
+    "employee_id" uuid NOT NULL,
+    "company_id" uuid NOT NULL,
+    "load_id" varchar(255) NOT NULL,
+    "draft" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_performances_employee" FOREIGN KEY ("employee_id") REFERENCES "employees"("id"),
+    CONSTRAINT "fk_performances_company" FOREIGN KEY ("company_id") REFERENCES "companies"("id") ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_performances_deleted_at" ON "performances" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "histories" (
+    "id" uuid,
+    "driver_name" varchar(255) NOT NULL,
+    "logistic_id" uuid NOT NULL,
+    "from_logistic" jsonb,
+    "to_logistic" jsonb,
+    "from_cargo" jsonb,
+    "to_cargo" jsonb,
+    "employee_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_histories_employee" FOREIGN KEY ("employee_id") REFERENCES "employees"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_histories_deleted_at" ON "histories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "invoices" (
+    "id" uuid,
+    "number" varchar(40) NOT NULL,
+    "sequence" bigint NOT NULL,
+    "company_id" uuid NOT NULL,
+    "provider_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'DRAFT',
+    "issue_date" date,
+    "due_date" date,
+    "total" decimal(12,2) NOT NULL,
+    "amount_paid" decimal(12,2) NOT NULL DEFAULT 0,
+    "short_paid" decimal(12,2) NOT NULL DEFAULT 0,
+    "short_pay_reason" varchar(255) NOT NULL DEFAULT '',
+    "short_paid_at" date,
+    "factored" boolean NOT NULL DEFAULT false,
+    "factoring_company" varchar(90) NOT NULL DEFAULT '',
+    "factored_at" date,
+    "advance_amount" decimal(12,2) NOT NULL DEFAULT 0,
+    "reserve_amount" decimal(12,2) NOT NULL DEFAULT 0,
+    "factoring_fee" decimal(12,2) NOT NULL DEFAULT 0,
+    "reserve_released_at" date,
+    "notes" text NOT NULL DEFAULT '',
+    "employee_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoices_company" FOREIGN KEY ("company_id") REFERENCES "companies"("id"),
+    CONSTRAINT "fk_invoices_provider" FOREIGN KEY ("provider_id") REFERENCES "providers"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_invoices_company_id" ON "invoices" ("company_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_deleted_at" ON "invoices" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_invoices_provider_id" ON "invoices" ("provider_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invoices_number" ON "invoices" ("number");
+
+CREATE TABLE IF NOT EXISTS "invoice_lines" (
+    "id" uuid,
+    "invoice_id" uuid NOT NULL,
+    "transaction_id" uuid,
+    "type" varchar(20) NOT NULL,
+    "description" varchar(255) NOT NULL,
+    "amount" decimal(12,2) NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoices_lines" FOREIGN KEY ("invoice_id") REFERENCES "invoices"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_invoice_lines_deleted_at" ON "invoice_lines" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_invoice_lines_invoice_id" ON "invoice_lines" ("invoice_id");
+
+CREATE TABLE IF NOT EXISTS "invoice_payments" (
+    "id" uuid,
+    "invoice_id" uuid NOT NULL,
+    "amount" decimal(12,2) NOT NULL,
+    "paid_at" date NOT NULL,
+    "method" varchar(30) NOT NULL DEFAULT '',
+    "reference" varchar(90) NOT NULL DEFAULT '',
+    "employee_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoices_payments" FOREIGN KEY ("invoice_id") REFERENCES "invoices"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_invoice_payments_deleted_at" ON "invoice_payments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_invoice_payments_invoice_id" ON "invoice_payments" ("invoice_id");
+
+CREATE TABLE IF NOT EXISTS "invoice_counters" (
+    "company_id" uuid,
+    "last_sequence" bigint NOT NULL,
+    PRIMARY KEY ("company_id")
+);
+
+CREATE TABLE IF NOT EXISTS "pay_profiles" (
+    "id" uuid,
+    "driver_id" uuid NOT NULL,
+    "method" varchar(20) NOT NULL,
+    "loaded_rate" decimal(10,4) NOT NULL DEFAULT 0,
+    "empty_rate" decimal(10,4) NOT NULL DEFAULT 0,
+    "percent" decimal(5,2) NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_pay_profiles_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_pay_profiles_deleted_at" ON "pay_profiles" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_pay_profiles_driver_id" ON "pay_profiles" ("driver_id");
+
+CREATE TABLE IF NOT EXISTS "settlement_adjustments" (
+    "id" uuid,
+    "driver_id" uuid NOT NULL,
+    "type" varchar(20) NOT NULL,
+    "description" varchar(255) NOT NULL,
+    "amount" decimal(10,2) NOT NULL,
+    "date" date NOT NULL,
+    "recurring" boolean NOT NULL DEFAULT false,
+    "end_date" date,
+    "settlement_id" uuid,
+    "employee_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_settlement_adjustments_deleted_at" ON "settlement_adjustments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_settlement_adjustments_driver_id" ON "settlement_adjustments" ("driver_id");
+CREATE INDEX IF NOT EXISTS "idx_settlement_adjustments_settlement_id" ON "settlement_adjustments" ("settlement_id");
+
+CREATE TABLE IF NOT EXISTS "settlements" (
+    "id" uuid,
+    "driver_id" uuid NOT NULL,
+    "week_start" date NOT NULL,
+    "week_end" date NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'DRAFT',
+    "method" varchar(20) NOT NULL,
+    "loads" bigint NOT NULL,
+    "loaded_miles" bigint NOT NULL,
+    "empty_miles" bigint NOT NULL,
+    "gross" decimal(12,2) NOT NULL,
+    "pay" decimal(12,2) NOT NULL,
+    "deductions" decimal(12,2) NOT NULL,
+    "advances" decimal(12,2) NOT NULL,
+    "reimbursements" decimal(12,2) NOT NULL,
+    "net_pay" decimal(12,2) NOT NULL,
+    "locked_at" timestamptz,
+    "locked_by" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_settlements_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_settlements_deleted_at" ON "settlements" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_settlements_driver_week" ON "settlements" ("driver_id","week_start");
+
+CREATE TABLE IF NOT EXISTS "settlement_lines" (
+    "id" uuid,
+    "settlement_id" uuid NOT NULL,
+    "transaction_id" uuid,
+    "adjustment_id" uuid,
+    "type" varchar(20) NOT NULL,
+    "description" varchar(255) NOT NULL,
+    "miles" bigint NOT NULL DEFAULT 0,
+    "amount" decimal(12,2) NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_settlements_lines" FOREIGN KEY ("settlement_id") REFERENCES "settlements"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_settlement_lines_settlement_id" ON "settlement_lines" ("settlement_id");
+
+CREATE TABLE IF NOT EXISTS "driver_compliances" (
+    "id" uuid,
+    "driver_id" uuid NOT NULL,
+    "cdl_number" varchar(30) NOT NULL DEFAULT '',
+    "cdl_state" varchar(2) NOT NULL DEFAULT '',
+    "cdl_class" varchar(1) NOT NULL DEFAULT '',
+    "cdl_expiry" date,
+    "endorsements" varchar(20) NOT NULL DEFAULT '',
+    "medical_card_expiry" date,
+    "last_drug_test" date,
+    "next_drug_test" date,
+    "mvr_reviewed_at" date,
+    "mvr_next_review" date,
+    "status" varchar(20) NOT NULL DEFAULT 'OK',
+    "issues" varchar(255) NOT NULL DEFAULT '',
+    "checked_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_driver_compliances_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_driver_compliances_deleted_at" ON "driver_compliances" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_driver_compliances_driver_id" ON "driver_compliances" ("driver_id");
+
+CREATE TABLE IF NOT EXISTS "driver_hos" (
+    "id" uuid,
+    "driver_id" uuid NOT NULL,
+    "drive_left" decimal(5,2) NOT NULL DEFAULT 0,
+    "on_duty_left" decimal(5,2) NOT NULL DEFAULT 0,
+    "cycle_left" decimal(5,2) NOT NULL DEFAULT 0,
+    "source" varchar(10) NOT NULL DEFAULT 'MANUAL',
+    "eld_driver_id" varchar(50) NOT NULL DEFAULT '',
+    "reported_at" timestamp NOT NULL,
+    "employee_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_driver_hos_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_driver_hos_deleted_at" ON "driver_hos" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_driver_hos_driver_id" ON "driver_hos" ("driver_id");
+
+CREATE TABLE IF NOT EXISTS "trucks" (
+    "id" uuid,
+    "company_id" uuid NOT NULL,
+    "number" varchar(20) NOT NULL,
+    "vin" varchar(17) NOT NULL,
+    "make" varchar(30) NOT NULL DEFAULT '',
+    "model" varchar(30) NOT NULL DEFAULT '',
+    "year" bigint NOT NULL DEFAULT 0,
+    "odometer" bigint NOT NULL DEFAULT 0,
+    "plate" varchar(20) NOT NULL DEFAULT '',
+    "plate_state" varchar(2) NOT NULL DEFAULT '',
+    "registration_expiry" date,
+    "ownership" varchar(20) NOT NULL DEFAULT 'COMPANY',
+    "status" varchar(20) NOT NULL DEFAULT 'ACTIVE',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_trucks_company" FOREIGN KEY ("company_id") REFERENCES "companies"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_trucks_company_id" ON "trucks" ("company_id");
+CREATE INDEX IF NOT EXISTS "idx_trucks_deleted_at" ON "trucks" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_trucks_vin" ON "trucks" ("vin") WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "trailers" (
+    "id" uuid,
+    "company_id" uuid NOT NULL,
+    "number" varchar(20) NOT NULL,
+    "vin" varchar(17) NOT NULL,
+    "type" varchar(20) NOT NULL,
+    "length" bigint NOT NULL DEFAULT 53,
+    "make" varchar(30) NOT NULL DEFAULT '',
+    "year" bigint NOT NULL DEFAULT 0,
+    "plate" varchar(20) NOT NULL DEFAULT '',
+    "plate_state" varchar(2) NOT NULL DEFAULT '',
+    "registration_expiry" date,
+    "ownership" varchar(20) NOT NULL DEFAULT 'COMPANY',
+    "status" varchar(20) NOT NULL DEFAULT 'ACTIVE',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_trailers_company" FOREIGN KEY ("company_id") REFERENCES "companies"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_trailers_company_id" ON "trailers" ("company_id");
+CREATE INDEX IF NOT EXISTS "idx_trailers_deleted_at" ON "trailers" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_trailers_vin" ON "trailers" ("vin") WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "equipment_assignments" (
+    "id" uuid,
+    "driver_id" uuid NOT NULL,
+    "truck_id" uuid,
+    "trailer_id" uuid,
+    "started_at" timestamp NOT NULL,
+    "ended_at" timestamp,
+    "notes" varchar(255) NOT NULL DEFAULT '',
+    "employee_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_equipment_assignments_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id"),
+    CONSTRAINT "fk_equipment_assignments_truck" FOREIGN KEY ("truck_id") REFERENCES "trucks"("id"),
+    CONSTRAINT "fk_equipment_assignments_trailer" FOREIGN KEY ("trailer_id") REFERENCES "trailers"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_equipment_assignments_driver_id" ON "equipment_assignments" ("driver_id");
+CREATE INDEX IF NOT EXISTS "idx_equipment_assignments_trailer_id" ON "equipment_assignments" ("trailer_id");
+CREATE INDEX IF NOT EXISTS "idx_equipment_assignments_truck_id" ON "equipment_assignments" ("truck_id");
+
+CREATE TABLE IF NOT EXISTS "maintenance_records" (
+    "id" uuid,
+    "truck_id" uuid NOT NULL,
+    "service_type" varchar(20) NOT NULL,
+    "description" varchar(255) NOT NULL DEFAULT '',
+    "odometer" bigint NOT NULL DEFAULT 0,
+    "cost" decimal(10,2) NOT NULL DEFAULT 0,
+    "shop" varchar(90) NOT NULL DEFAULT '',
+    "started_at" date NOT NULL,
+    "completed_at" date,
+    "employee_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_maintenance_records_truck" FOREIGN KEY ("truck_id") REFERENCES "trucks"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_maintenance_records_deleted_at" ON "maintenance_records" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_records_truck_id" ON "maintenance_records" ("truck_id");
+
+CREATE TABLE IF NOT EXISTS "maintenance_schedules" (
+    "id" uuid,
+    "truck_id" uuid NOT NULL,
+    "service_type" varchar(20) NOT NULL,
+    "interval_miles" bigint NOT NULL DEFAULT 0,
+    "interval_days" bigint NOT NULL DEFAULT 0,
+    "last_odometer" bigint NOT NULL DEFAULT 0,
+    "last_date" date,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_maintenance_schedules_truck" FOREIGN KEY ("truck_id") REFERENCES "trucks"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_maintenance_schedules_deleted_at" ON "maintenance_schedules" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_schedules_truck_id" ON "maintenance_schedules" ("truck_id");
+
+CREATE TABLE IF NOT EXISTS "downtimes" (
+    "id" uuid,
+    "truck_id" uuid,
+    "truck_number" varchar(20) NOT NULL,
+    "driver_id" uuid NOT NULL,
+    "company_id" uuid NOT NULL,
+    "logistic_id" uuid NOT NULL,
+    "reason" varchar(255) NOT NULL DEFAULT '',
+    "started_at" timestamp NOT NULL,
+    "ended_at" timestamp,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_downtimes_company_id" ON "downtimes" ("company_id");
+CREATE INDEX IF NOT EXISTS "idx_downtimes_driver_id" ON "downtimes" ("driver_id");
+CREATE INDEX IF NOT EXISTS "idx_downtimes_truck_id" ON "downtimes" ("truck_id");
+
+CREATE TABLE IF NOT EXISTS "alert_rules" (
+    "id" uuid,
+    "name" varchar(90) NOT NULL,
+    "kind" varchar(30) NOT NULL,
+    "statuses" varchar(255) NOT NULL DEFAULT '',
+    "threshold" bigint NOT NULL DEFAULT 0,
+    "severity" varchar(10) NOT NULL DEFAULT 'WARNING',
+    "channels" varchar(50) NOT NULL DEFAULT 'INBOX',
+    "recipients" varchar(255) NOT NULL DEFAULT '',
+    "webhook_url" varchar(255) NOT NULL DEFAULT '',
+    "cooldown" bigint NOT NULL DEFAULT 0,
+    "enabled" boolean NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_alert_rules_deleted_at" ON "alert_rules" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "alerts" (
+    "id" uuid,
+    "rule_id" uuid NOT NULL,
+    "dedup_key" varchar(100) NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "message" text NOT NULL,
+    "severity" varchar(10) NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'OPEN',
+    "logistic_id" uuid,
+    "driver_id" uuid,
+    "employee_id" uuid,
+    "count" bigint NOT NULL DEFAULT 1,
+    "first_seen_at" timestamp NOT NULL,
+    "last_seen_at" timestamp NOT NULL,
+    "notified_at" timestamp,
+    "delivery_error" varchar(255) NOT NULL DEFAULT '',
+    "inbox" boolean NOT NULL DEFAULT false,
+    "snoozed_until" timestamp,
+    "acknowledged_at" timestamp,
+    "acknowledged_by" uuid,
+    "resolved_at" timestamp,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_alerts_rule" FOREIGN KEY ("rule_id") REFERENCES "alert_rules"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_alerts_dedup_key" ON "alerts" ("dedup_key");
+CREATE INDEX IF NOT EXISTS "idx_alerts_employee_id" ON "alerts" ("employee_id");
+CREATE INDEX IF NOT EXISTS "idx_alerts_rule_id" ON "alerts" ("rule_id");
+CREATE INDEX IF NOT EXISTS "idx_alerts_status" ON "alerts" ("status");
+
+CREATE TABLE IF NOT EXISTS "webhook_subscriptions" (
+    "id" uuid,
+    "name" varchar(90) NOT NULL,
+    "url" varchar(255) NOT NULL,
+    "secret" varchar(100) NOT NULL,
+    "event_types" varchar(255) NOT NULL DEFAULT '',
+    "company_id" uuid,
+    "active" boolean NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_deleted_at" ON "webhook_subscriptions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_events" (
+    "id" uuid,
+    "type" varchar(30) NOT NULL,
+    "company_id" uuid NOT NULL,
+    "payload" jsonb NOT NULL,
+    "occurred_at" timestamp NOT NULL,
+    "dispatched_at" timestamp,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_dispatched_at" ON "webhook_events" ("dispatched_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_type" ON "webhook_events" ("type");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" uuid,
+    "event_id" uuid NOT NULL,
+    "subscription_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'PENDING',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamp NOT NULL,
+    "last_status_code" bigint NOT NULL DEFAULT 0,
+    "last_error" varchar(255) NOT NULL DEFAULT '',
+    "delivered_at" timestamp,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_webhook_deliveries_event" FOREIGN KEY ("event_id") REFERENCES "webhook_events"("id"),
+    CONSTRAINT "fk_webhook_deliveries_subscription" FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_event_id" ON "webhook_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_status" ON "webhook_deliveries" ("status");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_subscription_id" ON "webhook_deliveries" ("subscription_id");
+
+CREATE TABLE IF NOT EXISTS "flag_rules" (
+    "id" uuid,
+    "name" varchar(90) NOT NULL,
+    "condition" varchar(30) NOT NULL,
+    "statuses" jsonb NOT NULL DEFAULT '[]',
+    "threshold" bigint NOT NULL DEFAULT 0,
+    "icon" varchar(30) NOT NULL,
+    "priority" bigint NOT NULL DEFAULT 0,
+    "company_id" uuid,
+    "enabled" boolean NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_flag_rules_deleted_at" ON "flag_rules" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "job_runs" (
+    "id" uuid,
+    "job" varchar(60) NOT NULL,
+    "trigger" varchar(20) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "instance" varchar(90) NOT NULL DEFAULT '',
+    "employee_id" uuid,
+    "started_at" timestamp NOT NULL,
+    "finished_at" timestamp,
+    "duration_ms" bigint NOT NULL DEFAULT 0,
+    "error" text NOT NULL DEFAULT '',
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_job_runs_job" ON "job_runs" ("job");
+CREATE INDEX IF NOT EXISTS "idx_job_runs_started_at" ON "job_runs" ("started_at");
+CREATE INDEX IF NOT EXISTS "idx_job_runs_status" ON "job_runs" ("status");
+
+-- Columns added to tables after AutoMigrate first created them. None of them
+-- has a foreign key.
+ALTER TABLE "drivers"
+    ADD COLUMN IF NOT EXISTS "truck_id" uuid,
+    ADD COLUMN IF NOT EXISTS "trailer_id" uuid;
+
+ALTER TABLE "cargos"
+    ADD COLUMN IF NOT EXISTS "provider_id" uuid,
+    ADD COLUMN IF NOT EXISTS "driver_id" uuid,
+    ADD COLUMN IF NOT EXISTS "status" varchar(20) NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN IF NOT EXISTS "transaction_id" uuid,
+    ADD COLUMN IF NOT EXISTS "terminated_at" timestamp;
+CREATE INDEX IF NOT EXISTS "idx_cargos_provider_id" ON "cargos" ("provider_id");
+CREATE INDEX IF NOT EXISTS "idx_cargos_driver_id" ON "cargos" ("driver_id");
+
+ALTER TABLE "logistics"
+    ADD COLUMN IF NOT EXISTS "flags" jsonb NOT NULL DEFAULT '[]';
+
+ALTER TABLE "transactions"
+    ADD COLUMN IF NOT EXISTS "provider_id" uuid,
+    ADD COLUMN IF NOT EXISTS "stops" jsonb,
+    ADD COLUMN IF NOT EXISTS "invoice_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_transactions_provider_id" ON "transactions" ("provider_id");
+CREATE INDEX IF NOT EXISTS "idx_transactions_invoice_id" ON "transactions" ("invoice_id");
+
+ALTER TABLE "performances"
+    ADD COLUMN IF NOT EXISTS "draft" boolean NOT NULL DEFAULT false;
+
+ALTER TABLE "invoices"
+    ADD COLUMN IF NOT EXISTS "short_paid" decimal(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "short_pay_reason" varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "short_paid_at" date,
+    ADD COLUMN IF NOT EXISTS "factored" boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS "factoring_company" varchar(90) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "factored_at" date,
+    ADD COLUMN IF NOT EXISTS "advance_amount" decimal(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "reserve_amount" decimal(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "factoring_fee" decimal(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "reserve_released_at" date;
+
+ALTER TABLE "trucks"
+    ADD COLUMN IF NOT EXISTS "odometer" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "alerts"
+    ADD COLUMN IF NOT EXISTS "employee_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_alerts_employee_id" ON "alerts" ("employee_id");
@@ -0,0 +1 @@
+-- Backfilled data is kept, there is nothing to undo.
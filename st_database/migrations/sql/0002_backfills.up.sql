@@ -0,0 +1,67 @@
+-- Backfills that used to run after AutoMigrate on every boot.
+
+-- Every distinct free-text provider of cargos and transactions becomes a
+-- provider and the rows are linked to it. Names are normalized the same way
+-- as helpers.NormalizeProvider, so "tql" and "TQL" end up on one provider.
+INSERT INTO providers (id, name, normalized_name, created_at, updated_at)
+SELECT gen_random_uuid(), MIN(src.name), src.normalized_name, NOW(), NOW()
+FROM (
+    SELECT TRIM(provider) AS name,
+           UPPER(REGEXP_REPLACE(TRIM(provider), '\s+', ' ', 'g')) AS normalized_name
+    FROM cargos WHERE provider_id IS NULL AND TRIM(provider) <> ''
+    UNION ALL
+    SELECT TRIM(provider) AS name,
+           UPPER(REGEXP_REPLACE(TRIM(provider), '\s+', ' ', 'g')) AS normalized_name
+    FROM transactions WHERE provider_id IS NULL AND TRIM(provider) <> ''
+) src
+WHERE NOT EXISTS (
+    SELECT 1 FROM providers p
+    WHERE p.normalized_name = src.normalized_name AND p.deleted_at IS NULL
+)
+GROUP BY src.normalized_name;
+
+UPDATE cargos t
+SET provider_id = p.id
+FROM providers p
+WHERE t.provider_id IS NULL
+  AND p.deleted_at IS NULL
+  AND p.normalized_name = UPPER(REGEXP_REPLACE(TRIM(t.provider), '\s+', ' ', 'g'));
+
+UPDATE transactions t
+SET provider_id = p.id
+FROM providers p
+WHERE t.provider_id IS NULL
+  AND p.deleted_at IS NULL
+  AND p.normalized_name = UPPER(REGEXP_REPLACE(TRIM(t.provider), '\s+', ' ', 'g'));
+
+-- Single-lane cargos created before stops existed get a pickup stop at From
+-- and a delivery stop at To.
+INSERT INTO cargo_stops (id, cargo_id, sequence, type, location, appointment_time, status, created_at, updated_at)
+SELECT gen_random_uuid(), c.id, s.sequence, s.type, s.location, s.appointment_time, 'PENDING', NOW(), NOW()
+FROM cargos c
+CROSS JOIN LATERAL (VALUES
+    (1, 'PICKUP', c."from", c.pick_up_time),
+    (2, 'DELIVERY', c."to", c.delivery_time)
+) AS s(sequence, type, location, appointment_time)
+WHERE NOT EXISTS (SELECT 1 FROM cargo_stops cs WHERE cs.cargo_id = c.id);
+
+-- Cargos created before driver, status and transaction were tracked on the
+-- cargo itself. Active loads take the driver from their logistic, terminated
+-- ones from the transaction written by Terminate/CancelLate.
+UPDATE cargos c
+SET driver_id = l.driver_id
+FROM logistics l
+WHERE l.cargo_id = c.id AND c.driver_id IS NULL AND l.deleted_at IS NULL;
+
+UPDATE cargos c
+SET driver_id = COALESCE(c.driver_id, t.driver_id),
+    transaction_id = t.id,
+    status = CASE WHEN t.success THEN 'DELIVERED' ELSE 'CANCELLED' END,
+    terminated_at = t.created_at
+FROM transactions t
+WHERE c.transaction_id IS NULL
+  AND t.deleted_at IS NULL
+  AND t.cargo_id = c.cargo_id
+  AND t.employee_id = c.employee_id
+  AND t.pu_time = c.pick_up_time
+  AND NOT EXISTS (SELECT 1 FROM logistics l WHERE l.cargo_id = c.id AND l.deleted_at IS NULL);
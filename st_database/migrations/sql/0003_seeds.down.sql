@@ -0,0 +1,9 @@
+DELETE FROM alerts WHERE rule_id IN (SELECT id FROM alert_rules WHERE kind = 'LATE_LOAD' AND name = 'Late load');
+DELETE FROM alert_rules WHERE kind = 'LATE_LOAD' AND name = 'Late load';
+
+DELETE FROM flag_rules WHERE name IN ('Ready for over a day', 'ETA within a day', 'Late ETA');
+
+-- The system employee stays while history is attributed to it.
+DELETE FROM employees
+WHERE id = '00000000-0000-0000-0000-000000000001'
+  AND NOT EXISTS (SELECT 1 FROM histories WHERE employee_id = '00000000-0000-0000-0000-000000000001');
@@ -0,0 +1,23 @@
+-- Rows the backend expects to exist. Each insert is skipped when the row, or
+-- for rules any rule of its kind, was created before.
+
+-- The employee changes made by the backend itself are attributed to. Its
+-- password is not a bcrypt hash, so no password matches it.
+INSERT INTO employees (id, name, surname, username, position, access_level, password, email, phone_number, birthday, created_at, updated_at)
+VALUES ('00000000-0000-0000-0000-000000000001', 'System', '', '__system__', 'SYSTEM', 3, '!', 'system@localhost', '', '1970-01-01', NOW(), NOW())
+ON CONFLICT DO NOTHING;
+
+-- The icons the board had before flag rules became configurable.
+INSERT INTO flag_rules (id, name, condition, statuses, threshold, icon, priority, enabled, created_at, updated_at)
+SELECT gen_random_uuid(), r.name, r.condition, r.statuses::jsonb, r.threshold, r.icon, r.priority, TRUE, NOW(), NOW()
+FROM (VALUES
+    ('Ready for over a day', 'AFTER_ST_TIME', '["READY", "READY AT HOME"]', 1440, '🗿', 1),
+    ('ETA within a day', 'BEFORE_ST_TIME', '["ETA"]', 1440, '⏰', 2),
+    ('Late ETA', 'AFTER_ST_TIME', '["ETA", "ETA, WILL BE LATE"]', 0, '❗️', 3)
+) AS r(name, condition, statuses, threshold, icon, priority)
+WHERE NOT EXISTS (SELECT 1 FROM flag_rules);
+
+-- Notifies dispatchers of their late loads.
+INSERT INTO alert_rules (id, name, kind, severity, channels, enabled, created_at, updated_at)
+SELECT gen_random_uuid(), 'Late load', 'LATE_LOAD', 'WARNING', 'INBOX', TRUE, NOW(), NOW()
+WHERE NOT EXISTS (SELECT 1 FROM alert_rules WHERE kind = 'LATE_LOAD');
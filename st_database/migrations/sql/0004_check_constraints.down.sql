@@ -0,0 +1,17 @@
+ALTER TABLE flag_rules
+    DROP CONSTRAINT IF EXISTS chk_flag_rules_threshold,
+    DROP CONSTRAINT IF EXISTS chk_flag_rules_condition;
+
+ALTER TABLE alert_rules
+    DROP CONSTRAINT IF EXISTS chk_alert_rules_cooldown,
+    DROP CONSTRAINT IF EXISTS chk_alert_rules_threshold;
+
+ALTER TABLE alerts
+    DROP CONSTRAINT IF EXISTS chk_alerts_status;
+
+ALTER TABLE webhook_deliveries
+    DROP CONSTRAINT IF EXISTS chk_webhook_deliveries_status;
+
+ALTER TABLE job_runs
+    DROP CONSTRAINT IF EXISTS chk_job_runs_trigger,
+    DROP CONSTRAINT IF EXISTS chk_job_runs_status;
@@ -0,0 +1,18 @@
+-- Statuses and settings the backend only ever writes from a fixed set.
+ALTER TABLE job_runs
+    ADD CONSTRAINT chk_job_runs_status CHECK (status IN ('RUNNING', 'SUCCEEDED', 'FAILED')),
+    ADD CONSTRAINT chk_job_runs_trigger CHECK ("trigger" IN ('SCHEDULE', 'MANUAL'));
+
+ALTER TABLE webhook_deliveries
+    ADD CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED'));
+
+ALTER TABLE alerts
+    ADD CONSTRAINT chk_alerts_status CHECK (status IN ('OPEN', 'ACKNOWLEDGED', 'RESOLVED'));
+
+ALTER TABLE alert_rules
+    ADD CONSTRAINT chk_alert_rules_threshold CHECK (threshold >= 0),
+    ADD CONSTRAINT chk_alert_rules_cooldown CHECK (cooldown >= 0);
+
+ALTER TABLE flag_rules
+    ADD CONSTRAINT chk_flag_rules_condition CHECK (condition IN ('AFTER_ST_TIME', 'BEFORE_ST_TIME')),
+    ADD CONSTRAINT chk_flag_rules_threshold CHECK (threshold >= 0);
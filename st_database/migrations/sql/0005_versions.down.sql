@@ -0,0 +1,5 @@
+ALTER TABLE cargos
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE logistics
+    DROP COLUMN IF EXISTS version;
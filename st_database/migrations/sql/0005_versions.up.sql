@@ -0,0 +1,7 @@
+-- Versions for optimistic locking of the board: every write of a logistic or
+-- cargo bumps its version, and updates sent with an older one are refused.
+ALTER TABLE logistics
+    ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE cargos
+    ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+ALTER TABLE transactions
+    ALTER COLUMN provider TYPE varchar(50) USING LEFT(provider, 50);
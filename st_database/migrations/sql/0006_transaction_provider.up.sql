@@ -0,0 +1,3 @@
+-- Transactions copy the provider name, which can be as long as providers.name.
+ALTER TABLE transactions
+    ALTER COLUMN provider TYPE varchar(90);
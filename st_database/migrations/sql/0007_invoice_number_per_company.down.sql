@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_invoices_company_number;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices (number);
@@ -0,0 +1,5 @@
+-- Invoice numbers come from a counter per company, so they are only unique
+-- within a company; two companies sharing a SCAC both have a -000001.
+DROP INDEX IF EXISTS idx_invoices_number;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_company_number ON invoices (company_id, number);
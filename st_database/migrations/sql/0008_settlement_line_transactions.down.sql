@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_settlement_lines_transaction_id;
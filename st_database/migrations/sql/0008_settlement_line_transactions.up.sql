@@ -0,0 +1,2 @@
+-- Settlement runs look up whether a transaction is already paid.
+CREATE INDEX IF NOT EXISTS idx_settlement_lines_transaction_id ON settlement_lines (transaction_id);
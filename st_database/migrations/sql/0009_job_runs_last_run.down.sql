@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_job_runs_job ON job_runs (job);
+
+DROP INDEX IF EXISTS idx_job_runs_job_started_at;
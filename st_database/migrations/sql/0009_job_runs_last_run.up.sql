@@ -0,0 +1,5 @@
+-- The runner reads the latest run of every job on each health check, so runs
+-- are indexed by job and newest first. It replaces the index on job alone.
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_started_at ON job_runs (job, started_at DESC);
+
+DROP INDEX IF EXISTS idx_job_runs_job;
@@ -0,0 +1,18 @@
+-- Schema gorm AutoMigrate created before the backlog of providers, stops,
+-- invoices and the rest, as the production database still has it.
+CREATE TABLE "companies" ("id" uuid,"name" varchar(20) NOT NULL,"address" varchar(50) NOT NULL,"number" varchar(20) NOT NULL,"scac" varchar(20) NOT NULL,"start_date" date,"dot" bigint NOT NULL,"mc" bigint NOT NULL,"drivers_number" bigint NOT NULL,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_companies_deleted_at" ON "companies" ("deleted_at");
+CREATE TABLE "drivers" ("id" uuid,"name" varchar(50) NOT NULL,"surname" varchar(50) NOT NULL,"type" varchar(50) NOT NULL,"position" varchar(50) NOT NULL,"truck_number" varchar NOT NULL,"phone_number" varchar(20) NOT NULL,"mail" varchar(50) NOT NULL,"birthday" date NOT NULL,"start_date" date,"company_id" uuid NOT NULL,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_companies_drivers" FOREIGN KEY ("company_id") REFERENCES "companies"("id"));
+CREATE INDEX IF NOT EXISTS "idx_drivers_deleted_at" ON "drivers" ("deleted_at");
+CREATE TABLE "cargos" ("id" uuid,"cargo_id" varchar(90) NOT NULL,"provider" varchar(90) NOT NULL,"loaded_miles" bigint NOT NULL,"free_miles" bigint NOT NULL,"from" varchar(90) NOT NULL,"to" varchar(90) NOT NULL,"cost" bigint NOT NULL,"rate" decimal(10,2) NOT NULL,"pick_up_time" timestamp NOT NULL,"delivery_time" timestamp NOT NULL,"employee_id" uuid,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_cargos_deleted_at" ON "cargos" ("deleted_at");
+CREATE TABLE "logistics" ("id" uuid,"post" boolean DEFAULT false,"driver_id" uuid NOT NULL,"status" varchar(30) NOT NULL DEFAULT 'READY',"update_time" timestamp NOT NULL,"st_time" timestamp,"state" varchar(90) NOT NULL,"location" varchar(90) NOT NULL,"emoji" varchar(30) NOT NULL DEFAULT '',"notion" varchar(255) NOT NULL DEFAULT '',"cargo_id" uuid,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_logistics_cargo" FOREIGN KEY ("cargo_id") REFERENCES "cargos"("id"),CONSTRAINT "fk_logistics_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id"),CONSTRAINT "uni_logistics_driver_id" UNIQUE ("driver_id"));
+CREATE INDEX IF NOT EXISTS "idx_logistics_deleted_at" ON "logistics" ("deleted_at");
+CREATE TABLE "employees" ("id" uuid,"name" varchar(30) NOT NULL,"surname" varchar(30) NOT NULL,"username" varchar(50) NOT NULL,"position" varchar(30) NOT NULL,"access_level" bigint NOT NULL DEFAULT 3,"password" varchar(200) NOT NULL,"logo_id" varchar(255) DEFAULT NULL,"email" varchar(50) NOT NULL,"phone_number" varchar(50) NOT NULL,"birthday" date NOT NULL,"company" varchar(50) DEFAULT NULL,"start_date" date,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "uni_employees_username" UNIQUE ("username"),CONSTRAINT "uni_employees_email" UNIQUE ("email"));
+CREATE INDEX IF NOT EXISTS "idx_employees_deleted_at" ON "employees" ("deleted_at");
+CREATE TABLE "transactions" ("id" uuid,"from" varchar(50) NOT NULL,"to" varchar(50) NOT NULL,"pu_time" timestamp NOT NULL,"delivery_time" timestamp NOT NULL,"loaded_miles" bigint NOT NULL,"total_miles" bigint NOT NULL,"provider" varchar(50) NOT NULL,"cost" bigint NOT NULL,"rate" decimal(10,2) NOT NULL,"driver_id" uuid NOT NULL,"employee_id" uuid NOT NULL,"cargo_id" varchar(90) NOT NULL,"success" boolean NOT NULL,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_transactions_driver" FOREIGN KEY ("driver_id") REFERENCES "drivers"("id"),CONSTRAINT "fk_transactions_employee" FOREIGN KEY ("employee_id") REFERENCES "employees"("id"));
+CREATE INDEX IF NOT EXISTS "idx_transactions_deleted_at" ON "transactions" ("deleted_at");
+CREATE TABLE "performances" ("id" uuid,"reason" varchar(255),"whose_fault" varchar(255),"status" varchar(30),"section" varchar(255),"employee_id" uuid NOT NULL,"company_id" uuid NOT NULL,"load_id" varchar(255) NOT NULL,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_performances_employee" FOREIGN KEY ("employee_id") REFERENCES "employees"("id"),CONSTRAINT "fk_performances_company" FOREIGN KEY ("company_id") REFERENCES "companies"("id") ON DELETE SET NULL ON UPDATE CASCADE);
+CREATE INDEX IF NOT EXISTS "idx_performances_deleted_at" ON "performances" ("deleted_at");
+CREATE TABLE "histories" ("id" uuid,"driver_name" varchar(255) NOT NULL,"logistic_id" uuid NOT NULL,"from_logistic" jsonb,"to_logistic" jsonb,"from_cargo" jsonb,"to_cargo" jsonb,"employee_id" uuid NOT NULL,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_histories_employee" FOREIGN KEY ("employee_id") REFERENCES "employees"("id"));
+CREATE INDEX IF NOT EXISTS "idx_histories_deleted_at" ON "histories" ("deleted_at");
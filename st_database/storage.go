@@ -11,7 +11,18 @@ type IStore interface {
 	Employee() storage.Employee
 	Logistic() storage.Logistic
 	Cargo() storage.Cargo
+	Provider() storage.Provider
 	Transaction() storage.Transaction
+	Invoice() storage.Invoice
+	Settlement() storage.Settlement
+	Compliance() storage.Compliance
+	HOS() storage.HOS
+	Equipment() storage.Equipment
+	Maintenance() storage.Maintenance
+	Alert() storage.Alert
+	Webhook() storage.Webhook
+	FlagRule() storage.FlagRule
+	Job() storage.Job
 	Performance() storage.Performance
 	History() storage.History
 	DB() *gorm.DB
@@ -24,7 +35,18 @@ type Store struct {
 	employee    storage.Employee
 	logistic    storage.Logistic
 	cargo       storage.Cargo
+	provider    storage.Provider
 	transaction storage.Transaction
+	invoice     storage.Invoice
+	settlement  storage.Settlement
+	compliance  storage.Compliance
+	hos         storage.HOS
+	equipment   storage.Equipment
+	maintenance storage.Maintenance
+	alert       storage.Alert
+	webhook     storage.Webhook
+	flagRule    storage.FlagRule
+	job         storage.Job
 	performance storage.Performance
 	history     storage.History
 }
@@ -39,8 +61,30 @@ func (s *Store) Logistic() storage.Logistic { return s.logistic }
 
 func (s *Store) Cargo() storage.Cargo { return s.cargo }
 
+func (s *Store) Provider() storage.Provider { return s.provider }
+
 func (s *Store) Transaction() storage.Transaction { return s.transaction }
 
+func (s *Store) Invoice() storage.Invoice { return s.invoice }
+
+func (s *Store) Settlement() storage.Settlement { return s.settlement }
+
+func (s *Store) Compliance() storage.Compliance { return s.compliance }
+
+func (s *Store) HOS() storage.HOS { return s.hos }
+
+func (s *Store) Equipment() storage.Equipment { return s.equipment }
+
+func (s *Store) Maintenance() storage.Maintenance { return s.maintenance }
+
+func (s *Store) Alert() storage.Alert { return s.alert }
+
+func (s *Store) Webhook() storage.Webhook { return s.webhook }
+
+func (s *Store) FlagRule() storage.FlagRule { return s.flagRule }
+
+func (s *Store) Job() storage.Job { return s.job }
+
 func (s *Store) Performance() storage.Performance { return s.performance }
 
 func (s *Store) History() storage.History { return s.history }
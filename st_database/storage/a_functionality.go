@@ -3,7 +3,9 @@ package storage
 import (
 	"backend/models"
 	"context"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"time"
 )
 
 type Company interface {
@@ -35,17 +37,37 @@ type Logistic interface {
 	Create(ctx context.Context, update *models.Logistic, tx ...*gorm.DB) (string, error)
 	Update(ctx context.Context, update *models.Logistic, tx ...*gorm.DB) error
 	Delete(ctx context.Context, req models.RequestId) error
-	Get(ctx context.Context, req models.RequestId) (*models.Logistic, error)
+	Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Logistic, error)
+	GetByCargo(ctx context.Context, cargoId uuid.UUID, tx ...*gorm.DB) (*models.Logistic, error)
 	GetAll(ctx context.Context, req models.GetAllLogisticsReq) (*models.GetAllLogisticsResp, error)
-	Overview(ctx context.Context) (models.GetOverview, error)
-	Emoji(ctx context.Context) error
+	Overview(ctx context.Context, now time.Time) (models.GetOverview, error)
+	RefreshFlags(ctx context.Context, rules []models.FlagRule, now time.Time) error
+	GetOverdue(ctx context.Context, status string, now time.Time) ([]models.Logistic, error)
+	ChangeStatus(ctx context.Context, id uuid.UUID, from, to string, tx ...*gorm.DB) (bool, error)
 }
 
 type Cargo interface {
 	Create(ctx context.Context, cargo *models.Cargo, tx ...*gorm.DB) (string, error)
 	Update(ctx context.Context, cargo *models.Cargo, tx ...*gorm.DB) error
 	Delete(ctx context.Context, req models.RequestId) error
-	Get(ctx context.Context, req models.RequestId) (*models.Cargo, error)
+	Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Cargo, error)
+	ReplaceStops(ctx context.Context, cargoId uuid.UUID, stops []models.Stop, tx ...*gorm.DB) error
+	GetStop(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Stop, error)
+	UpdateStop(ctx context.Context, stop *models.Stop, tx ...*gorm.DB) error
+	Close(ctx context.Context, req models.RequestId, status string, transactionId uuid.UUID, tx ...*gorm.DB) error
+	GetWithDetails(ctx context.Context, req models.RequestId) (*models.CargoResponse, error)
+	GetAll(ctx context.Context, req models.GetAllCargosReq) (*models.GetAllCargosResp, error)
+	FindDuplicates(ctx context.Context, cargo *models.Cargo, window time.Duration, tx ...*gorm.DB) ([]models.DuplicateLoad, error)
+}
+
+type Provider interface {
+	Create(ctx context.Context, provider *models.Provider, tx ...*gorm.DB) (string, error)
+	Update(ctx context.Context, provider *models.Provider) error
+	Delete(ctx context.Context, req models.RequestId) error
+	Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Provider, error)
+	GetAll(ctx context.Context, req models.GetAllProvidersReq) (*models.GetAllProvidersResp, error)
+	GetOrCreateByName(ctx context.Context, name string, tx ...*gorm.DB) (*models.Provider, error)
+	Stats(ctx context.Context, req models.RequestId) (*models.ProviderStats, error)
 }
 
 type Transaction interface {
@@ -54,6 +76,139 @@ type Transaction interface {
 	Delete(ctx context.Context, req models.RequestId) error
 	Get(ctx context.Context, req models.RequestId) (*models.Transaction, error)
 	GetAll(ctx context.Context, req models.GetAllTransReq) (*models.GetAllTransResp, error)
+	GetForInvoice(ctx context.Context, ids []uuid.UUID, tx ...*gorm.DB) ([]models.Transaction, error)
+	SetInvoice(ctx context.Context, ids []uuid.UUID, invoiceId uuid.UUID, tx ...*gorm.DB) error
+	ReleaseInvoice(ctx context.Context, invoiceId uuid.UUID, tx ...*gorm.DB) error
+	GetUnsettled(ctx context.Context, driverId uuid.UUID, until time.Time, settlementId uuid.UUID, tx ...*gorm.DB) ([]models.Transaction, error)
+}
+
+type Invoice interface {
+	Create(ctx context.Context, invoice *models.Invoice, tx ...*gorm.DB) (string, error)
+	NextSequence(ctx context.Context, companyId uuid.UUID, tx ...*gorm.DB) (int64, error)
+	UpdateStatus(ctx context.Context, invoice *models.Invoice, tx ...*gorm.DB) error
+	UpdateFactoring(ctx context.Context, invoice *models.Invoice, tx ...*gorm.DB) error
+	AddPayment(ctx context.Context, payment *models.InvoicePayment, tx ...*gorm.DB) (string, error)
+	Delete(ctx context.Context, req models.RequestId, tx ...*gorm.DB) error
+	Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Invoice, error)
+	GetAll(ctx context.Context, req models.GetAllInvoicesReq) (*models.GetAllInvoicesResp, error)
+	Aging(ctx context.Context, req models.GetARAgingReq) ([]models.ARAging, error)
+	GetForLedger(ctx context.Context, providerId uuid.UUID) ([]models.Invoice, error)
+}
+
+type Settlement interface {
+	SavePayProfile(ctx context.Context, profile *models.PayProfile) error
+	GetPayProfile(ctx context.Context, driverId uuid.UUID) (*models.PayProfile, error)
+	GetPayProfiles(ctx context.Context, tx ...*gorm.DB) ([]models.PayProfile, error)
+	CreateAdjustment(ctx context.Context, adjustment *models.SettlementAdjustment) (string, error)
+	DeleteAdjustment(ctx context.Context, req models.RequestId) error
+	GetAllAdjustments(ctx context.Context, req models.GetAllAdjustmentsReq) (*models.GetAllAdjustmentsResp, error)
+	GetAdjustmentsForWeek(ctx context.Context, driverId uuid.UUID, weekStart, weekEnd time.Time, settlementId uuid.UUID, tx ...*gorm.DB) ([]models.SettlementAdjustment, error)
+	GetByWeek(ctx context.Context, driverId uuid.UUID, weekStart time.Time, tx ...*gorm.DB) (*models.Settlement, error)
+	Save(ctx context.Context, settlement *models.Settlement, tx ...*gorm.DB) error
+	DriversWithoutProfile(ctx context.Context, from, to time.Time) ([]models.Driver, error)
+	Lock(ctx context.Context, req models.RequestId, lockedAt time.Time, by models.RequestId) error
+	Get(ctx context.Context, req models.RequestId) (*models.Settlement, error)
+	GetAll(ctx context.Context, req models.GetAllSettlementsReq) (*models.GetAllSettlementsResp, error)
+}
+
+type Compliance interface {
+	Save(ctx context.Context, compliance *models.DriverCompliance) error
+	UpdateStatus(ctx context.Context, compliance *models.DriverCompliance) error
+	GetByDriver(ctx context.Context, driverId uuid.UUID, tx ...*gorm.DB) (*models.DriverCompliance, error)
+	GetAll(ctx context.Context) ([]models.DriverCompliance, error)
+	GetExpiring(ctx context.Context, until time.Time) ([]models.DriverCompliance, error)
+}
+
+type HOS interface {
+	Save(ctx context.Context, hos *models.DriverHOS) error
+	UpdateClocks(ctx context.Context, hos *models.DriverHOS) error
+	GetByDriver(ctx context.Context, driverId uuid.UUID, tx ...*gorm.DB) (*models.DriverHOS, error)
+	GetLinked(ctx context.Context) ([]models.DriverHOS, error)
+}
+
+type Equipment interface {
+	CreateTruck(ctx context.Context, truck *models.Truck) (string, error)
+	UpdateTruck(ctx context.Context, truck *models.Truck) error
+	DeleteTruck(ctx context.Context, req models.RequestId) error
+	GetTruck(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Truck, error)
+	GetAllTrucks(ctx context.Context, req models.GetAllEquipmentReq) (*models.GetAllTrucksResp, error)
+	RaiseOdometer(ctx context.Context, truckId uuid.UUID, odometer int64, tx ...*gorm.DB) error
+	CreateTrailer(ctx context.Context, trailer *models.Trailer) (string, error)
+	UpdateTrailer(ctx context.Context, trailer *models.Trailer) error
+	DeleteTrailer(ctx context.Context, req models.RequestId) error
+	GetTrailer(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Trailer, error)
+	GetAllTrailers(ctx context.Context, req models.GetAllEquipmentReq) (*models.GetAllTrailersResp, error)
+	AssignedDrivers(ctx context.Context, truckId, trailerId *uuid.UUID, tx ...*gorm.DB) ([]models.Driver, error)
+	Assign(ctx context.Context, assignment *models.EquipmentAssignment, truckNumber string, tx ...*gorm.DB) error
+	GetAllAssignments(ctx context.Context, req models.GetAllAssignmentsReq) (*models.GetAllAssignmentsResp, error)
+}
+
+type Maintenance interface {
+	CreateRecord(ctx context.Context, record *models.MaintenanceRecord, tx ...*gorm.DB) (string, error)
+	UpdateRecord(ctx context.Context, record *models.MaintenanceRecord, tx ...*gorm.DB) error
+	DeleteRecord(ctx context.Context, req models.RequestId) error
+	GetRecord(ctx context.Context, req models.RequestId) (*models.MaintenanceRecord, error)
+	GetAllRecords(ctx context.Context, req models.GetAllMaintenanceReq) (*models.GetAllMaintenanceRecordsResp, error)
+	CreateSchedule(ctx context.Context, schedule *models.MaintenanceSchedule) (string, error)
+	UpdateSchedule(ctx context.Context, schedule *models.MaintenanceSchedule) error
+	DeleteSchedule(ctx context.Context, req models.RequestId) error
+	GetAllSchedules(ctx context.Context, req models.GetAllMaintenanceReq) (*models.GetAllMaintenanceSchedulesResp, error)
+	GetActiveSchedules(ctx context.Context) ([]models.MaintenanceSchedule, error)
+	MarkServiced(ctx context.Context, truckId uuid.UUID, serviceType string, odometer int64, date time.Time, tx ...*gorm.DB) error
+	OpenDowntime(ctx context.Context, downtime *models.Downtime, tx ...*gorm.DB) (string, error)
+	GetOpenDowntime(ctx context.Context, logisticId uuid.UUID, tx ...*gorm.DB) (*models.Downtime, error)
+	CloseDowntime(ctx context.Context, id uuid.UUID, endedAt time.Time, tx ...*gorm.DB) error
+	GetAllDowntimes(ctx context.Context, req models.GetAllDowntimesReq) (*models.GetAllDowntimesResp, error)
+	DowntimeReport(ctx context.Context, req models.DowntimeReportReq, now time.Time) (*models.DowntimeReport, error)
+}
+
+type Alert interface {
+	CreateRule(ctx context.Context, rule *models.AlertRule) (string, error)
+	UpdateRule(ctx context.Context, rule *models.AlertRule) error
+	DeleteRule(ctx context.Context, req models.RequestId) error
+	GetRule(ctx context.Context, req models.RequestId) (*models.AlertRule, error)
+	GetRules(ctx context.Context, onlyEnabled bool) ([]models.AlertRule, error)
+	MatchLogistics(ctx context.Context, rule *models.AlertRule, now time.Time) ([]models.Logistic, error)
+	CreateAlert(ctx context.Context, alert *models.Alert) error
+	SaveAlert(ctx context.Context, alert *models.Alert) error
+	GetActiveAlerts(ctx context.Context, ruleId uuid.UUID) ([]models.Alert, error)
+	Resolve(ctx context.Context, ids []uuid.UUID, at time.Time) error
+	Acknowledge(ctx context.Context, id uuid.UUID, by uuid.UUID, at time.Time) error
+	Snooze(ctx context.Context, id uuid.UUID, until time.Time) error
+	GetAlert(ctx context.Context, req models.RequestId) (*models.Alert, error)
+	GetAllAlerts(ctx context.Context, req models.GetAllAlertsReq) (*models.GetAllAlertsResp, error)
+}
+
+type Webhook interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error)
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, req models.RequestId) error
+	GetSubscription(ctx context.Context, req models.RequestId) (*models.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context, onlyActive bool, tx ...*gorm.DB) ([]models.WebhookSubscription, error)
+	CreateEvent(ctx context.Context, event *models.WebhookEvent, tx ...*gorm.DB) error
+	GetUndispatchedEvents(ctx context.Context, limit int, tx ...*gorm.DB) ([]models.WebhookEvent, error)
+	MarkDispatched(ctx context.Context, ids []uuid.UUID, at time.Time, tx ...*gorm.DB) error
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery, tx ...*gorm.DB) error
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+	SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, req models.RequestId) (*models.WebhookDelivery, error)
+	GetAllDeliveries(ctx context.Context, req models.GetAllDeliveriesReq) (*models.GetAllDeliveriesResp, error)
+}
+
+type FlagRule interface {
+	Create(ctx context.Context, rule *models.FlagRule) (string, error)
+	Update(ctx context.Context, rule *models.FlagRule) error
+	Delete(ctx context.Context, req models.RequestId) error
+	Get(ctx context.Context, req models.RequestId) (*models.FlagRule, error)
+	GetAll(ctx context.Context, onlyEnabled bool) ([]models.FlagRule, error)
+}
+
+type Job interface {
+	CreateRun(ctx context.Context, run *models.JobRun) error
+	FinishRun(ctx context.Context, run *models.JobRun) error
+	GetLastRuns(ctx context.Context, jobs []string) ([]models.JobRun, error)
+	DeleteRuns(ctx context.Context, job string, before time.Time) (int64, error)
+	GetAllRuns(ctx context.Context, req models.GetAllJobRunsReq) (*models.GetAllJobRunsResp, error)
 }
 
 type Performance interface {
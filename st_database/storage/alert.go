@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type AlertRepo struct {
+	db *gorm.DB
+}
+
+func NewAlertRepo(db *gorm.DB) Alert {
+	return &AlertRepo{
+		db: db,
+	}
+}
+
+func (s *AlertRepo) CreateRule(ctx context.Context, rule *models.AlertRule) (string, error) {
+	id := uuid.New()
+	rule.Id = id
+
+	if err := s.db.WithContext(ctx).Create(rule).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *AlertRepo) UpdateRule(ctx context.Context, rule *models.AlertRule) error {
+	result := s.db.WithContext(ctx).Model(&models.AlertRule{}).Where("id = ?", rule.Id).
+		Updates(map[string]interface{}{
+			"Name":       rule.Name,
+			"Kind":       rule.Kind,
+			"Statuses":   rule.Statuses,
+			"Threshold":  rule.Threshold,
+			"Severity":   rule.Severity,
+			"Channels":   rule.Channels,
+			"Recipients": rule.Recipients,
+			"WebhookURL": rule.WebhookURL,
+			"Cooldown":   rule.Cooldown,
+			"Enabled":    rule.Enabled,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *AlertRepo) DeleteRule(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.AlertRule{}).Error
+}
+
+func (s *AlertRepo) GetRule(ctx context.Context, req models.RequestId) (*models.AlertRule, error) {
+	var rule models.AlertRule
+
+	err := s.db.WithContext(ctx).Where("id = ?", req.Id).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (s *AlertRepo) GetRules(ctx context.Context, onlyEnabled bool) ([]models.AlertRule, error) {
+	var (
+		rules []models.AlertRule
+		query = s.db.WithContext(ctx)
+	)
+
+	if onlyEnabled {
+		query = query.Where("enabled = ?", true)
+	}
+
+	if err := query.Order("name ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// MatchLogistics returns the logistics, with their driver, for which a rule of
+// one of the logistic kinds fires at now.
+func (s *AlertRepo) MatchLogistics(ctx context.Context, rule *models.AlertRule, now time.Time) ([]models.Logistic, error) {
+	var (
+		logistics []models.Logistic
+		threshold = time.Duration(rule.Threshold) * time.Minute
+		query     = s.db.WithContext(ctx).Model(&models.Logistic{}).Preload("Driver").Preload("Cargo").
+				Where("logistics.st_time IS NOT NULL")
+	)
+
+	if statuses := rule.StatusList(); len(statuses) > 0 {
+		query = query.Where("logistics.status IN ?", statuses)
+	}
+
+	switch rule.Kind {
+	case models.AlertKindStatusDuration, models.AlertKindETAPassed:
+		query = query.Where("logistics.st_time < ?", now.Add(-threshold))
+	case models.AlertKindETANearPickup:
+		query = query.Joins("JOIN cargos ON cargos.id = logistics.cargo_id").
+			Where("cargos.pick_up_time - logistics.st_time < ?::interval", fmt.Sprintf("%d minutes", rule.Threshold))
+	case models.AlertKindLateLoad:
+		query = query.Where("logistics.status = ? AND logistics.cargo_id IS NOT NULL", models.LogisticStatusLate)
+	default:
+		return nil, fmt.Errorf("rule kind %s does not match logistics", rule.Kind)
+	}
+
+	if err := query.Find(&logistics).Error; err != nil {
+		return nil, err
+	}
+
+	return logistics, nil
+}
+
+func (s *AlertRepo) CreateAlert(ctx context.Context, alert *models.Alert) error {
+	alert.Id = uuid.New()
+
+	return s.db.WithContext(ctx).Omit(clause.Associations).Create(alert).Error
+}
+
+// SaveAlert stores what the engine changed on an alert it saw again.
+func (s *AlertRepo) SaveAlert(ctx context.Context, alert *models.Alert) error {
+	return s.db.WithContext(ctx).Model(&models.Alert{}).Where("id = ?", alert.Id).
+		Updates(map[string]interface{}{
+			"Title":         alert.Title,
+			"Message":       alert.Message,
+			"Count":         alert.Count,
+			"LastSeenAt":    alert.LastSeenAt,
+			"NotifiedAt":    alert.NotifiedAt,
+			"DeliveryError": alert.DeliveryError,
+			"Inbox":         alert.Inbox,
+		}).Error
+}
+
+// GetActiveAlerts returns the alerts of the rule that are not resolved yet.
+func (s *AlertRepo) GetActiveAlerts(ctx context.Context, ruleId uuid.UUID) ([]models.Alert, error) {
+	var alerts []models.Alert
+
+	err := s.db.WithContext(ctx).Where("rule_id = ? AND status <> ?", ruleId, models.AlertStatusResolved).
+		Find(&alerts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (s *AlertRepo) Resolve(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Model(&models.Alert{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":      models.AlertStatusResolved,
+			"resolved_at": at,
+		}).Error
+}
+
+func (s *AlertRepo) Acknowledge(ctx context.Context, id uuid.UUID, by uuid.UUID, at time.Time) error {
+	result := s.db.WithContext(ctx).Model(&models.Alert{}).
+		Where("id = ? AND status = ?", id, models.AlertStatusOpen).
+		Updates(map[string]interface{}{
+			"status":          models.AlertStatusAcknowledged,
+			"acknowledged_at": at,
+			"acknowledged_by": by,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *AlertRepo) Snooze(ctx context.Context, id uuid.UUID, until time.Time) error {
+	result := s.db.WithContext(ctx).Model(&models.Alert{}).
+		Where("id = ? AND status <> ?", id, models.AlertStatusResolved).
+		Update("snoozed_until", until)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *AlertRepo) GetAlert(ctx context.Context, req models.RequestId) (*models.Alert, error) {
+	var alert models.Alert
+
+	err := s.db.WithContext(ctx).Preload("Rule").Where("id = ?", req.Id).First(&alert).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+func (s *AlertRepo) GetAllAlerts(ctx context.Context, req models.GetAllAlertsReq) (*models.GetAllAlertsResp, error) {
+	var (
+		resp   models.GetAllAlertsResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.Alert{})
+	)
+
+	if req.RuleId != uuid.Nil {
+		query = query.Where("rule_id = ?", req.RuleId)
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	if req.Severity != "" {
+		query = query.Where("severity = ?", req.Severity)
+	}
+
+	if req.Inbox {
+		query = query.Where("inbox = ?", true)
+	}
+
+	if req.EmployeeId != uuid.Nil {
+		query = query.Where("employee_id = ?", req.EmployeeId)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Order("last_seen_at DESC").Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Alerts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
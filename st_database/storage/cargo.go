@@ -1,10 +1,15 @@
 package storage
 
 import (
+	"backend/etc/Utime"
+	"backend/etc/helpers"
 	"backend/models"
 	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"time"
 )
 
 type CargoRepo struct {
@@ -27,7 +32,11 @@ func (s *CargoRepo) Create(ctx context.Context, cargo *models.Cargo, tx ...*gorm
 		query = tx[0]
 	}
 
-	if err := query.WithContext(ctx).Create(&cargo).Error; err != nil {
+	if err := query.WithContext(ctx).Omit(clause.Associations).Create(&cargo).Error; err != nil {
+		return "", err
+	}
+
+	if err := s.ReplaceStops(ctx, id, cargo.Stops, query); err != nil {
 		return "", err
 	}
 
@@ -41,13 +50,23 @@ func (s *CargoRepo) Update(ctx context.Context, cargo *models.Cargo, tx ...*gorm
 		query = tx[0]
 	}
 
-	result := query.WithContext(ctx).Model(cargo).Omit("Id", "EmployeeId").Updates(cargo)
+	result := query.WithContext(ctx).Model(cargo).Omit("Id", "EmployeeId", "Version", clause.Associations).Updates(cargo)
 	if result.Error != nil {
 		return result.Error
 	} else if result.RowsAffected == 0 {
 		return gorm.ErrRecordNotFound
 	}
 
+	err := query.WithContext(ctx).Model(&models.Cargo{}).Where("id = ?", cargo.Id).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error
+	if err != nil {
+		return err
+	}
+
+	if cargo.Stops != nil {
+		return s.ReplaceStops(ctx, cargo.Id, cargo.Stops, query)
+	}
+
 	return nil
 }
 
@@ -55,13 +74,331 @@ func (s *CargoRepo) Delete(ctx context.Context, req models.RequestId) error {
 	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.Cargo{}).Error
 }
 
-func (s *CargoRepo) Get(ctx context.Context, req models.RequestId) (*models.Cargo, error) {
-	var cargo *models.Cargo
+// Get loads a cargo with its stops. Inside a transaction the cargo row is
+// locked until the transaction ends.
+func (s *CargoRepo) Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Cargo, error) {
+	var (
+		cargo *models.Cargo
+		query = s.db.WithContext(ctx)
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0].WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}})
+	}
 
-	err := s.db.WithContext(ctx).Where("id = ?", req.Id).First(&cargo).Error
+	err := query.Preload("Stops", orderStops).Where("id = ?", req.Id).First(&cargo).Error
 	if err != nil {
 		return nil, err
 	}
 
 	return cargo, nil
 }
+
+// ReplaceStops makes the given stops the stop list of a cargo. Stops sent
+// with the id of one of its stops are updated in place, so ids handed out for
+// status updates stay valid, the others are added and the stops left out are
+// deleted. Sequence numbers are assigned from the slice order.
+func (s *CargoRepo) ReplaceStops(ctx context.Context, cargoId uuid.UUID, stops []models.Stop, tx ...*gorm.DB) error {
+	var (
+		query    = s.db
+		existing []uuid.UUID
+		kept     = make([]uuid.UUID, 0, len(stops))
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Model(&models.Stop{}).Where("cargo_id = ?", cargoId).Pluck("id", &existing).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range stops {
+		if stops[i].Id == uuid.Nil || !slices.Contains(existing, stops[i].Id) || slices.Contains(kept, stops[i].Id) {
+			stops[i].Id = uuid.New()
+		} else {
+			kept = append(kept, stops[i].Id)
+		}
+		stops[i].CargoId = cargoId
+		stops[i].Sequence = i + 1
+		if stops[i].Status == "" {
+			stops[i].Status = models.StopStatusPending
+		}
+	}
+
+	removed := query.WithContext(ctx).Unscoped().Where("cargo_id = ?", cargoId)
+	if len(kept) > 0 {
+		removed = removed.Where("id NOT IN ?", kept)
+	}
+	if err = removed.Delete(&models.Stop{}).Error; err != nil {
+		return err
+	}
+
+	if len(stops) == 0 {
+		return nil
+	}
+
+	return query.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"sequence", "type", "location", "appointment_time", "status", "arrived_at", "completed_at", "updated_at",
+		}),
+	}).Create(&stops).Error
+}
+
+func (s *CargoRepo) GetStop(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Stop, error) {
+	var (
+		stop  models.Stop
+		query = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Where("id = ?", req.Id).First(&stop).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &stop, nil
+}
+
+func (s *CargoRepo) UpdateStop(ctx context.Context, stop *models.Stop, tx ...*gorm.DB) error {
+	var query = s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	result := query.WithContext(ctx).Model(stop).Where("id = ?", stop.Id).Updates(map[string]interface{}{
+		"Status":      stop.Status,
+		"ArrivedAt":   stop.ArrivedAt,
+		"CompletedAt": stop.CompletedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Close marks a cargo as delivered or cancelled once Terminate/CancelLate has
+// written its transaction and detached it from the board.
+func (s *CargoRepo) Close(ctx context.Context, req models.RequestId, status string, transactionId uuid.UUID, tx ...*gorm.DB) error {
+	var query = s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	return query.WithContext(ctx).Model(&models.Cargo{}).Where("id = ?", req.Id).Updates(map[string]interface{}{
+		"Status":        status,
+		"TransactionId": transactionId,
+		"TerminatedAt":  Utime.Now(),
+		"Version":       gorm.Expr("version + 1"),
+	}).Error
+}
+
+func (s *CargoRepo) GetWithDetails(ctx context.Context, req models.RequestId) (*models.CargoResponse, error) {
+	var resp models.CargoResponse
+
+	err := s.detailsQuery(ctx).Where("cargos.id = ?", req.Id).Take(&resp).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Where("cargo_id = ?", req.Id).Order("sequence ASC").Find(&resp.Stops).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *CargoRepo) GetAll(ctx context.Context, req models.GetAllCargosReq) (*models.GetAllCargosResp, error) {
+	var (
+		resp   models.GetAllCargosResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.detailsQuery(ctx)
+	)
+
+	if req.Search != "" {
+		search := "%" + req.Search + "%"
+		query = query.Where(`cargos.cargo_id ILIKE ? OR cargos.provider ILIKE ? OR cargos."from" ILIKE ? OR cargos."to" ILIKE ?`,
+			search, search, search, search)
+	}
+
+	if req.CargoID != "" {
+		query = query.Where("UPPER(TRIM(cargos.cargo_id)) = UPPER(TRIM(?))", req.CargoID)
+	}
+
+	if req.Provider != "" {
+		query = query.Where("cargos.provider ILIKE ?", "%"+req.Provider+"%")
+	}
+
+	if req.ProviderId != uuid.Nil {
+		query = query.Where("cargos.provider_id = ?", req.ProviderId)
+	}
+
+	if req.From != "" {
+		query = query.Where(`EXISTS (SELECT 1 FROM cargo_stops cs
+			WHERE cs.cargo_id = cargos.id AND cs.type = ? AND cs.location ILIKE ? AND cs.deleted_at IS NULL)`,
+			models.StopTypePickup, "%"+req.From+"%")
+	}
+
+	if req.To != "" {
+		query = query.Where(`EXISTS (SELECT 1 FROM cargo_stops cs
+			WHERE cs.cargo_id = cargos.id AND cs.type = ? AND cs.location ILIKE ? AND cs.deleted_at IS NULL)`,
+			models.StopTypeDelivery, "%"+req.To+"%")
+	}
+
+	if req.PickUpFrom != nil {
+		query = query.Where("cargos.pick_up_time >= ?", *req.PickUpFrom)
+	}
+
+	if req.PickUpTo != nil {
+		query = query.Where("cargos.pick_up_time < ?", *req.PickUpTo)
+	}
+
+	if req.EmployeeId != uuid.Nil {
+		query = query.Where("cargos.employee_id = ?", req.EmployeeId)
+	}
+
+	if req.DispatcherName != "" {
+		query = query.Where("CONCAT(employees.name, ' ', employees.surname) ILIKE ?", "%"+req.DispatcherName+"%")
+	}
+
+	if req.DriverId != uuid.Nil {
+		query = query.Where("cargos.driver_id = ?", req.DriverId)
+	}
+
+	if req.Status != "" {
+		query = query.Where("cargos.status = ?", req.Status)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Order("cargos.pick_up_time DESC").Offset(int(offset)).Limit(int(req.Limit)).Scan(&resp.Cargos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// detailsQuery selects cargos together with the dispatcher who booked them,
+// the driver who carried them and, for loads still on the board, the
+// logistic they are attached to.
+func (s *CargoRepo) detailsQuery(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Model(&models.Cargo{}).
+		Joins("LEFT JOIN drivers ON drivers.id = cargos.driver_id").
+		Joins("LEFT JOIN employees ON employees.id = cargos.employee_id").
+		Joins("LEFT JOIN logistics ON logistics.cargo_id = cargos.id AND logistics.deleted_at IS NULL").
+		Select(`
+			cargos.id AS id,
+			cargos.cargo_id AS cargo_id,
+			cargos.provider AS provider,
+			cargos.provider_id AS provider_id,
+			cargos.loaded_miles AS loaded_miles,
+			cargos.free_miles AS free_miles,
+			cargos."from" AS "from",
+			cargos."to" AS "to",
+			cargos.cost AS cost,
+			cargos.rate AS rate,
+			cargos.pick_up_time AS pick_up_time,
+			cargos.delivery_time AS delivery_time,
+			cargos.status AS status,
+			cargos.employee_id AS employee_id,
+			TRIM(CONCAT(employees.name, ' ', employees.surname)) AS dispatcher_name,
+			cargos.driver_id AS driver_id,
+			COALESCE(drivers.name, '') AS driver_name,
+			COALESCE(drivers.surname, '') AS driver_surname,
+			logistics.id AS logistic_id,
+			cargos.transaction_id AS transaction_id,
+			cargos.terminated_at AS terminated_at,
+			cargos.created_at AS created_at
+		`)
+}
+
+// FindDuplicates looks for loads of the same provider that either carry the
+// same normalized load number or run the same lane with a pickup within the
+// given window. Legacy transactions that never had a cargo row are checked
+// by load number as well.
+func (s *CargoRepo) FindDuplicates(ctx context.Context, cargo *models.Cargo, window time.Duration, tx ...*gorm.DB) ([]models.DuplicateLoad, error) {
+	var (
+		resp    []models.DuplicateLoad
+		legacy  []models.DuplicateLoad
+		query   = s.db
+		cargoID = helpers.NormalizeCargoID(cargo.CargoID)
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	if cargo.ProviderId == nil {
+		return nil, nil
+	}
+
+	err := query.WithContext(ctx).Raw(`
+		SELECT c.id AS cargo_id,
+		       c.cargo_id AS load_id,
+		       c.provider AS provider,
+		       c."from" AS "from",
+		       c."to" AS "to",
+		       c.pick_up_time AS pick_up_time,
+		       c.status AS status,
+		       l.id AS logistic_id,
+		       c.transaction_id AS transaction_id,
+		       UPPER(REGEXP_REPLACE(c.cargo_id, '\s', '', 'g')) = @cargo_id AS exact
+		FROM cargos c
+		LEFT JOIN logistics l ON l.cargo_id = c.id AND l.deleted_at IS NULL
+		WHERE c.deleted_at IS NULL
+		  AND c.provider_id = @provider_id
+		  AND c.id <> @id
+		  AND (
+		      UPPER(REGEXP_REPLACE(c.cargo_id, '\s', '', 'g')) = @cargo_id
+		      OR (UPPER(TRIM(c."from")) = UPPER(TRIM(@from))
+		          AND UPPER(TRIM(c."to")) = UPPER(TRIM(@to))
+		          AND c.pick_up_time BETWEEN @pick_up_from AND @pick_up_to)
+		  )
+		ORDER BY exact DESC, c.pick_up_time DESC
+	`, map[string]interface{}{
+		"cargo_id":     cargoID,
+		"provider_id":  *cargo.ProviderId,
+		"id":           cargo.Id,
+		"from":         cargo.From,
+		"to":           cargo.To,
+		"pick_up_from": cargo.PickUpTime.Add(-window),
+		"pick_up_to":   cargo.PickUpTime.Add(window),
+	}).Scan(&resp).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.WithContext(ctx).Raw(`
+		SELECT t.cargo_id AS load_id,
+		       t.provider AS provider,
+		       t."from" AS "from",
+		       t."to" AS "to",
+		       t.pu_time AS pick_up_time,
+		       CASE WHEN t.success THEN 'DELIVERED' ELSE 'CANCELLED' END AS status,
+		       t.id AS transaction_id,
+		       TRUE AS exact
+		FROM transactions t
+		WHERE t.deleted_at IS NULL
+		  AND t.provider_id = ?
+		  AND UPPER(REGEXP_REPLACE(t.cargo_id, '\s', '', 'g')) = ?
+		  AND NOT EXISTS (SELECT 1 FROM cargos c WHERE c.transaction_id = t.id)
+	`, *cargo.ProviderId, cargoID).Scan(&legacy).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return append(resp, legacy...), nil
+}
+
+func orderStops(db *gorm.DB) *gorm.DB {
+	return db.Order("sequence ASC")
+}
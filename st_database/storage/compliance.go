@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ComplianceRepo struct {
+	db *gorm.DB
+}
+
+func NewComplianceRepo(db *gorm.DB) Compliance {
+	return &ComplianceRepo{
+		db: db,
+	}
+}
+
+// Save creates the compliance record of a driver or replaces the existing one.
+func (s *ComplianceRepo) Save(ctx context.Context, compliance *models.DriverCompliance) error {
+	var existing models.DriverCompliance
+	err := s.db.WithContext(ctx).Where("driver_id = ?", compliance.DriverId).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		compliance.Id = uuid.New()
+		return s.db.WithContext(ctx).Omit("Driver").Create(compliance).Error
+	} else if err != nil {
+		return err
+	}
+
+	compliance.Id = existing.Id
+	return s.db.WithContext(ctx).Model(&existing).Updates(map[string]interface{}{
+		"CDLNumber":         compliance.CDLNumber,
+		"CDLState":          compliance.CDLState,
+		"CDLClass":          compliance.CDLClass,
+		"CDLExpiry":         compliance.CDLExpiry,
+		"Endorsements":      compliance.Endorsements,
+		"MedicalCardExpiry": compliance.MedicalCardExpiry,
+		"LastDrugTest":      compliance.LastDrugTest,
+		"NextDrugTest":      compliance.NextDrugTest,
+		"MVRReviewedAt":     compliance.MVRReviewedAt,
+		"MVRNextReview":     compliance.MVRNextReview,
+		"Status":            compliance.Status,
+		"Issues":            compliance.Issues,
+		"CheckedAt":         compliance.CheckedAt,
+	}).Error
+}
+
+func (s *ComplianceRepo) UpdateStatus(ctx context.Context, compliance *models.DriverCompliance) error {
+	return s.db.WithContext(ctx).Model(&models.DriverCompliance{}).Where("id = ?", compliance.Id).
+		Updates(map[string]interface{}{
+			"Status":    compliance.Status,
+			"Issues":    compliance.Issues,
+			"CheckedAt": compliance.CheckedAt,
+		}).Error
+}
+
+func (s *ComplianceRepo) GetByDriver(ctx context.Context, driverId uuid.UUID, tx ...*gorm.DB) (*models.DriverCompliance, error) {
+	var (
+		compliance models.DriverCompliance
+		query      = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Where("driver_id = ?", driverId).First(&compliance).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &compliance, nil
+}
+
+// GetAll returns every compliance record; used by the background checker.
+func (s *ComplianceRepo) GetAll(ctx context.Context) ([]models.DriverCompliance, error) {
+	var records []models.DriverCompliance
+	err := s.db.WithContext(ctx).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// GetExpiring returns the records with at least one document expiring on or
+// before until, including documents that already expired.
+func (s *ComplianceRepo) GetExpiring(ctx context.Context, until time.Time) ([]models.DriverCompliance, error) {
+	var records []models.DriverCompliance
+	err := s.db.WithContext(ctx).Preload("Driver").
+		Where("cdl_expiry <= ? OR medical_card_expiry <= ? OR next_drug_test <= ? OR mvr_next_review <= ?",
+			until, until, until, until).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
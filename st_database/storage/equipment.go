@@ -0,0 +1,320 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+type EquipmentRepo struct {
+	db *gorm.DB
+}
+
+func NewEquipmentRepo(db *gorm.DB) Equipment {
+	return &EquipmentRepo{
+		db: db,
+	}
+}
+
+func (s *EquipmentRepo) CreateTruck(ctx context.Context, truck *models.Truck) (string, error) {
+	id := uuid.New()
+	truck.Id = id
+	truck.VIN = strings.ToUpper(truck.VIN)
+
+	if err := s.db.WithContext(ctx).Omit(clause.Associations).Create(truck).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *EquipmentRepo) UpdateTruck(ctx context.Context, truck *models.Truck) error {
+	result := s.db.WithContext(ctx).Model(&models.Truck{}).Where("id = ?", truck.Id).
+		Updates(map[string]interface{}{
+			"CompanyId":          truck.CompanyId,
+			"Number":             truck.Number,
+			"VIN":                strings.ToUpper(truck.VIN),
+			"Make":               truck.Make,
+			"Model":              truck.Model,
+			"Year":               truck.Year,
+			"Plate":              truck.Plate,
+			"PlateState":         truck.PlateState,
+			"RegistrationExpiry": truck.RegistrationExpiry,
+			"Ownership":          truck.Ownership,
+			"Status":             truck.Status,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *EquipmentRepo) DeleteTruck(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.Truck{}).Error
+}
+
+func (s *EquipmentRepo) GetTruck(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Truck, error) {
+	var (
+		truck models.Truck
+		query = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Where("id = ?", req.Id).First(&truck).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &truck, nil
+}
+
+func (s *EquipmentRepo) GetAllTrucks(ctx context.Context, req models.GetAllEquipmentReq) (*models.GetAllTrucksResp, error) {
+	var (
+		resp   models.GetAllTrucksResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.Truck{})
+	)
+
+	if req.CompanyId != uuid.Nil {
+		query = query.Where("company_id = ?", req.CompanyId)
+	}
+
+	if req.Search != "" {
+		query = query.Where("number ILIKE ? OR vin ILIKE ? OR plate ILIKE ?",
+			"%"+req.Search+"%", "%"+req.Search+"%", "%"+req.Search+"%")
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Order("number ASC").Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Trucks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// RaiseOdometer moves the odometer of the truck forward, never back.
+func (s *EquipmentRepo) RaiseOdometer(ctx context.Context, truckId uuid.UUID, odometer int64, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	return query.WithContext(ctx).Model(&models.Truck{}).
+		Where("id = ? AND odometer < ?", truckId, odometer).
+		Update("odometer", odometer).Error
+}
+
+func (s *EquipmentRepo) CreateTrailer(ctx context.Context, trailer *models.Trailer) (string, error) {
+	id := uuid.New()
+	trailer.Id = id
+	trailer.VIN = strings.ToUpper(trailer.VIN)
+
+	if err := s.db.WithContext(ctx).Omit(clause.Associations).Create(trailer).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *EquipmentRepo) UpdateTrailer(ctx context.Context, trailer *models.Trailer) error {
+	result := s.db.WithContext(ctx).Model(&models.Trailer{}).Where("id = ?", trailer.Id).
+		Updates(map[string]interface{}{
+			"CompanyId":          trailer.CompanyId,
+			"Number":             trailer.Number,
+			"VIN":                strings.ToUpper(trailer.VIN),
+			"Type":               trailer.Type,
+			"Length":             trailer.Length,
+			"Make":               trailer.Make,
+			"Year":               trailer.Year,
+			"Plate":              trailer.Plate,
+			"PlateState":         trailer.PlateState,
+			"RegistrationExpiry": trailer.RegistrationExpiry,
+			"Ownership":          trailer.Ownership,
+			"Status":             trailer.Status,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *EquipmentRepo) DeleteTrailer(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.Trailer{}).Error
+}
+
+func (s *EquipmentRepo) GetTrailer(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Trailer, error) {
+	var (
+		trailer models.Trailer
+		query   = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Where("id = ?", req.Id).First(&trailer).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &trailer, nil
+}
+
+func (s *EquipmentRepo) GetAllTrailers(ctx context.Context, req models.GetAllEquipmentReq) (*models.GetAllTrailersResp, error) {
+	var (
+		resp   models.GetAllTrailersResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.Trailer{})
+	)
+
+	if req.CompanyId != uuid.Nil {
+		query = query.Where("company_id = ?", req.CompanyId)
+	}
+
+	if req.Search != "" {
+		query = query.Where("number ILIKE ? OR vin ILIKE ? OR plate ILIKE ?",
+			"%"+req.Search+"%", "%"+req.Search+"%", "%"+req.Search+"%")
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	if req.Type != "" {
+		query = query.Where("type = ?", req.Type)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Order("number ASC").Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Trailers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// AssignedDrivers returns the drivers currently running the truck or the
+// trailer, locked for the rest of tx.
+func (s *EquipmentRepo) AssignedDrivers(ctx context.Context, truckId, trailerId *uuid.UUID, tx ...*gorm.DB) ([]models.Driver, error) {
+	var (
+		drivers []models.Driver
+		query   = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+	if truckId == nil && trailerId == nil {
+		return nil, nil
+	}
+
+	query = query.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"})
+	switch {
+	case truckId != nil && trailerId != nil:
+		query = query.Where("truck_id = ? OR trailer_id = ?", *truckId, *trailerId)
+	case truckId != nil:
+		query = query.Where("truck_id = ?", *truckId)
+	default:
+		query = query.Where("trailer_id = ?", *trailerId)
+	}
+
+	if err := query.Find(&drivers).Error; err != nil {
+		return nil, err
+	}
+
+	return drivers, nil
+}
+
+// Assign ends the open assignment of the driver, starts a new one for the
+// given equipment (if any) and points the driver at it. The truck number of
+// the driver follows the truck; it is cleared when the truck is taken away.
+func (s *EquipmentRepo) Assign(ctx context.Context, assignment *models.EquipmentAssignment, truckNumber string, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+	query = query.WithContext(ctx)
+
+	err := query.Model(&models.EquipmentAssignment{}).
+		Where("driver_id = ? AND ended_at IS NULL", assignment.DriverId).
+		Update("ended_at", assignment.StartedAt).Error
+	if err != nil {
+		return err
+	}
+
+	if assignment.TruckId != nil || assignment.TrailerId != nil {
+		assignment.Id = uuid.New()
+		if err = query.Omit(clause.Associations).Create(assignment).Error; err != nil {
+			return err
+		}
+	}
+
+	fields := map[string]interface{}{
+		"truck_id":     assignment.TruckId,
+		"trailer_id":   assignment.TrailerId,
+		"truck_number": truckNumber,
+	}
+	if assignment.TruckId == nil {
+		// A number typed in for a driver without a truck record is kept, only
+		// the one of the truck taken away is cleared.
+		fields["truck_number"] = gorm.Expr("CASE WHEN truck_id IS NULL THEN truck_number ELSE '' END")
+	}
+
+	return query.Model(&models.Driver{}).Where("id = ?", assignment.DriverId).Updates(fields).Error
+}
+
+func (s *EquipmentRepo) GetAllAssignments(ctx context.Context, req models.GetAllAssignmentsReq) (*models.GetAllAssignmentsResp, error) {
+	var (
+		resp   models.GetAllAssignmentsResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.EquipmentAssignment{})
+	)
+
+	if req.DriverId != uuid.Nil {
+		query = query.Where("driver_id = ?", req.DriverId)
+	}
+
+	if req.TruckId != uuid.Nil {
+		query = query.Where("truck_id = ?", req.TruckId)
+	}
+
+	if req.TrailerId != uuid.Nil {
+		query = query.Where("trailer_id = ?", req.TrailerId)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Preload("Driver").Preload("Truck").Preload("Trailer").
+		Order("started_at DESC").Offset(int(offset)).Limit(int(req.Limit)).
+		Find(&resp.Assignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"backend/etc/apperr"
+	"errors"
+	"gorm.io/gorm"
+)
+
+// ErrorTranslator replaces the error of every query with its apperr
+// counterpart, so a missing record or a violated constraint reaches the
+// services as a NotFound, Conflict or Validation error. The original error
+// stays wrapped, errors.Is(err, gorm.ErrRecordNotFound) keeps working.
+type ErrorTranslator struct{}
+
+func (ErrorTranslator) Name() string { return "apperr" }
+
+func (ErrorTranslator) Initialize(db *gorm.DB) error {
+	c := db.Callback()
+
+	return errors.Join(
+		c.Create().After("gorm:create").Register("apperr:create", translate),
+		c.Query().After("gorm:query").Register("apperr:query", translate),
+		c.Update().After("gorm:update").Register("apperr:update", translate),
+		c.Delete().After("gorm:delete").Register("apperr:delete", translate),
+		c.Row().After("gorm:row").Register("apperr:row", translate),
+		c.Raw().After("gorm:raw").Register("apperr:raw", translate),
+	)
+}
+
+func translate(db *gorm.DB) {
+	if db.Error != nil {
+		db.Error = apperr.FromDB(db.Error)
+	}
+}
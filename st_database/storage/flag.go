@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FlagRuleRepo struct {
+	db *gorm.DB
+}
+
+func NewFlagRuleRepo(db *gorm.DB) FlagRule {
+	return &FlagRuleRepo{
+		db: db,
+	}
+}
+
+func (s *FlagRuleRepo) Create(ctx context.Context, rule *models.FlagRule) (string, error) {
+	id := uuid.New()
+	rule.Id = id
+
+	if err := s.db.WithContext(ctx).Create(rule).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *FlagRuleRepo) Update(ctx context.Context, rule *models.FlagRule) error {
+	result := s.db.WithContext(ctx).Model(&models.FlagRule{}).Where("id = ?", rule.Id).
+		Updates(map[string]interface{}{
+			"Name":      rule.Name,
+			"Condition": rule.Condition,
+			"Statuses":  rule.Statuses,
+			"Threshold": rule.Threshold,
+			"Icon":      rule.Icon,
+			"Priority":  rule.Priority,
+			"CompanyId": rule.CompanyId,
+			"Enabled":   rule.Enabled,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *FlagRuleRepo) Delete(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.FlagRule{}).Error
+}
+
+func (s *FlagRuleRepo) Get(ctx context.Context, req models.RequestId) (*models.FlagRule, error) {
+	var rule models.FlagRule
+
+	err := s.db.WithContext(ctx).Where("id = ?", req.Id).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// GetAll returns the rules in the order their flags are shown.
+func (s *FlagRuleRepo) GetAll(ctx context.Context, onlyEnabled bool) ([]models.FlagRule, error) {
+	var (
+		rules []models.FlagRule
+		query = s.db.WithContext(ctx)
+	)
+
+	if onlyEnabled {
+		query = query.Where("enabled = ?", true)
+	}
+
+	if err := query.Order("priority ASC").Order("name ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
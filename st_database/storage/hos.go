@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type HOSRepo struct {
+	db *gorm.DB
+}
+
+func NewHOSRepo(db *gorm.DB) HOS {
+	return &HOSRepo{
+		db: db,
+	}
+}
+
+// Save creates the hours-of-service record of a driver or replaces the existing one.
+func (s *HOSRepo) Save(ctx context.Context, hos *models.DriverHOS) error {
+	var existing models.DriverHOS
+	err := s.db.WithContext(ctx).Where("driver_id = ?", hos.DriverId).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		hos.Id = uuid.New()
+		return s.db.WithContext(ctx).Omit("Driver").Create(hos).Error
+	} else if err != nil {
+		return err
+	}
+
+	hos.Id = existing.Id
+	return s.db.WithContext(ctx).Model(&existing).Updates(map[string]interface{}{
+		"DriveLeft":   hos.DriveLeft,
+		"OnDutyLeft":  hos.OnDutyLeft,
+		"CycleLeft":   hos.CycleLeft,
+		"Source":      hos.Source,
+		"ELDDriverId": hos.ELDDriverId,
+		"ReportedAt":  hos.ReportedAt,
+		"EmployeeId":  hos.EmployeeId,
+	}).Error
+}
+
+// UpdateClocks stores the clocks reported by an ELD without touching the link.
+func (s *HOSRepo) UpdateClocks(ctx context.Context, hos *models.DriverHOS) error {
+	return s.db.WithContext(ctx).Model(&models.DriverHOS{}).Where("id = ?", hos.Id).
+		Updates(map[string]interface{}{
+			"DriveLeft":  hos.DriveLeft,
+			"OnDutyLeft": hos.OnDutyLeft,
+			"CycleLeft":  hos.CycleLeft,
+			"Source":     models.HOSSourceELD,
+			"ReportedAt": hos.ReportedAt,
+			"EmployeeId": nil,
+		}).Error
+}
+
+func (s *HOSRepo) GetByDriver(ctx context.Context, driverId uuid.UUID, tx ...*gorm.DB) (*models.DriverHOS, error) {
+	var (
+		hos   models.DriverHOS
+		query = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Where("driver_id = ?", driverId).First(&hos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &hos, nil
+}
+
+// GetLinked returns the records that are linked to a driver of the ELD.
+func (s *HOSRepo) GetLinked(ctx context.Context) ([]models.DriverHOS, error) {
+	var records []models.DriverHOS
+
+	err := s.db.WithContext(ctx).Where("eld_driver_id <> ''").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepo struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepo(db *gorm.DB) Invoice {
+	return &InvoiceRepo{
+		db: db,
+	}
+}
+
+func (s *InvoiceRepo) Create(ctx context.Context, invoice *models.Invoice, tx ...*gorm.DB) (string, error) {
+	var (
+		id    = uuid.New()
+		query = s.db
+	)
+	invoice.Id = id
+	for i := range invoice.Lines {
+		invoice.Lines[i].Id = uuid.New()
+		invoice.Lines[i].InvoiceId = id
+	}
+
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	if err := query.WithContext(ctx).Create(invoice).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// NextSequence reserves the next invoice sequence of a company. The counter row
+// stays locked until tx finishes, so numbers are issued without gaps or repeats.
+func (s *InvoiceRepo) NextSequence(ctx context.Context, companyId uuid.UUID, tx ...*gorm.DB) (int64, error) {
+	var (
+		sequence int64
+		query    = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Raw(`
+		INSERT INTO invoice_counters (company_id, last_sequence) VALUES (?, 1)
+		ON CONFLICT (company_id) DO UPDATE SET last_sequence = invoice_counters.last_sequence + 1
+		RETURNING last_sequence
+	`, companyId).Scan(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+func (s *InvoiceRepo) UpdateStatus(ctx context.Context, invoice *models.Invoice, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	result := query.WithContext(ctx).Model(&models.Invoice{}).Where("id = ?", invoice.Id).
+		Updates(map[string]interface{}{
+			"Status":         invoice.Status,
+			"IssueDate":      invoice.IssueDate,
+			"DueDate":        invoice.DueDate,
+			"AmountPaid":     invoice.AmountPaid,
+			"ShortPaid":      invoice.ShortPaid,
+			"ShortPayReason": invoice.ShortPayReason,
+			"ShortPaidAt":    invoice.ShortPaidAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *InvoiceRepo) UpdateFactoring(ctx context.Context, invoice *models.Invoice, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	result := query.WithContext(ctx).Model(&models.Invoice{}).Where("id = ?", invoice.Id).
+		Updates(map[string]interface{}{
+			"Factored":          invoice.Factored,
+			"FactoringCompany":  invoice.FactoringCompany,
+			"FactoredAt":        invoice.FactoredAt,
+			"AdvanceAmount":     invoice.AdvanceAmount,
+			"ReserveAmount":     invoice.ReserveAmount,
+			"FactoringFee":      invoice.FactoringFee,
+			"ReserveReleasedAt": invoice.ReserveReleasedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *InvoiceRepo) AddPayment(ctx context.Context, payment *models.InvoicePayment, tx ...*gorm.DB) (string, error) {
+	var (
+		id    = uuid.New()
+		query = s.db
+	)
+	payment.Id = id
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	if err := query.WithContext(ctx).Create(payment).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *InvoiceRepo) Delete(ctx context.Context, req models.RequestId, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	if err := query.WithContext(ctx).Where("invoice_id = ?", req.Id).Delete(&models.InvoiceLine{}).Error; err != nil {
+		return err
+	}
+
+	return query.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.Invoice{}).Error
+}
+
+// Get loads an invoice with its lines and payments. Inside a transaction the
+// invoice row is locked so status and payment changes are serialized.
+func (s *InvoiceRepo) Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Invoice, error) {
+	var (
+		invoice models.Invoice
+		query   = s.db.WithContext(ctx)
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0].WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}})
+	}
+
+	err := query.Where("id = ?", req.Id).
+		Preload("Company").
+		Preload("Provider").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Payments", func(db *gorm.DB) *gorm.DB { return db.Order("paid_at ASC") }).
+		First(&invoice).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+func (s *InvoiceRepo) GetAll(ctx context.Context, req models.GetAllInvoicesReq) (*models.GetAllInvoicesResp, error) {
+	var (
+		resp   models.GetAllInvoicesResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.Invoice{})
+	)
+
+	if req.CompanyId != uuid.Nil {
+		query = query.Where("company_id = ?", req.CompanyId)
+	}
+
+	if req.ProviderId != uuid.Nil {
+		query = query.Where("provider_id = ?", req.ProviderId)
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	if req.Number != "" {
+		query = query.Where("number ILIKE ?", "%"+req.Number+"%")
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Preload("Provider").Order("created_at DESC").
+		Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Invoices).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Aging buckets the open balance of sent invoices per provider by the number
+// of days since the invoice was issued.
+func (s *InvoiceRepo) Aging(ctx context.Context, req models.GetARAgingReq) ([]models.ARAging, error) {
+	var (
+		aging []models.ARAging
+		query = s.db.WithContext(ctx).Table("invoices AS i").
+			Joins("JOIN providers AS p ON p.id = i.provider_id").
+			Where("i.deleted_at IS NULL AND i.status = ?", models.InvoiceStatusSent)
+	)
+
+	if req.CompanyId != uuid.Nil {
+		query = query.Where("i.company_id = ?", req.CompanyId)
+	}
+
+	if req.ProviderId != uuid.Nil {
+		query = query.Where("i.provider_id = ?", req.ProviderId)
+	}
+
+	err := query.Select(`
+			i.provider_id,
+			p.name AS provider_name,
+			SUM(CASE WHEN CURRENT_DATE - i.issue_date <= 30 THEN i.total - i.amount_paid - i.short_paid ELSE 0 END) AS days_0_30,
+			SUM(CASE WHEN CURRENT_DATE - i.issue_date BETWEEN 31 AND 60 THEN i.total - i.amount_paid - i.short_paid ELSE 0 END) AS days_31_60,
+			SUM(CASE WHEN CURRENT_DATE - i.issue_date BETWEEN 61 AND 90 THEN i.total - i.amount_paid - i.short_paid ELSE 0 END) AS days_61_90,
+			SUM(CASE WHEN CURRENT_DATE - i.issue_date > 90 THEN i.total - i.amount_paid - i.short_paid ELSE 0 END) AS days_90_plus,
+			SUM(i.total - i.amount_paid - i.short_paid) AS total,
+			COUNT(*) AS invoices
+		`).
+		Group("i.provider_id, p.name").
+		Order("total DESC").
+		Scan(&aging).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return aging, nil
+}
+
+// GetForLedger returns the issued invoices of a provider with their payments,
+// oldest first.
+func (s *InvoiceRepo) GetForLedger(ctx context.Context, providerId uuid.UUID) ([]models.Invoice, error) {
+	var invoices []models.Invoice
+	err := s.db.WithContext(ctx).
+		Where("provider_id = ? AND status IN ?", providerId, []string{models.InvoiceStatusSent, models.InvoiceStatusPaid}).
+		Preload("Payments", func(db *gorm.DB) *gorm.DB { return db.Order("paid_at ASC") }).
+		Order("issue_date ASC, sequence ASC").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
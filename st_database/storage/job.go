@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type JobRepo struct {
+	db *gorm.DB
+}
+
+func NewJobRepo(db *gorm.DB) Job {
+	return &JobRepo{
+		db: db,
+	}
+}
+
+func (s *JobRepo) CreateRun(ctx context.Context, run *models.JobRun) error {
+	run.Id = uuid.New()
+
+	return s.db.WithContext(ctx).Create(run).Error
+}
+
+func (s *JobRepo) FinishRun(ctx context.Context, run *models.JobRun) error {
+	return s.db.WithContext(ctx).Model(&models.JobRun{}).Where("id = ?", run.Id).
+		Updates(map[string]interface{}{
+			"Status":     run.Status,
+			"FinishedAt": run.FinishedAt,
+			"DurationMs": run.DurationMs,
+			"Error":      run.Error,
+		}).Error
+}
+
+// GetLastRuns returns the latest run of each of jobs that ran at least once.
+// Every job is read from the top of its index, however many runs it kept.
+func (s *JobRepo) GetLastRuns(ctx context.Context, jobs []string) ([]models.JobRun, error) {
+	var runs []models.JobRun
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	names, err := json.Marshal(jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Raw(`
+		SELECT r.*
+		FROM jsonb_array_elements_text(?::jsonb) AS j(name)
+		CROSS JOIN LATERAL (
+			SELECT * FROM job_runs WHERE job = j.name ORDER BY started_at DESC LIMIT 1
+		) r
+	`, string(names)).Scan(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// DeleteRuns deletes the runs of job started before before and reports how
+// many there were.
+func (s *JobRepo) DeleteRuns(ctx context.Context, job string, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("job = ? AND started_at < ?", job, before).Delete(&models.JobRun{})
+
+	return result.RowsAffected, result.Error
+}
+
+func (s *JobRepo) GetAllRuns(ctx context.Context, req models.GetAllJobRunsReq) (*models.GetAllJobRunsResp, error) {
+	var (
+		resp   models.GetAllJobRunsResp
+		query  = s.db.WithContext(ctx).Model(&models.JobRun{})
+		offset = (req.Page - 1) * req.Limit
+	)
+
+	if req.Job != "" {
+		query = query.Where("job = ?", req.Job)
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	if err := query.Count(&resp.Count).Error; err != nil {
+		return nil, err
+	}
+
+	err := query.Order("started_at DESC").Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Runs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
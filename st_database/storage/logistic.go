@@ -1,17 +1,16 @@
 package storage
 
 import (
-	"backend/etc/Utime"
 	"backend/etc/helpers"
 	"backend/models"
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"log"
+	"gorm.io/gorm/clause"
 	"strconv"
-	"sync"
+	"time"
 )
 
 type LogisticRepo struct {
@@ -57,8 +56,10 @@ func (s *LogisticRepo) Update(ctx context.Context, update *models.Logistic, tx .
 		"State":      update.State,
 		"Location":   update.Location,
 		"Emoji":      update.Emoji,
+		"Flags":      update.Flags,
 		"Notion":     update.Notion,
 		"CargoId":    update.CargoId,
+		"Version":    gorm.Expr("version + 1"),
 	}).Error
 	if err != nil {
 		return err
@@ -76,10 +77,19 @@ func (s *LogisticRepo) Delete(ctx context.Context, req models.RequestId) error {
 	return nil
 }
 
-func (s *LogisticRepo) Get(ctx context.Context, req models.RequestId) (*models.Logistic, error) {
-	var update models.Logistic
+// Get loads a logistic with its driver and cargo. Inside a transaction the
+// logistic row is locked so concurrent updates are serialized. The preloads
+// do not lock, so the cargo has to be locked through CargoRepo.Get.
+func (s *LogisticRepo) Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Logistic, error) {
+	var (
+		update models.Logistic
+		query  = s.db.WithContext(ctx)
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0].WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}})
+	}
 
-	err := s.db.WithContext(ctx).Model(&models.Logistic{}).Preload("Driver").Preload("Cargo").Where("id = ?", req.Id).First(&update).Error
+	err := query.Model(&models.Logistic{}).Preload("Driver").Preload("Cargo").Preload("Cargo.Stops", orderStops).Where("id = ?", req.Id).First(&update).Error
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +97,24 @@ func (s *LogisticRepo) Get(ctx context.Context, req models.RequestId) (*models.L
 	return &update, nil
 }
 
+// GetByCargo loads the logistic the cargo is on, with its driver.
+func (s *LogisticRepo) GetByCargo(ctx context.Context, cargoId uuid.UUID, tx ...*gorm.DB) (*models.Logistic, error) {
+	var (
+		logistic models.Logistic
+		query    = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Preload("Driver").Where("cargo_id = ?", cargoId).First(&logistic).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &logistic, nil
+}
+
 func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq) (*models.GetAllLogisticsResp, error) {
 	var (
 		resp       models.GetAllLogisticsResp
@@ -96,6 +124,10 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 		companyIds []uuid.UUID
 	)
 
+	query = query.
+		Joins("LEFT JOIN trucks ON trucks.id = drivers.truck_id AND trucks.deleted_at IS NULL").
+		Joins("LEFT JOIN trailers ON trailers.id = drivers.trailer_id AND trailers.deleted_at IS NULL")
+
 	if req.Status != "" {
 		query = query.Where("logistics.status = ?", req.Status)
 	}
@@ -132,6 +164,10 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 		query = query.Where("drivers.company_id IN (?)", req.CompanyIds)
 	}
 
+	if req.TrailerType != "" {
+		query = query.Where("trailers.type = ?", req.TrailerType)
+	}
+
 	err := query.Select(`
 					logistics.id as id,
 					logistics.post as post,
@@ -145,11 +181,15 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 					logistics.emoji as emoji,
 					logistics.cargo_id as cargo_id,
 					logistics.updated_at as updated_at,
+					logistics.version as version,
 					drivers.name as driver_name,
 					drivers.surname as driver_surname,
 					drivers.type as driver_type,
 					drivers.position as driver_position,
-					drivers.company_id as company_id
+					drivers.company_id as company_id,
+					COALESCE(trucks.number, drivers.truck_number) as truck_number,
+					COALESCE(trailers.number, '') as trailer_number,
+					COALESCE(trailers.type, '') as trailer_type
 					`).
 		Order("drivers.company_id ASC").
 		Order(`
@@ -162,6 +202,7 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 				WHEN 'ETA' THEN 6
 				WHEN 'AT DEL' THEN 7
 				WHEN 'ETA WILL BE LATE' THEN 8
+				WHEN 'ETA, WILL BE LATE' THEN 8
 				WHEN 'TRUCK ISSUES' THEN 9
 				WHEN 'CANCELLED' THEN 10
 				WHEN 'AT HOME' THEN 11
@@ -174,7 +215,7 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 		Scan(&logistics).
 		Error
 	if err != nil {
-		return nil, errors.New("cannot get all logistics")
+		return nil, fmt.Errorf("getting all logistics: %w", err)
 	}
 
 	var companyOrder []uuid.UUID
@@ -209,6 +250,10 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 		resp.Companies = append(resp.Companies, *companyMap[companyId])
 	}
 
+	if err = s.currentStops(ctx, &resp); err != nil {
+		return nil, err
+	}
+
 	helpers.CountDown(&resp)
 
 	countQuery := s.db.WithContext(ctx).Model(&models.Logistic{}).Joins("JOIN drivers ON drivers.id = logistics.driver_id")
@@ -227,6 +272,12 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 		countQuery = countQuery.Where("logistics.location = ?", req.Location)
 	}
 
+	if req.TrailerType != "" {
+		countQuery = countQuery.
+			Joins("JOIN trailers ON trailers.id = drivers.trailer_id AND trailers.deleted_at IS NULL").
+			Where("trailers.type = ?", req.TrailerType)
+	}
+
 	err = countQuery.Count(&resp.Count).Error
 	if err != nil {
 		return nil, err
@@ -235,7 +286,52 @@ func (s *LogisticRepo) GetAll(ctx context.Context, req models.GetAllLogisticsReq
 	return &resp, nil
 }
 
-func (s *LogisticRepo) Overview(ctx context.Context) (models.GetOverview, error) {
+// currentStops attaches to every board row the first stop of its cargo that
+// has not been completed yet.
+func (s *LogisticRepo) currentStops(ctx context.Context, resp *models.GetAllLogisticsResp) error {
+	var cargoIds []uuid.UUID
+	for _, company := range resp.Companies {
+		for _, logistic := range company.Logistics {
+			if logistic.CargoId != nil {
+				cargoIds = append(cargoIds, *logistic.CargoId)
+			}
+		}
+	}
+
+	if len(cargoIds) == 0 {
+		return nil
+	}
+
+	var stops []models.Stop
+	err := s.db.WithContext(ctx).Model(&models.Stop{}).
+		Where("cargo_id IN (?) AND status <> ?", cargoIds, models.StopStatusCompleted).
+		Order("cargo_id ASC").Order("sequence ASC").
+		Find(&stops).Error
+	if err != nil {
+		return err
+	}
+
+	current := map[uuid.UUID]*models.Stop{}
+	for i := range stops {
+		if _, exists := current[stops[i].CargoId]; !exists {
+			current[stops[i].CargoId] = &stops[i]
+		}
+	}
+
+	for i := range resp.Companies {
+		for j := range resp.Companies[i].Logistics {
+			logistic := &resp.Companies[i].Logistics[j]
+			if logistic.CargoId != nil {
+				logistic.CurrentStop = current[*logistic.CargoId]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Overview counts the drivers of every company by what they are doing at now.
+func (s *LogisticRepo) Overview(ctx context.Context, now time.Time) (models.GetOverview, error) {
 	var (
 		resp  models.GetOverview
 		query = s.db.WithContext(ctx).Model(&models.Logistic{}).Joins("JOIN drivers ON drivers.id = logistics.driver_id")
@@ -250,17 +346,23 @@ func (s *LogisticRepo) Overview(ctx context.Context) (models.GetOverview, error)
                 END) AS free_drivers,
             COUNT(CASE 
                 WHEN (logistics.status IN ('ETA', 'ETA, WILL BE LATE')
-											AND logistics.st_time <= NOW() + INTERVAL '1 hour') 
+											AND logistics.st_time <= @soon) 
                      OR logistics.status IN ('WILL BE READY', 'AT DEL') THEN 1 
                 END) AS will_be_soon_drivers,
             COUNT(CASE 
                 WHEN logistics.status IN ('COVERED', 'AT PU') OR (logistics.status IN ('ETA', 'ETA, WILL BE LATE')
-																		AND logistics.st_time >= NOW() + INTERVAL '1 hour') THEN 1 
+																		AND logistics.st_time >= @soon) THEN 1 
                 END) AS occupied_drivers,
             COUNT(CASE
                 WHEN logistics.status IN ('LET US KNOW', 'AT HOME') THEN 1
-                END) AS not_working
-        `).
+                END) AS not_working,
+            COUNT(CASE
+                WHEN logistics.status IN ('ETA', 'ETA, WILL BE LATE') AND logistics.st_time < @now THEN 1
+                END) AS overdue
+        `, map[string]interface{}{
+			"now":  now,
+			"soon": now.Add(time.Hour),
+		}).
 		Group("drivers.company_id").
 		Scan(&resp.Companies).Error
 
@@ -271,74 +373,106 @@ func (s *LogisticRepo) Overview(ctx context.Context) (models.GetOverview, error)
 	return resp, nil
 }
 
-func (s *LogisticRepo) Emoji(ctx context.Context) error {
-	const limit = 500
-	now := Utime.Parse(Utime.Now())
-	var (
-		offset  int
-		wg      sync.WaitGroup
-		errChan = make(chan error, 10)
-	)
+// GetOverdue returns the logistics in status, with their driver and cargo,
+// whose st_time is before now.
+func (s *LogisticRepo) GetOverdue(ctx context.Context, status string, now time.Time) ([]models.Logistic, error) {
+	var logistics []models.Logistic
 
-	for {
-		var count int64
-		err := s.db.WithContext(ctx).Model(&models.Logistic{}).
-			Where("status IN (?) AND st_time IS NOT NULL", []string{
-				"READY", "READY AT HOME", "WILL BE READY", "LET US KNOW",
-				"ETA", "ETA, WILL BE LATE", "AT DEL", "AT PU", "COVERED", "AT HOME"}).
-			Count(&count).Error
-		if err != nil {
-			return err
-		}
+	err := s.db.WithContext(ctx).Model(&models.Logistic{}).Preload("Driver").Preload("Cargo").
+		Where("status = ? AND st_time IS NOT NULL AND st_time < ?", status, now).
+		Find(&logistics).Error
+	if err != nil {
+		return nil, err
+	}
 
-		if count == 0 {
-			break
-		}
+	return logistics, nil
+}
 
-		query := `
-			UPDATE logistics
-			SET emoji = CASE
-				WHEN status IN ('READY', 'READY AT HOME') AND EXTRACT(EPOCH FROM (? - st_time)) > 86400 THEN '🗿'
-				WHEN status = 'ETA' AND EXTRACT(EPOCH FROM (st_time - ?)) < 86400 THEN '⏰'
-				WHEN status IN ('ETA, WILL BE LATE', 'ETA') AND st_time < ? THEN '❗️'
-				ELSE ''
-			END
-			WHERE id IN (
-				SELECT id FROM logistics
-				WHERE status IN ('READY', 'READY AT HOME', 'WILL BE READY', 'LET US KNOW', 
-								 'ETA', 'ETA, WILL BE LATE', 'AT DEL', 'AT PU', 'COVERED', 'AT HOME')
-				  AND st_time IS NOT NULL
-				LIMIT ? OFFSET ?
-			);
-		`
-
-		wg.Add(1)
-		go func(offset int) {
-			defer wg.Done()
-			err := s.db.WithContext(ctx).Exec(query, now, now, now, limit, offset).Error
-			if err != nil {
-				errChan <- err
-			}
-		}(offset)
+// ChangeStatus moves a logistic to status to if it is still in from, so it
+// does not overwrite a change a dispatcher made in the meantime. It reports
+// whether the logistic was moved.
+func (s *LogisticRepo) ChangeStatus(ctx context.Context, id uuid.UUID, from, to string, tx ...*gorm.DB) (bool, error) {
+	var query = s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
 
-		offset += limit
-		if offset >= int(count) {
-			break
-		}
+	result := query.WithContext(ctx).Model(&models.Logistic{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{
+			"status":  to,
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
 	}
 
-	wg.Wait()
-	close(errChan)
+	return result.RowsAffected > 0, nil
+}
+
+// RefreshFlags stores the flags the rules give every logistic at now, and the
+// icon of the first one in emoji. The rows are read once and the changed ones
+// written back with a single UPDATE, so a run sees one consistent board. A row
+// whose status, st_time or driver was edited in between is left to the next
+// run, so its flags are never computed from the values it had before.
+func (s *LogisticRepo) RefreshFlags(ctx context.Context, rules []models.FlagRule, now time.Time) error {
+	type change struct {
+		Id       uuid.UUID    `json:"id"`
+		Status   string       `json:"status"`
+		StTime   *time.Time   `json:"st_time"`
+		DriverId uuid.UUID    `json:"driver_id"`
+		Flags    models.Flags `json:"flags"`
+		Emoji    string       `json:"emoji"`
+	}
 
-	var hasError bool
-	for err := range errChan {
-		log.Println("Error updating logistics:", err)
-		hasError = true
+	var rows []struct {
+		Id        uuid.UUID
+		Status    string
+		StTime    *time.Time
+		DriverId  uuid.UUID
+		Flags     models.Flags
+		CompanyId uuid.UUID
 	}
 
-	if hasError {
-		return fmt.Errorf("one or more updates failed")
+	err := s.db.WithContext(ctx).Model(&models.Logistic{}).
+		Joins("JOIN drivers ON drivers.id = logistics.driver_id").
+		Select("logistics.id, logistics.status, logistics.st_time, logistics.driver_id, logistics.flags, drivers.company_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
 	}
 
-	return nil
+	changes := []change{}
+	for _, row := range rows {
+		flags := models.EvaluateFlags(rules, row.CompanyId, row.Status, row.StTime, now)
+		if !flags.Equal(row.Flags) {
+			changes = append(changes, change{
+				Id:       row.Id,
+				Status:   row.Status,
+				StTime:   row.StTime,
+				DriverId: row.DriverId,
+				Flags:    flags,
+				Emoji:    flags.Icon(),
+			})
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Exec(`
+		UPDATE logistics AS l
+		SET flags = c.flags, emoji = c.emoji
+		FROM jsonb_to_recordset(?::jsonb) AS c(id uuid, status text, st_time timestamp, driver_id uuid, flags jsonb, emoji text)
+		WHERE l.id = c.id
+		  AND l.status = c.status
+		  AND l.st_time IS NOT DISTINCT FROM c.st_time
+		  AND l.driver_id = c.driver_id
+	`, string(payload)).Error
 }
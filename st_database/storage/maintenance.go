@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type MaintenanceRepo struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceRepo(db *gorm.DB) Maintenance {
+	return &MaintenanceRepo{
+		db: db,
+	}
+}
+
+func (s *MaintenanceRepo) CreateRecord(ctx context.Context, record *models.MaintenanceRecord, tx ...*gorm.DB) (string, error) {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	id := uuid.New()
+	record.Id = id
+
+	if err := query.WithContext(ctx).Omit(clause.Associations).Create(record).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *MaintenanceRepo) UpdateRecord(ctx context.Context, record *models.MaintenanceRecord, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	result := query.WithContext(ctx).Model(&models.MaintenanceRecord{}).Where("id = ?", record.Id).
+		Updates(map[string]interface{}{
+			"ServiceType": record.ServiceType,
+			"Description": record.Description,
+			"Odometer":    record.Odometer,
+			"Cost":        record.Cost,
+			"Shop":        record.Shop,
+			"StartedAt":   record.StartedAt,
+			"CompletedAt": record.CompletedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *MaintenanceRepo) DeleteRecord(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.MaintenanceRecord{}).Error
+}
+
+func (s *MaintenanceRepo) GetRecord(ctx context.Context, req models.RequestId) (*models.MaintenanceRecord, error) {
+	var record models.MaintenanceRecord
+
+	err := s.db.WithContext(ctx).Preload("Truck").Where("id = ?", req.Id).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (s *MaintenanceRepo) GetAllRecords(ctx context.Context, req models.GetAllMaintenanceReq) (*models.GetAllMaintenanceRecordsResp, error) {
+	var (
+		resp   models.GetAllMaintenanceRecordsResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.MaintenanceRecord{})
+	)
+
+	if req.TruckId != uuid.Nil {
+		query = query.Where("truck_id = ?", req.TruckId)
+	}
+
+	if req.ServiceType != "" {
+		query = query.Where("service_type = ?", req.ServiceType)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Preload("Truck").Order("started_at DESC").Offset(int(offset)).Limit(int(req.Limit)).
+		Find(&resp.Records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *MaintenanceRepo) CreateSchedule(ctx context.Context, schedule *models.MaintenanceSchedule) (string, error) {
+	id := uuid.New()
+	schedule.Id = id
+
+	if err := s.db.WithContext(ctx).Omit(clause.Associations).Create(schedule).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *MaintenanceRepo) UpdateSchedule(ctx context.Context, schedule *models.MaintenanceSchedule) error {
+	result := s.db.WithContext(ctx).Model(&models.MaintenanceSchedule{}).Where("id = ?", schedule.Id).
+		Updates(map[string]interface{}{
+			"ServiceType":   schedule.ServiceType,
+			"IntervalMiles": schedule.IntervalMiles,
+			"IntervalDays":  schedule.IntervalDays,
+			"LastOdometer":  schedule.LastOdometer,
+			"LastDate":      schedule.LastDate,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *MaintenanceRepo) DeleteSchedule(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.MaintenanceSchedule{}).Error
+}
+
+func (s *MaintenanceRepo) GetAllSchedules(ctx context.Context, req models.GetAllMaintenanceReq) (*models.GetAllMaintenanceSchedulesResp, error) {
+	var (
+		resp   models.GetAllMaintenanceSchedulesResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.MaintenanceSchedule{})
+	)
+
+	if req.TruckId != uuid.Nil {
+		query = query.Where("truck_id = ?", req.TruckId)
+	}
+
+	if req.ServiceType != "" {
+		query = query.Where("service_type = ?", req.ServiceType)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Preload("Truck").Order("created_at ASC").Offset(int(offset)).Limit(int(req.Limit)).
+		Find(&resp.Schedules).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetActiveSchedules returns the schedules of all active trucks with the
+// truck preloaded.
+func (s *MaintenanceRepo) GetActiveSchedules(ctx context.Context) ([]models.MaintenanceSchedule, error) {
+	var schedules []models.MaintenanceSchedule
+
+	err := s.db.WithContext(ctx).Preload("Truck").
+		Joins("JOIN trucks ON trucks.id = maintenance_schedules.truck_id AND trucks.deleted_at IS NULL").
+		Where("trucks.status = ?", models.EquipmentStatusActive).
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// MarkServiced restarts the intervals of the truck's schedules for the given
+// service type, unless they were already restarted by a later service.
+func (s *MaintenanceRepo) MarkServiced(ctx context.Context, truckId uuid.UUID, serviceType string, odometer int64, date time.Time, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	return query.WithContext(ctx).Model(&models.MaintenanceSchedule{}).
+		Where("truck_id = ? AND service_type = ?", truckId, serviceType).
+		Where("last_date IS NULL OR last_date <= ?", date).
+		Updates(map[string]interface{}{
+			"last_odometer": gorm.Expr("GREATEST(last_odometer, ?)", odometer),
+			"last_date":     date,
+		}).Error
+}
+
+func (s *MaintenanceRepo) OpenDowntime(ctx context.Context, downtime *models.Downtime, tx ...*gorm.DB) (string, error) {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	id := uuid.New()
+	downtime.Id = id
+
+	if err := query.WithContext(ctx).Create(downtime).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// GetOpenDowntime returns the downtime of the logistic that has not ended
+// yet, locked for the rest of tx.
+func (s *MaintenanceRepo) GetOpenDowntime(ctx context.Context, logisticId uuid.UUID, tx ...*gorm.DB) (*models.Downtime, error) {
+	var (
+		downtime models.Downtime
+		query    = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("logistic_id = ? AND ended_at IS NULL", logisticId).
+		Order("started_at DESC").First(&downtime).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &downtime, nil
+}
+
+func (s *MaintenanceRepo) CloseDowntime(ctx context.Context, id uuid.UUID, endedAt time.Time, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	return query.WithContext(ctx).Model(&models.Downtime{}).Where("id = ?", id).
+		Update("ended_at", endedAt).Error
+}
+
+func (s *MaintenanceRepo) GetAllDowntimes(ctx context.Context, req models.GetAllDowntimesReq) (*models.GetAllDowntimesResp, error) {
+	var (
+		resp   models.GetAllDowntimesResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.Downtime{})
+	)
+
+	if req.TruckId != uuid.Nil {
+		query = query.Where("truck_id = ?", req.TruckId)
+	}
+
+	if req.CompanyId != uuid.Nil {
+		query = query.Where("company_id = ?", req.CompanyId)
+	}
+
+	if req.Open {
+		query = query.Where("ended_at IS NULL")
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Order("started_at DESC").Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Downtimes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DowntimeReport sums the hours of downtime that fall between req.From and
+// req.To per truck and per company. Open downtimes count until now.
+func (s *MaintenanceRepo) DowntimeReport(ctx context.Context, req models.DowntimeReportReq, now time.Time) (*models.DowntimeReport, error) {
+	var (
+		report = models.DowntimeReport{From: req.From, To: req.To}
+		hours  = gorm.Expr("COALESCE(SUM(EXTRACT(EPOCH FROM LEAST(COALESCE(downtimes.ended_at, ?), ?) - GREATEST(downtimes.started_at, ?)) / 3600), 0)",
+			now, req.To, req.From)
+	)
+
+	query := func() *gorm.DB {
+		q := s.db.WithContext(ctx).Model(&models.Downtime{}).
+			Where("downtimes.started_at < ? AND (downtimes.ended_at IS NULL OR downtimes.ended_at > ?)", req.To, req.From)
+		if req.CompanyId != uuid.Nil {
+			q = q.Where("downtimes.company_id = ?", req.CompanyId)
+		}
+		return q
+	}
+
+	err := query().Select("downtimes.truck_number, downtimes.company_id, COUNT(*) AS events, ? AS hours", hours).
+		Group("downtimes.truck_number, downtimes.company_id").
+		Order("hours DESC").Scan(&report.Trucks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query().Select("downtimes.company_id, companies.name AS company_name, COUNT(*) AS events, ? AS hours", hours).
+		Joins("LEFT JOIN companies ON companies.id = downtimes.company_id").
+		Group("downtimes.company_id, companies.name").
+		Order("hours DESC").Scan(&report.Companies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
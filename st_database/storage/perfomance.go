@@ -5,6 +5,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strconv"
 )
 
 type PerformanceRepo struct {
@@ -36,8 +37,18 @@ func (s *PerformanceRepo) Create(ctx context.Context, performance *models.Perfor
 }
 
 func (s *PerformanceRepo) Update(ctx context.Context, performance *models.Performance) error {
-	return s.db.WithContext(ctx).Model(performance).
+	err := s.db.WithContext(ctx).Model(performance).
 		Omit("Id", "EmployeeId").Updates(performance).Error
+	if err != nil {
+		return err
+	}
+
+	// Updates skips false, so saving a draft has to complete it explicitly.
+	if !performance.Draft {
+		return s.db.WithContext(ctx).Model(performance).Update("draft", false).Error
+	}
+
+	return nil
 }
 
 func (s *PerformanceRepo) Delete(ctx context.Context, req models.RequestId) error {
@@ -81,6 +92,14 @@ func (s *PerformanceRepo) GetAll(ctx context.Context, req models.GetAllPerforman
 		query = query.Where("employee_id = ?", req.EmployeeId)
 	}
 
+	if req.Draft != "" {
+		draft, err := strconv.ParseBool(req.Draft)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("draft = ?", draft)
+	}
+
 	err := query.Find(&resp.Performances).Offset(int(offset)).Limit(int(req.Limit)).Error
 	if err != nil {
 		return nil, err
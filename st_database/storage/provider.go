@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"backend/etc/helpers"
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"strings"
+)
+
+type ProviderRepo struct {
+	db *gorm.DB
+}
+
+func NewProviderRepo(db *gorm.DB) Provider {
+	return &ProviderRepo{
+		db: db,
+	}
+}
+
+func (s *ProviderRepo) Create(ctx context.Context, provider *models.Provider, tx ...*gorm.DB) (string, error) {
+	var (
+		id    = uuid.New()
+		query = s.db
+	)
+	provider.Id = id
+	provider.NormalizedName = helpers.NormalizeProvider(provider.Name)
+
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	if err := query.WithContext(ctx).Create(provider).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *ProviderRepo) Update(ctx context.Context, provider *models.Provider) error {
+	provider.NormalizedName = helpers.NormalizeProvider(provider.Name)
+
+	result := s.db.WithContext(ctx).Model(provider).Where("id = ?", provider.Id).
+		Omit("Id").Updates(map[string]interface{}{
+		"Name":           provider.Name,
+		"NormalizedName": provider.NormalizedName,
+		"MC":             provider.MC,
+		"DOT":            provider.DOT,
+		"ContactName":    provider.ContactName,
+		"ContactPhone":   provider.ContactPhone,
+		"ContactEmail":   provider.ContactEmail,
+		"PaymentTerms":   provider.PaymentTerms,
+		"CreditNotes":    provider.CreditNotes,
+		"Blacklisted":    provider.Blacklisted,
+	})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *ProviderRepo) Delete(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.Provider{}).Error
+}
+
+func (s *ProviderRepo) Get(ctx context.Context, req models.RequestId, tx ...*gorm.DB) (*models.Provider, error) {
+	var (
+		provider models.Provider
+		query    = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Where("id = ?", req.Id).First(&provider).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider, nil
+}
+
+func (s *ProviderRepo) GetAll(ctx context.Context, req models.GetAllProvidersReq) (*models.GetAllProvidersResp, error) {
+	var (
+		resp   models.GetAllProvidersResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.Provider{})
+	)
+
+	if req.Search != "" {
+		query = query.Where("name ILIKE ? OR mc = ? OR dot = ?", "%"+req.Search+"%", req.Search, req.Search)
+	}
+
+	if req.Blacklisted != "" {
+		blacklisted, err := strconv.ParseBool(req.Blacklisted)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("blacklisted = ?", blacklisted)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Order("name ASC").Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Providers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetOrCreateByName resolves a free-text provider name to a provider, creating
+// one when no provider with the same normalized name exists yet. Two bookings
+// creating the same new provider at once both end up with the one inserted.
+func (s *ProviderRepo) GetOrCreateByName(ctx context.Context, name string, tx ...*gorm.DB) (*models.Provider, error) {
+	var (
+		provider   models.Provider
+		query      = s.db
+		normalized = helpers.NormalizeProvider(name)
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Where("normalized_name = ?", normalized).First(&provider).Error
+	if err == nil {
+		return &provider, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	provider = models.Provider{
+		Id:             uuid.New(),
+		Name:           strings.TrimSpace(name),
+		NormalizedName: normalized,
+	}
+	result := query.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "normalized_name"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoNothing:   true,
+	}).Create(&provider)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return &provider, nil
+	}
+
+	provider = models.Provider{}
+	err = query.WithContext(ctx).Where("normalized_name = ?", normalized).First(&provider).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider, nil
+}
+
+func (s *ProviderRepo) Stats(ctx context.Context, req models.RequestId) (*models.ProviderStats, error) {
+	var stats models.ProviderStats
+
+	err := s.db.WithContext(ctx).Model(&models.Transaction{}).
+		Select(`
+			COUNT(*) AS loads,
+			COUNT(CASE WHEN NOT success THEN 1 END) AS cancelled_loads,
+			COALESCE(SUM(CASE WHEN success THEN cost END), 0) AS gross,
+			COALESCE(SUM(CASE WHEN success THEN cost END)::numeric / NULLIF(SUM(CASE WHEN success THEN loaded_miles END), 0), 0) AS average_rpm
+		`).
+		Where("provider_id = ?", req.Id).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats.ProviderId = req.Id
+	if stats.Loads > 0 {
+		stats.CancellationRate = float64(stats.CancelledLoads) / float64(stats.Loads)
+	}
+
+	return &stats, nil
+}
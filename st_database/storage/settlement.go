@@ -0,0 +1,323 @@
+package storage
+
+import (
+	"backend/etc/apperr"
+	"backend/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type SettlementRepo struct {
+	db *gorm.DB
+}
+
+func NewSettlementRepo(db *gorm.DB) Settlement {
+	return &SettlementRepo{
+		db: db,
+	}
+}
+
+// SavePayProfile creates the pay profile of a driver or replaces the existing one.
+func (s *SettlementRepo) SavePayProfile(ctx context.Context, profile *models.PayProfile) error {
+	var existing models.PayProfile
+	err := s.db.WithContext(ctx).Where("driver_id = ?", profile.DriverId).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		profile.Id = uuid.New()
+		return s.db.WithContext(ctx).Create(profile).Error
+	} else if err != nil {
+		return err
+	}
+
+	profile.Id = existing.Id
+	return s.db.WithContext(ctx).Model(&existing).Updates(map[string]interface{}{
+		"Method":     profile.Method,
+		"LoadedRate": profile.LoadedRate,
+		"EmptyRate":  profile.EmptyRate,
+		"Percent":    profile.Percent,
+	}).Error
+}
+
+func (s *SettlementRepo) GetPayProfile(ctx context.Context, driverId uuid.UUID) (*models.PayProfile, error) {
+	var profile models.PayProfile
+	err := s.db.WithContext(ctx).Where("driver_id = ?", driverId).First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (s *SettlementRepo) GetPayProfiles(ctx context.Context, tx ...*gorm.DB) ([]models.PayProfile, error) {
+	var (
+		profiles []models.PayProfile
+		query    = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Preload("Driver").Find(&profiles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+func (s *SettlementRepo) CreateAdjustment(ctx context.Context, adjustment *models.SettlementAdjustment) (string, error) {
+	id := uuid.New()
+	adjustment.Id = id
+
+	if err := s.db.WithContext(ctx).Create(adjustment).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// DeleteAdjustment removes an adjustment that has not been put on a locked
+// settlement yet.
+func (s *SettlementRepo) DeleteAdjustment(ctx context.Context, req models.RequestId) error {
+	result := s.db.WithContext(ctx).
+		Where("id = ?", req.Id).
+		Where("settlement_id IS NULL OR settlement_id NOT IN (?)",
+			s.db.Model(&models.Settlement{}).Select("id").Where("status = ?", models.SettlementStatusLocked)).
+		Delete(&models.SettlementAdjustment{})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return apperr.NotFound(apperr.CodeNotFound, "adjustment not found or already settled")
+	}
+
+	return nil
+}
+
+func (s *SettlementRepo) GetAllAdjustments(ctx context.Context, req models.GetAllAdjustmentsReq) (*models.GetAllAdjustmentsResp, error) {
+	var (
+		resp   models.GetAllAdjustmentsResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.SettlementAdjustment{})
+	)
+
+	if req.DriverId != uuid.Nil {
+		query = query.Where("driver_id = ?", req.DriverId)
+	}
+
+	if req.Pending {
+		query = query.Where("recurring OR settlement_id IS NULL")
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Order("date DESC").Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Adjustments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetAdjustmentsForWeek returns the adjustments that belong on a driver's
+// settlement for the week: recurring ones active during the week, one-time
+// ones dated up to the week end that no other settlement has taken yet.
+func (s *SettlementRepo) GetAdjustmentsForWeek(ctx context.Context, driverId uuid.UUID, weekStart, weekEnd time.Time, settlementId uuid.UUID, tx ...*gorm.DB) ([]models.SettlementAdjustment, error) {
+	var (
+		adjustments []models.SettlementAdjustment
+		query       = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).
+		Where("driver_id = ? AND date <= ?", driverId, weekEnd).
+		Where(s.db.
+			Where("recurring AND (end_date IS NULL OR end_date >= ?)", weekStart).
+			Or("NOT recurring AND (settlement_id IS NULL OR settlement_id = ?)", settlementId)).
+		Order("date ASC").
+		Find(&adjustments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+// GetByWeek returns the settlement of a driver for a week, locking it for the
+// rest of tx. It returns gorm.ErrRecordNotFound when there is none yet.
+func (s *SettlementRepo) GetByWeek(ctx context.Context, driverId uuid.UUID, weekStart time.Time, tx ...*gorm.DB) (*models.Settlement, error) {
+	var (
+		settlement models.Settlement
+		query      = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("driver_id = ? AND week_start = ?", driverId, weekStart).
+		First(&settlement).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &settlement, nil
+}
+
+// Save writes a draft settlement with its lines, replacing the lines of a
+// previous run, and marks its one-time adjustments as taken.
+func (s *SettlementRepo) Save(ctx context.Context, settlement *models.Settlement, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+	query = query.WithContext(ctx)
+
+	if settlement.Id == uuid.Nil {
+		settlement.Id = uuid.New()
+		if err := query.Omit(clause.Associations).Create(settlement).Error; err != nil {
+			return err
+		}
+	} else {
+		err := query.Model(&models.Settlement{}).Where("id = ?", settlement.Id).Updates(map[string]interface{}{
+			"Method":         settlement.Method,
+			"Loads":          settlement.Loads,
+			"LoadedMiles":    settlement.LoadedMiles,
+			"EmptyMiles":     settlement.EmptyMiles,
+			"Gross":          settlement.Gross,
+			"Pay":            settlement.Pay,
+			"Deductions":     settlement.Deductions,
+			"Advances":       settlement.Advances,
+			"Reimbursements": settlement.Reimbursements,
+			"NetPay":         settlement.NetPay,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err = query.Where("settlement_id = ?", settlement.Id).Delete(&models.SettlementLine{}).Error; err != nil {
+			return err
+		}
+
+		err = query.Model(&models.SettlementAdjustment{}).
+			Where("settlement_id = ? AND NOT recurring", settlement.Id).
+			Update("settlement_id", nil).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	var adjustmentIds []uuid.UUID
+	for i := range settlement.Lines {
+		settlement.Lines[i].Id = uuid.New()
+		settlement.Lines[i].SettlementId = settlement.Id
+		if settlement.Lines[i].AdjustmentId != nil {
+			adjustmentIds = append(adjustmentIds, *settlement.Lines[i].AdjustmentId)
+		}
+	}
+
+	if len(settlement.Lines) > 0 {
+		if err := query.Create(&settlement.Lines).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(adjustmentIds) > 0 {
+		err := query.Model(&models.SettlementAdjustment{}).
+			Where("id IN ? AND NOT recurring", adjustmentIds).
+			Update("settlement_id", settlement.Id).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Lock freezes a draft settlement so that later runs leave it untouched.
+func (s *SettlementRepo) Lock(ctx context.Context, req models.RequestId, lockedAt time.Time, by models.RequestId) error {
+	result := s.db.WithContext(ctx).Model(&models.Settlement{}).
+		Where("id = ? AND status = ?", req.Id, models.SettlementStatusDraft).
+		Updates(map[string]interface{}{
+			"Status":   models.SettlementStatusLocked,
+			"LockedAt": lockedAt,
+			"LockedBy": by.Id,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return apperr.Conflict("SETTLEMENT_LOCKED", "settlement not found or already locked")
+	}
+
+	return nil
+}
+
+func (s *SettlementRepo) Get(ctx context.Context, req models.RequestId) (*models.Settlement, error) {
+	var settlement models.Settlement
+	err := s.db.WithContext(ctx).Where("id = ?", req.Id).
+		Preload("Driver").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&settlement).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &settlement, nil
+}
+
+func (s *SettlementRepo) GetAll(ctx context.Context, req models.GetAllSettlementsReq) (*models.GetAllSettlementsResp, error) {
+	var (
+		resp   models.GetAllSettlementsResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.Settlement{})
+	)
+
+	if req.DriverId != uuid.Nil {
+		query = query.Where("driver_id = ?", req.DriverId)
+	}
+
+	if req.WeekStart != nil {
+		query = query.Where("week_start = ?", *req.WeekStart)
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Preload("Driver").Order("week_start DESC").
+		Offset(int(offset)).Limit(int(req.Limit)).Find(&resp.Settlements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DriversWithoutProfile returns drivers that delivered loads in [from, to) but
+// have no pay profile, so a settlement run can report them.
+func (s *SettlementRepo) DriversWithoutProfile(ctx context.Context, from, to time.Time) ([]models.Driver, error) {
+	var drivers []models.Driver
+	err := s.db.WithContext(ctx).
+		Where("id IN (?)", s.db.Model(&models.Transaction{}).Select("driver_id").
+			Where("success AND delivery_time >= ? AND delivery_time < ?", from, to)).
+		Where("id NOT IN (?)", s.db.Model(&models.PayProfile{}).Select("driver_id")).
+		Order("name ASC").
+		Find(&drivers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return drivers, nil
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"strconv"
+	"time"
 )
 
 type TransactionRepo struct {
@@ -68,6 +70,10 @@ func (t *TransactionRepo) GetAll(ctx context.Context, req models.GetAllTransReq)
 		query = query.Where("provider = ?", req.Provider)
 	}
 
+	if req.ProviderId != uuid.Nil {
+		query = query.Where("provider_id = ?", req.ProviderId)
+	}
+
 	if req.Success != "" {
 		success, err := strconv.ParseBool(req.Success)
 		if err != nil {
@@ -88,3 +94,72 @@ func (t *TransactionRepo) GetAll(ctx context.Context, req models.GetAllTransReq)
 
 	return &resp, nil
 }
+
+// GetForInvoice locks and returns the given transactions so they cannot be put
+// on two invoices at the same time.
+func (t *TransactionRepo) GetForInvoice(ctx context.Context, ids []uuid.UUID, tx ...*gorm.DB) ([]models.Transaction, error) {
+	var (
+		transactions []models.Transaction
+		query        = t.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}}).
+		Where("id IN ?", ids).Preload("Driver").Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// SetInvoice links transactions to an invoice.
+func (t *TransactionRepo) SetInvoice(ctx context.Context, ids []uuid.UUID, invoiceId uuid.UUID, tx ...*gorm.DB) error {
+	query := t.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	return query.WithContext(ctx).Model(&models.Transaction{}).Where("id IN ?", ids).
+		Update("invoice_id", invoiceId).Error
+}
+
+// ReleaseInvoice unlinks every transaction of an invoice so they can be billed again.
+func (t *TransactionRepo) ReleaseInvoice(ctx context.Context, invoiceId uuid.UUID, tx ...*gorm.DB) error {
+	query := t.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	return query.WithContext(ctx).Model(&models.Transaction{}).Where("invoice_id = ?", invoiceId).
+		Update("invoice_id", nil).Error
+}
+
+// GetUnsettled returns the successful transactions of a driver delivered
+// before until that no other settlement has paid yet. Loads entered after
+// their week was locked are picked up by the next open week.
+func (t *TransactionRepo) GetUnsettled(ctx context.Context, driverId uuid.UUID, until time.Time, settlementId uuid.UUID, tx ...*gorm.DB) ([]models.Transaction, error) {
+	var (
+		transactions []models.Transaction
+		query        = t.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).
+		Where("driver_id = ? AND success AND delivery_time < ?", driverId, until).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM settlement_lines
+			WHERE settlement_lines.transaction_id = transactions.id AND settlement_lines.settlement_id <> ?
+		)`, settlementId).
+		Order("delivery_time ASC").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"backend/models"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type WebhookRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepo(db *gorm.DB) Webhook {
+	return &WebhookRepo{
+		db: db,
+	}
+}
+
+func (s *WebhookRepo) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (string, error) {
+	id := uuid.New()
+	subscription.Id = id
+
+	if err := s.db.WithContext(ctx).Create(subscription).Error; err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// UpdateSubscription keeps the secret unless a new one is given.
+func (s *WebhookRepo) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	fields := map[string]interface{}{
+		"Name":       subscription.Name,
+		"URL":        subscription.URL,
+		"EventTypes": subscription.EventTypes,
+		"CompanyId":  subscription.CompanyId,
+		"Active":     subscription.Active,
+	}
+	if subscription.Secret != "" {
+		fields["Secret"] = subscription.Secret
+	}
+
+	result := s.db.WithContext(ctx).Model(&models.WebhookSubscription{}).Where("id = ?", subscription.Id).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *WebhookRepo) DeleteSubscription(ctx context.Context, req models.RequestId) error {
+	return s.db.WithContext(ctx).Where("id = ?", req.Id).Delete(&models.WebhookSubscription{}).Error
+}
+
+func (s *WebhookRepo) GetSubscription(ctx context.Context, req models.RequestId) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+
+	err := s.db.WithContext(ctx).Where("id = ?", req.Id).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (s *WebhookRepo) GetSubscriptions(ctx context.Context, onlyActive bool, tx ...*gorm.DB) ([]models.WebhookSubscription, error) {
+	var (
+		subscriptions []models.WebhookSubscription
+		query         = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	query = query.WithContext(ctx)
+	if onlyActive {
+		query = query.Where("active = ?", true)
+	}
+
+	if err := query.Order("name ASC").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *WebhookRepo) CreateEvent(ctx context.Context, event *models.WebhookEvent, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	event.Id = uuid.New()
+
+	return query.WithContext(ctx).Create(event).Error
+}
+
+// GetUndispatchedEvents locks the oldest events that were not fanned out yet,
+// skipping the ones another dispatcher already holds.
+func (s *WebhookRepo) GetUndispatchedEvents(ctx context.Context, limit int, tx ...*gorm.DB) ([]models.WebhookEvent, error) {
+	var (
+		events []models.WebhookEvent
+		query  = s.db
+	)
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+
+	err := query.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("dispatched_at IS NULL").Order("occurred_at ASC").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (s *WebhookRepo) MarkDispatched(ctx context.Context, ids []uuid.UUID, at time.Time, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return query.WithContext(ctx).Model(&models.WebhookEvent{}).Where("id IN ?", ids).
+		Update("dispatched_at", at).Error
+}
+
+func (s *WebhookRepo) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery, tx ...*gorm.DB) error {
+	query := s.db
+	if len(tx) > 0 && tx[0] != nil {
+		query = tx[0]
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	for i := range deliveries {
+		deliveries[i].Id = uuid.New()
+	}
+
+	return query.WithContext(ctx).Omit(clause.Associations).Create(&deliveries).Error
+}
+
+// ClaimDeliveries takes the pending deliveries that are due and moves their
+// next attempt lease into the future, so that no other dispatcher picks them
+// up while they are being sent. Event and subscription are preloaded.
+func (s *WebhookRepo) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryStatusPending, now).
+			Order("next_attempt_at ASC").Limit(limit).Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].Id
+		}
+
+		err = tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Preload("Event").Preload("Subscription", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).Where("id IN ?", ids).Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (s *WebhookRepo) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return s.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("id = ?", delivery.Id).
+		Updates(map[string]interface{}{
+			"Status":         delivery.Status,
+			"Attempts":       delivery.Attempts,
+			"NextAttemptAt":  delivery.NextAttemptAt,
+			"LastStatusCode": delivery.LastStatusCode,
+			"LastError":      delivery.LastError,
+			"DeliveredAt":    delivery.DeliveredAt,
+		}).Error
+}
+
+func (s *WebhookRepo) GetDelivery(ctx context.Context, req models.RequestId) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+
+	err := s.db.WithContext(ctx).Preload("Event").Where("id = ?", req.Id).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (s *WebhookRepo) GetAllDeliveries(ctx context.Context, req models.GetAllDeliveriesReq) (*models.GetAllDeliveriesResp, error) {
+	var (
+		resp   models.GetAllDeliveriesResp
+		offset = (req.Page - 1) * req.Limit
+		query  = s.db.WithContext(ctx).Model(&models.WebhookDelivery{})
+	)
+
+	if req.SubscriptionId != uuid.Nil {
+		query = query.Where("subscription_id = ?", req.SubscriptionId)
+	}
+
+	if req.EventId != uuid.Nil {
+		query = query.Where("event_id = ?", req.EventId)
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	err := query.Count(&resp.Count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Preload("Event").Order("created_at DESC").Offset(int(offset)).Limit(int(req.Limit)).
+		Find(&resp.Deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}